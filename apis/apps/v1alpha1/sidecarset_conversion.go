@@ -82,6 +82,7 @@ func (scs *SidecarSet) ConvertTo(dst conversion.Hub) error {
 			UpdatedReadyPods:   scs.Status.UpdatedReadyPods,
 			LatestRevision:     scs.Status.LatestRevision,
 			CollisionCount:     scs.Status.CollisionCount,
+			NamespaceStatuses:  convertNamespaceStatusesToV1Beta1(scs.Status.NamespaceStatuses),
 		}
 
 		return nil
@@ -131,6 +132,7 @@ func (scs *SidecarSet) ConvertFrom(src conversion.Hub) error {
 			UpdatedReadyPods:   scsv1beta1.Status.UpdatedReadyPods,
 			LatestRevision:     scsv1beta1.Status.LatestRevision,
 			CollisionCount:     scsv1beta1.Status.CollisionCount,
+			NamespaceStatuses:  convertNamespaceStatusesToV1Alpha1(scsv1beta1.Status.NamespaceStatuses),
 		}
 
 		return nil
@@ -181,6 +183,7 @@ func convertUpgradeStrategyToV1Beta1(strategy SidecarContainerUpgradeStrategy) v
 	return v1beta1.SidecarContainerUpgradeStrategy{
 		UpgradeType:          v1beta1.SidecarContainerUpgradeType(strategy.UpgradeType),
 		HotUpgradeEmptyImage: strategy.HotUpgradeEmptyImage,
+		HandoffProbe:         convertHandoffProbeToV1Beta1(strategy.HandoffProbe),
 	}
 }
 
@@ -188,6 +191,29 @@ func convertUpgradeStrategyToV1Alpha1(strategy v1beta1.SidecarContainerUpgradeSt
 	return SidecarContainerUpgradeStrategy{
 		UpgradeType:          SidecarContainerUpgradeType(strategy.UpgradeType),
 		HotUpgradeEmptyImage: strategy.HotUpgradeEmptyImage,
+		HandoffProbe:         convertHandoffProbeToV1Alpha1(strategy.HandoffProbe),
+	}
+}
+
+func convertHandoffProbeToV1Beta1(probe *SidecarContainerHandoffProbe) *v1beta1.SidecarContainerHandoffProbe {
+	if probe == nil {
+		return nil
+	}
+	return &v1beta1.SidecarContainerHandoffProbe{
+		HTTPGet:        probe.HTTPGet,
+		TimeoutSeconds: probe.TimeoutSeconds,
+		PeriodSeconds:  probe.PeriodSeconds,
+	}
+}
+
+func convertHandoffProbeToV1Alpha1(probe *v1beta1.SidecarContainerHandoffProbe) *SidecarContainerHandoffProbe {
+	if probe == nil {
+		return nil
+	}
+	return &SidecarContainerHandoffProbe{
+		HTTPGet:        probe.HTTPGet,
+		TimeoutSeconds: probe.TimeoutSeconds,
+		PeriodSeconds:  probe.PeriodSeconds,
 	}
 }
 
@@ -271,15 +297,17 @@ func convertSourceContainerNameSourceToV1Alpha1(source *v1beta1.SourceContainerN
 
 func convertInjectionStrategyToV1Beta1(strategy SidecarSetInjectionStrategy) v1beta1.SidecarSetInjectionStrategy {
 	return v1beta1.SidecarSetInjectionStrategy{
-		Paused:   strategy.Paused,
-		Revision: convertInjectRevisionToV1Beta1(strategy.Revision),
+		Paused:        strategy.Paused,
+		Revision:      convertInjectRevisionToV1Beta1(strategy.Revision),
+		NativeSidecar: strategy.NativeSidecar,
 	}
 }
 
 func convertInjectionStrategyToV1Alpha1(strategy v1beta1.SidecarSetInjectionStrategy) SidecarSetInjectionStrategy {
 	return SidecarSetInjectionStrategy{
-		Paused:   strategy.Paused,
-		Revision: convertInjectRevisionToV1Alpha1(strategy.Revision),
+		Paused:        strategy.Paused,
+		Revision:      convertInjectRevisionToV1Alpha1(strategy.Revision),
+		NativeSidecar: strategy.NativeSidecar,
 	}
 }
 
@@ -291,6 +319,7 @@ func convertInjectRevisionToV1Beta1(revision *SidecarSetInjectRevision) *v1beta1
 		CustomVersion: revision.CustomVersion,
 		RevisionName:  revision.RevisionName,
 		Policy:        v1beta1.SidecarSetInjectRevisionPolicy(revision.Policy),
+		Weight:        revision.Weight,
 	}
 }
 
@@ -302,6 +331,7 @@ func convertInjectRevisionToV1Alpha1(revision *v1beta1.SidecarSetInjectRevision)
 		CustomVersion: revision.CustomVersion,
 		RevisionName:  revision.RevisionName,
 		Policy:        SidecarSetInjectRevisionPolicy(revision.Policy),
+		Weight:        revision.Weight,
 	}
 }
 
@@ -314,6 +344,7 @@ func convertUpdateStrategyToV1Beta1(strategy SidecarSetUpdateStrategy) v1beta1.S
 		MaxUnavailable:   strategy.MaxUnavailable,
 		PriorityStrategy: strategy.PriorityStrategy,
 		ScatterStrategy:  convertScatterStrategyToV1Beta1(strategy.ScatterStrategy),
+		NamespaceWaves:   convertNamespaceWavesToV1Beta1(strategy.NamespaceWaves),
 	}
 }
 
@@ -326,6 +357,7 @@ func convertUpdateStrategyToV1Alpha1(strategy v1beta1.SidecarSetUpdateStrategy)
 		MaxUnavailable:   strategy.MaxUnavailable,
 		PriorityStrategy: strategy.PriorityStrategy,
 		ScatterStrategy:  convertScatterStrategyToV1Alpha1(strategy.ScatterStrategy),
+		NamespaceWaves:   convertNamespaceWavesToV1Alpha1(strategy.NamespaceWaves),
 	}
 }
 
@@ -357,6 +389,60 @@ func convertScatterStrategyToV1Alpha1(strategy v1beta1.UpdateScatterStrategy) Up
 	return result
 }
 
+func convertNamespaceWavesToV1Beta1(waves []SidecarSetNamespaceWave) []v1beta1.SidecarSetNamespaceWave {
+	if waves == nil {
+		return nil
+	}
+	result := make([]v1beta1.SidecarSetNamespaceWave, len(waves))
+	for i, wave := range waves {
+		result[i] = v1beta1.SidecarSetNamespaceWave{
+			Namespaces:     wave.Namespaces,
+			Paused:         wave.Paused,
+			Partition:      wave.Partition,
+			MaxUnavailable: wave.MaxUnavailable,
+		}
+	}
+	return result
+}
+
+func convertNamespaceWavesToV1Alpha1(waves []v1beta1.SidecarSetNamespaceWave) []SidecarSetNamespaceWave {
+	if waves == nil {
+		return nil
+	}
+	result := make([]SidecarSetNamespaceWave, len(waves))
+	for i, wave := range waves {
+		result[i] = SidecarSetNamespaceWave{
+			Namespaces:     wave.Namespaces,
+			Paused:         wave.Paused,
+			Partition:      wave.Partition,
+			MaxUnavailable: wave.MaxUnavailable,
+		}
+	}
+	return result
+}
+
+func convertNamespaceStatusesToV1Beta1(statuses []SidecarSetNamespaceStatus) []v1beta1.SidecarSetNamespaceStatus {
+	if statuses == nil {
+		return nil
+	}
+	result := make([]v1beta1.SidecarSetNamespaceStatus, len(statuses))
+	for i, status := range statuses {
+		result[i] = v1beta1.SidecarSetNamespaceStatus(status)
+	}
+	return result
+}
+
+func convertNamespaceStatusesToV1Alpha1(statuses []v1beta1.SidecarSetNamespaceStatus) []SidecarSetNamespaceStatus {
+	if statuses == nil {
+		return nil
+	}
+	result := make([]SidecarSetNamespaceStatus, len(statuses))
+	for i, status := range statuses {
+		result[i] = SidecarSetNamespaceStatus(status)
+	}
+	return result
+}
+
 func convertPatchPodMetadataToV1Beta1(metadata []SidecarSetPatchPodMetadata) []v1beta1.SidecarSetPatchPodMetadata {
 	if metadata == nil {
 		return nil
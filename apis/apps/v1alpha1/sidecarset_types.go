@@ -278,6 +278,29 @@ type SidecarContainerUpgradeStrategy struct {
 	// HotUpgradeEmptyImage is consistent of sidecar container in Command, Args, Liveness probe, etc.
 	// but it does no actual work.
 	HotUpgradeEmptyImage string `json:"hotUpgradeEmptyImage,omitempty"`
+
+	// when HotUpgrade, HandoffProbe is used to confirm that the new sidecar container has taken over
+	// the work of the older one. If it is set, HotUpgradeEmptyImage is not needed: the older sidecar container
+	// is reset to the latest image as the standby only after the handoff probe succeeds.
+	// +optional
+	HandoffProbe *SidecarContainerHandoffProbe `json:"handoffProbe,omitempty"`
+}
+
+// SidecarContainerHandoffProbe is an HTTP endpoint polled on the pod after the hot upgrade container is upgraded.
+// The handoff is considered succeeded once the endpoint responds with a 2xx status code.
+// The standby sidecar container is started with SIDECARSET_VERSION "0", so that it should not do any actual work.
+type SidecarContainerHandoffProbe struct {
+	// HTTPGet specifies the endpoint to call on the pod IP.
+	HTTPGet corev1.HTTPGetAction `json:"httpGet"`
+
+	// TimeoutSeconds is the timeout of each call. Defaults to 1. Maximum value is 30.
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// PeriodSeconds is the interval between the calls on each pod. Defaults to 5.
+	// The time of the last call is recorded in the pod annotations.
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
 }
 
 // SidecarSetInjectionStrategy indicates the injection strategy of SidecarSet.
@@ -292,6 +315,15 @@ type SidecarSetInjectionStrategy struct {
 	// this filed, SidecarSet will try to inject specific revision according to
 	// different policies.
 	Revision *SidecarSetInjectRevision `json:"revision,omitempty"`
+
+	// NativeSidecar indicates SidecarSet to inject the containers as Kubernetes native sidecars,
+	// which are initContainers with restartPolicy Always, if the cluster supports them (Kubernetes 1.29+).
+	// The native sidecars are started before and stopped after the app containers, so that Jobs can complete
+	// without SidecarTerminator. The podInjectPolicy of containers decides whether they are injected
+	// before or after the initContainers of pods.
+	// Otherwise, the containers are injected as normal containers.
+	// +optional
+	NativeSidecar bool `json:"nativeSidecar,omitempty"`
 }
 
 type SidecarSetInjectRevision struct {
@@ -304,9 +336,15 @@ type SidecarSetInjectRevision struct {
 	// + optional
 	RevisionName *string `json:"revisionName,omitempty"`
 	// Policy describes the behavior of revision injection.
-	// +kubebuilder:validation:Enum=Always;Partial;
+	// +kubebuilder:validation:Enum=Always;Partial;Weighted;
 	// +kubebuilder:default=Always
 	Policy SidecarSetInjectRevisionPolicy `json:"policy,omitempty"`
+	// Weight is the percentage of newly created Pods to be injected with the latest revision,
+	// while the other Pods are injected with the specific revision. It only works with the Weighted policy.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// + optional
+	Weight *int32 `json:"weight,omitempty"`
 }
 
 type SidecarSetInjectRevisionPolicy string
@@ -324,6 +362,15 @@ const (
 	// where the probability is `1 - UpdateStrategy.Partition`.
 	// If `Partition` is not a percentage or is not configured, its value is considered to be 0%.
 	PartialSidecarSetInjectRevisionPolicy SidecarSetInjectRevisionPolicy = "Partial"
+
+	// WeightedSidecarSetInjectRevisionPolicy means the SidecarSet will inject the latest revision to the percentage of
+	// newly created Pods specified by Weight, and the specific revision to the others.
+	//
+	// Pods are divided deterministically by the hash of their controller owner, so that all the Pods created by
+	// the same workload are injected with the same revision, and increasing the Weight only moves more workloads
+	// to the latest revision without restarting the ones already injected.
+	// If UpdateStrategy.Pause is true, the specific revision will be injected to all the Pods.
+	WeightedSidecarSetInjectRevisionPolicy SidecarSetInjectRevisionPolicy = "Weighted"
 )
 
 // SidecarSetUpdateStrategy indicates the strategy that the SidecarSet
@@ -368,6 +415,30 @@ type SidecarSetUpdateStrategy struct {
 	// - Note that pods will be scattered after priority sort. So, although priority strategy and scatter strategy can be applied together, we suggest to use either one of them.
 	// - If scatterStrategy is used, we suggest to just use one term. Otherwise, the update order can be hard to understand.
 	ScatterStrategy UpdateScatterStrategy `json:"scatterStrategy,omitempty"`
+
+	// NamespaceWaves, if not empty, updates the pods namespace by namespace in the order of waves.
+	// The next wave is not started until the pods in the previous waves have been updated and ready, except for
+	// the pods kept in old revisions by the partition of the waves.
+	// Pods in the namespaces not listed in any wave are updated in the last wave, with the Partition and MaxUnavailable above.
+	NamespaceWaves []SidecarSetNamespaceWave `json:"namespaceWaves,omitempty"`
+}
+
+// SidecarSetNamespaceWave defines a wave of namespaces to update in the rolling update.
+type SidecarSetNamespaceWave struct {
+	// Namespaces are the namespaces to update in this wave.
+	Namespaces []string `json:"namespaces"`
+
+	// Paused indicates that the pods in this wave and the following waves are paused to update.
+	Paused bool `json:"paused,omitempty"`
+
+	// Partition is the desired number of pods in old revisions in this wave.
+	// Default value is 0.
+	Partition *intstr.IntOrString `json:"partition,omitempty"`
+
+	// The maximum number of pods in this wave that can be unavailable during the update.
+	// Value can be an absolute number (ex: 5) or a percentage of the pods in this wave (ex: 10%).
+	// Defaults to the MaxUnavailable of the update strategy.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 type SidecarSetUpdateStrategyType string
@@ -402,6 +473,33 @@ type SidecarSetStatus struct {
 	// uses this field as a collision avoidance mechanism when it needs to create the name for the
 	// newest ControllerRevision.
 	CollisionCount *int32 `json:"collisionCount,omitempty"`
+
+	// NamespaceStatuses is the status of the matched pods in each namespace listed in updateStrategy.namespaceWaves,
+	// followed by the aggregated status of the pods in the other namespaces, whose namespace is "*".
+	// It is only recorded if the updateStrategy.namespaceWaves is set.
+	NamespaceStatuses []SidecarSetNamespaceStatus `json:"namespaceStatuses,omitempty"`
+}
+
+// SidecarSetOtherNamespaces is the namespace of the status aggregating the pods in the namespaces
+// not listed in updateStrategy.namespaceWaves.
+const SidecarSetOtherNamespaces = "*"
+
+// SidecarSetNamespaceStatus defines the observed state of the matched pods in a namespace.
+type SidecarSetNamespaceStatus struct {
+	// Namespace is the namespace of the pods.
+	Namespace string `json:"namespace"`
+
+	// matchedPods is the number of matched Pods in the namespace
+	MatchedPods int32 `json:"matchedPods"`
+
+	// updatedPods is the number of matched Pods in the namespace that are injected with the latest SidecarSet's containers
+	UpdatedPods int32 `json:"updatedPods"`
+
+	// readyPods is the number of matched Pods in the namespace that have a ready condition
+	ReadyPods int32 `json:"readyPods"`
+
+	// updatedReadyPods is the number of matched pods in the namespace that updated and ready
+	UpdatedReadyPods int32 `json:"updatedReadyPods,omitempty"`
 }
 
 // +genclient
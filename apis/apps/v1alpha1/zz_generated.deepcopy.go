@@ -2786,7 +2786,7 @@ func (in *ShareVolumePolicy) DeepCopy() *ShareVolumePolicy {
 func (in *SidecarContainer) DeepCopyInto(out *SidecarContainer) {
 	*out = *in
 	in.Container.DeepCopyInto(&out.Container)
-	out.UpgradeStrategy = in.UpgradeStrategy
+	in.UpgradeStrategy.DeepCopyInto(&out.UpgradeStrategy)
 	out.ShareVolumePolicy = in.ShareVolumePolicy
 	if in.ShareVolumeDevicePolicy != nil {
 		in, out := &in.ShareVolumeDevicePolicy, &out.ShareVolumeDevicePolicy
@@ -2817,9 +2817,30 @@ func (in *SidecarContainer) DeepCopy() *SidecarContainer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarContainerHandoffProbe) DeepCopyInto(out *SidecarContainerHandoffProbe) {
+	*out = *in
+	in.HTTPGet.DeepCopyInto(&out.HTTPGet)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarContainerHandoffProbe.
+func (in *SidecarContainerHandoffProbe) DeepCopy() *SidecarContainerHandoffProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarContainerHandoffProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SidecarContainerUpgradeStrategy) DeepCopyInto(out *SidecarContainerUpgradeStrategy) {
 	*out = *in
+	if in.HandoffProbe != nil {
+		in, out := &in.HandoffProbe, &out.HandoffProbe
+		*out = new(SidecarContainerHandoffProbe)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarContainerUpgradeStrategy.
@@ -2872,6 +2893,11 @@ func (in *SidecarSetInjectRevision) DeepCopyInto(out *SidecarSetInjectRevision)
 		*out = new(string)
 		**out = **in
 	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetInjectRevision.
@@ -2936,6 +2962,51 @@ func (in *SidecarSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarSetNamespaceStatus) DeepCopyInto(out *SidecarSetNamespaceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetNamespaceStatus.
+func (in *SidecarSetNamespaceStatus) DeepCopy() *SidecarSetNamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarSetNamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarSetNamespaceWave) DeepCopyInto(out *SidecarSetNamespaceWave) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetNamespaceWave.
+func (in *SidecarSetNamespaceWave) DeepCopy() *SidecarSetNamespaceWave {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarSetNamespaceWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SidecarSetPatchPodMetadata) DeepCopyInto(out *SidecarSetPatchPodMetadata) {
 	*out = *in
@@ -3031,6 +3102,11 @@ func (in *SidecarSetStatus) DeepCopyInto(out *SidecarSetStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.NamespaceStatuses != nil {
+		in, out := &in.NamespaceStatuses, &out.NamespaceStatuses
+		*out = make([]SidecarSetNamespaceStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetStatus.
@@ -3071,6 +3147,13 @@ func (in *SidecarSetUpdateStrategy) DeepCopyInto(out *SidecarSetUpdateStrategy)
 		*out = make(UpdateScatterStrategy, len(*in))
 		copy(*out, *in)
 	}
+	if in.NamespaceWaves != nil {
+		in, out := &in.NamespaceWaves, &out.NamespaceWaves
+		*out = make([]SidecarSetNamespaceWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetUpdateStrategy.
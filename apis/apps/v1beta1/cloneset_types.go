@@ -35,6 +35,35 @@ const (
 	// CloneSetScalingExcludePreparingDeleteKey is the label key that enables scalingExcludePreparingDelete
 	// only for this CloneSet, which means it will calculate scale number excluding Pods in PreparingDelete state.
 	CloneSetScalingExcludePreparingDeleteKey = "apps.kruise.io/cloneset-scaling-exclude-preparing-delete"
+
+	// CloneSetRollbackToRevisionAnnotation is the annotation to roll back CloneSet to a revision in history,
+	// which works the same as spec.rollbackTo.revision and can also be used for v1alpha1.
+	CloneSetRollbackToRevisionAnnotation = "apps.kruise.io/rollback-to-revision"
+
+	// CloneSetStandbyLabelKey is the label of standby Pods, which are created in advance but not serving.
+	CloneSetStandbyLabelKey = "apps.kruise.io/cloneset-standby"
+
+	// CloneSetPodUpdateHoldAnnotation is the annotation on Pod to hold it from being updated by CloneSet,
+	// whose value is a JSON of CloneSetPodUpdateHold.
+	CloneSetPodUpdateHoldAnnotation = "apps.kruise.io/update-hold"
+)
+
+// CloneSetPodUpdateHold is the value of CloneSetPodUpdateHoldAnnotation.
+// A held Pod will not be updated in-place or recreated, and it is not counted in maxUnavailable of updating.
+type CloneSetPodUpdateHold struct {
+	// Reason is why the Pod is held, such as it is being debugged.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// ExpireTime is the time when the hold lifts itself.
+	// If not set, the Pod is held until the annotation is removed.
+	// +optional
+	ExpireTime *metav1.Time `json:"expireTime,omitempty"`
+}
+
+const (
+	// CloneSetStandbyPromoted is the readiness gate of standby Pods. It keeps a standby Pod out of
+	// Service endpoints until the Pod is promoted to serve.
+	CloneSetStandbyPromoted v1.PodConditionType = "CloneSetStandbyPromoted"
 )
 
 // CloneSetSpec defines the desired state of CloneSet
@@ -61,6 +90,10 @@ type CloneSetSpec struct {
 	// +kubebuilder:validation:Schemaless
 	VolumeClaimTemplates []v1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
 
+	// VolumeClaimUpdateStrategy specifies how the existing PVCs are updated when volumeClaimTemplates changes.
+	// +optional
+	VolumeClaimUpdateStrategy CloneSetVolumeClaimUpdateStrategy `json:"volumeClaimUpdateStrategy,omitempty"`
+
 	// ScaleStrategy indicates the ScaleStrategy that will be employed to
 	// create and delete Pods in the CloneSet.
 	ScaleStrategy CloneSetScaleStrategy `json:"scaleStrategy,omitempty"`
@@ -88,8 +121,65 @@ type CloneSetSpec struct {
 	// condition when timeout occurs, while excluding paused state duration from the deadline calculation.
 	// This field is optional. If not set, the controller will not track progress deadlines or add the condition.
 	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// ProgressDeadlineExceededPolicy indicates what the controller does to the update once the CloneSet
+	// exceeds progressDeadlineSeconds. It can be "None", "Pause" or "RollbackPartition". Defaults to None.
+	// Pause sets updateStrategy.rollingUpdate.paused to true, and RollbackPartition sets
+	// updateStrategy.rollingUpdate.partition to 100%, which also rolls back the updated pods
+	// if CloneSetPartitionRollback feature-gate is enabled.
+	// RollbackPartition can not be used with updateStrategy.rollingUpdate.canarySteps, which manage the partition.
+	// +optional
+	ProgressDeadlineExceededPolicy CloneSetProgressDeadlineExceededPolicyType `json:"progressDeadlineExceededPolicy,omitempty"`
+
+	// RollbackTo is the config that this CloneSet is rolling back to. It will be cleared after the rollback starts.
+	// The template will be restored from the ControllerRevision of the specified revision,
+	// and pods are updated to it following the updateStrategy, including partition and maxUnavailable.
+	// +optional
+	RollbackTo *CloneSetRollbackConfig `json:"rollbackTo,omitempty"`
 }
 
+// CloneSetRollbackConfig specifies the revision to roll back to.
+type CloneSetRollbackConfig struct {
+	// The revision number of ControllerRevision to roll back to.
+	// If set to 0, roll back to the last revision before the current template.
+	// +optional
+	Revision int64 `json:"revision,omitempty"`
+}
+
+// CloneSetVolumeClaimUpdateStrategyType defines how the existing PVCs are updated.
+type CloneSetVolumeClaimUpdateStrategyType string
+
+const (
+	// OnDeleteCloneSetVolumeClaimUpdateStrategyType keeps the existing PVCs unchanged,
+	// and only the PVCs created later follow the new volumeClaimTemplates.
+	OnDeleteCloneSetVolumeClaimUpdateStrategyType CloneSetVolumeClaimUpdateStrategyType = "OnDelete"
+	// InPlaceExpandCloneSetVolumeClaimUpdateStrategyType expands the existing bound PVCs in place when only
+	// the storage requests in volumeClaimTemplates grow, without recreating Pods.
+	InPlaceExpandCloneSetVolumeClaimUpdateStrategyType CloneSetVolumeClaimUpdateStrategyType = "InPlaceExpand"
+)
+
+// CloneSetVolumeClaimUpdateStrategy defines the strategy for updating the existing PVCs.
+type CloneSetVolumeClaimUpdateStrategy struct {
+	// Type can be "OnDelete" or "InPlaceExpand". Defaults to OnDelete.
+	// For InPlaceExpand, the storage class of PVC must allow volume expansion, and the PVCs with
+	// any other difference from templates are left unchanged. Changing only the storage requests does not
+	// recreate Pods for InPlaceExpand, even if RecreatePodWhenChangeVCTInCloneSetGate is enabled.
+	// +optional
+	Type CloneSetVolumeClaimUpdateStrategyType `json:"type,omitempty"`
+}
+
+// CloneSetProgressDeadlineExceededPolicyType defines what to do once the progress deadline is exceeded.
+type CloneSetProgressDeadlineExceededPolicyType string
+
+const (
+	// NoneProgressDeadlineExceededPolicyType only sets the ProgressDeadlineExceeded condition.
+	NoneProgressDeadlineExceededPolicyType CloneSetProgressDeadlineExceededPolicyType = "None"
+	// PauseProgressDeadlineExceededPolicyType pauses the update.
+	PauseProgressDeadlineExceededPolicyType CloneSetProgressDeadlineExceededPolicyType = "Pause"
+	// RollbackPartitionProgressDeadlineExceededPolicyType sets the partition to 100%.
+	RollbackPartitionProgressDeadlineExceededPolicyType CloneSetProgressDeadlineExceededPolicyType = "RollbackPartition"
+)
+
 // CloneSetScaleStrategy defines strategies for pods scale.
 type CloneSetScaleStrategy struct {
 	// PodsToDelete is the names of Pod should be deleted.
@@ -115,6 +205,65 @@ type CloneSetScaleStrategy struct {
 	// Default is false.
 	// +optional
 	ExcludePreparingDelete bool `json:"excludePreparingDelete,omitempty"`
+
+	// ScaleDownPolicy indicates how CloneSet chooses pods to delete when scaling in.
+	// If not set, pods are sorted by their state, deletion-cost and the topology spread constraints in template.
+	// +optional
+	ScaleDownPolicy *CloneSetScaleDownPolicy `json:"scaleDownPolicy,omitempty"`
+
+	// StandbyReplicas is the number of standby Pods, which are created with the update revision in advance
+	// but kept out of Service endpoints by a readiness gate. They are not counted in replicas.
+	// When scaling out, standby Pods that are already running and ready will be promoted to serve first,
+	// and then the standby pool will be topped up again.
+	// Defaults to 0.
+	// +optional
+	StandbyReplicas *int32 `json:"standbyReplicas,omitempty"`
+
+	// InstanceIDPolicy indicates how CloneSet generates the instance-id for new Pods, which is also the suffix of Pod name.
+	// Random (default) generates a random string for each new Pod.
+	// Ordinal allocates the smallest non-negative integer that is not held by the other Pods, so the instance-ids
+	// come from a bounded pool and get reused after Pods are deleted, such as sample-0, sample-1.
+	// With enablePVCReuse, the instance-ids of the PVCs left by the previous Pods are allocated first,
+	// so that the new Pods reuse these PVCs.
+	// +optional
+	InstanceIDPolicy CloneSetInstanceIDPolicyType `json:"instanceIDPolicy,omitempty"`
+}
+
+// CloneSetInstanceIDPolicyType defines how to generate instance-id for new Pods.
+type CloneSetInstanceIDPolicyType string
+
+const (
+	// RandomInstanceIDPolicyType generates a random string as instance-id.
+	RandomInstanceIDPolicyType CloneSetInstanceIDPolicyType = "Random"
+	// OrdinalInstanceIDPolicyType allocates the smallest free non-negative integer as instance-id.
+	OrdinalInstanceIDPolicyType CloneSetInstanceIDPolicyType = "Ordinal"
+)
+
+// CloneSetScaleDownPolicyType defines the type of scale down policy.
+type CloneSetScaleDownPolicyType string
+
+const (
+	// DefaultScaleDownPolicyType sorts pods by their state, deletion-cost and topology ranks, and deletes the smallest ones.
+	DefaultScaleDownPolicyType CloneSetScaleDownPolicyType = "Default"
+	// TopologySpreadScaleDownPolicyType chooses pods one by one from the largest topology domains,
+	// so that the skew of pods among domains is kept as low as possible after scaling in.
+	TopologySpreadScaleDownPolicyType CloneSetScaleDownPolicyType = "TopologySpread"
+)
+
+// CloneSetScaleDownPolicy defines how to choose pods to delete when scaling in.
+type CloneSetScaleDownPolicy struct {
+	// Type of scale down policy. Can be "Default" or "TopologySpread". Default is Default.
+	// +optional
+	Type CloneSetScaleDownPolicyType `json:"type,omitempty"`
+
+	// TopologyKeys are the node label keys that pods should be spread across when TopologySpread type is used,
+	// such as topology.kubernetes.io/zone.
+	// Defaults to the topology keys of topologySpreadConstraints in template.
+	// When several keys are set, a pod that decreases the skew of more keys will be deleted first.
+	// Pods in the same domain are still sorted by their state and deletion-cost, so not-ready pods
+	// and pods with lower deletion-cost are deleted first.
+	// +optional
+	TopologyKeys []string `json:"topologyKeys,omitempty"`
 }
 
 // RollingUpdateCloneSetStrategy is used to communicate parameter for RollingUpdateCloneSetStrategy.
@@ -153,6 +302,64 @@ type RollingUpdateCloneSetStrategy struct {
 	ScatterStrategy UpdateScatterStrategy `json:"scatterStrategy,omitempty"`
 	// InPlaceUpdateStrategy contains strategies for in-place update.
 	InPlaceUpdateStrategy *appspub.InPlaceUpdateStrategy `json:"inPlaceUpdateStrategy,omitempty"`
+	// CanarySteps is an ordered list of steps that the controller works through on its own during an update.
+	// When it is not empty, Partition is ignored and the partition of the current step is used instead.
+	// If the check of a step fails, the rollout stops and the partition is reset to keep all pods
+	// in the stable revision recorded in status.canaryStatus.
+	// Note that pods already updated will be rolled back only if CloneSetPartitionRollback is enabled.
+	// +optional
+	CanarySteps []CloneSetCanaryStep `json:"canarySteps,omitempty"`
+	// EndpointReadinessGate makes the rolling update count a Pod as available only if it is also ready
+	// in the EndpointSlices of the given Service, in addition to the Pod readiness and minReadySeconds.
+	// So the next Pods will not be updated until the updated Pods have actually joined the Service.
+	// Note that Pods not selected by the Service are always treated as unavailable.
+	// +optional
+	EndpointReadinessGate *CloneSetEndpointReadinessGate `json:"endpointReadinessGate,omitempty"`
+}
+
+// CloneSetEndpointReadinessGate defines the Service whose endpoints are checked during rolling update.
+type CloneSetEndpointReadinessGate struct {
+	// ServiceName is the name of the Service in the same namespace of CloneSet.
+	ServiceName string `json:"serviceName"`
+}
+
+// CloneSetCanaryStep defines a single step of the canary rollout.
+type CloneSetCanaryStep struct {
+	// Partition is the desired number of pods in old revisions in this step.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	Partition intstr.IntOrString `json:"partition"`
+	// PauseSeconds is the time to wait after the pods of this step are available,
+	// before the check runs and the rollout moves to the next step.
+	// +optional
+	PauseSeconds int32 `json:"pauseSeconds,omitempty"`
+	// Check is an optional gate that must pass before the rollout moves to the next step.
+	// +optional
+	Check *CloneSetCanaryCheck `json:"check,omitempty"`
+}
+
+// CloneSetCanaryCheck defines the gate of a canary step.
+type CloneSetCanaryCheck struct {
+	// HTTPGet specifies the http request to perform, e.g. to a metric analysis service.
+	// The check passes if the response code is greater than or equal to 200 and less than 300.
+	// Redirects are not followed.
+	HTTPGet *CloneSetCanaryHTTPGetAction `json:"httpGet,omitempty"`
+	// Number of seconds after which the check times out.
+	// Defaults to 1 second. Maximum value is 30.
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// Minimum consecutive failures for the check to be considered failed.
+	// Defaults to 1.
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// CloneSetCanaryHTTPGetAction describes an action based on HTTP Get requests.
+type CloneSetCanaryHTTPGetAction struct {
+	// URL is the full address to request.
+	URL string `json:"url"`
+	// Custom headers to set in the request.
+	// +optional
+	HTTPHeaders []v1.HTTPHeader `json:"httpHeaders,omitempty"`
 }
 
 // CloneSetPodUpdateStrategyType is a string enumeration type that enumerates
@@ -247,6 +454,82 @@ type CloneSetStatus struct {
 
 	// LabelSelector is label selectors for query over pods that should match the replica count used by HPA.
 	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// CanaryStatus records the progress of updateStrategy.rollingUpdate.canarySteps.
+	// +optional
+	CanaryStatus *CloneSetCanaryStatus `json:"canaryStatus,omitempty"`
+
+	// StandbyReplicas is the number of standby Pods, which are not counted in replicas.
+	// +optional
+	StandbyReplicas int32 `json:"standbyReplicas,omitempty"`
+
+	// UpdateHeldPods is the list of Pods that are held from updating by CloneSetPodUpdateHoldAnnotation.
+	// +optional
+	UpdateHeldPods []CloneSetUpdateHeldPod `json:"updateHeldPods,omitempty"`
+
+	// VolumeClaims represents the status of the PVCs of each volumeClaimTemplate,
+	// which shows the progress of in-place expansion.
+	// +optional
+	VolumeClaims []CloneSetVolumeClaimStatus `json:"volumeClaims,omitempty"`
+}
+
+// CloneSetVolumeClaimStatus describes the status of a volume claim template of CloneSet.
+type CloneSetVolumeClaimStatus struct {
+	// VolumeClaimName is the name of the volume claim template.
+	VolumeClaimName string `json:"volumeClaimName"`
+	// CompatibleReplicas is the number of replicas whose PVC spec storage requests are
+	// greater than or equal to the template spec storage requests.
+	CompatibleReplicas int32 `json:"compatibleReplicas"`
+	// CompatibleReadyReplicas is the number of compatible replicas whose PVC status capacity
+	// is greater than or equal to the PVC spec storage requests.
+	CompatibleReadyReplicas int32 `json:"compatibleReadyReplicas"`
+}
+
+// CloneSetUpdateHeldPod describes a Pod that is held from updating.
+type CloneSetUpdateHeldPod struct {
+	// Name of the Pod.
+	Name string `json:"name"`
+
+	CloneSetPodUpdateHold `json:",inline"`
+}
+
+// CloneSetCanaryStepState is the state of the current canary step.
+type CloneSetCanaryStepState string
+
+const (
+	// CanaryStepStateUpgrade indicates the pods of the current step are being updated.
+	CanaryStepStateUpgrade CloneSetCanaryStepState = "StepUpgrade"
+	// CanaryStepStatePaused indicates the pods of the current step are available,
+	// and the rollout is waiting for pauseSeconds or the check to pass.
+	CanaryStepStatePaused CloneSetCanaryStepState = "StepPaused"
+	// CanaryStepStateCompleted indicates all steps have been finished.
+	CanaryStepStateCompleted CloneSetCanaryStepState = "Completed"
+	// CanaryStepStateFailed indicates the check of the current step failed and the rollout has been stopped.
+	CanaryStepStateFailed CloneSetCanaryStepState = "Failed"
+)
+
+// CloneSetCanaryStatus describes the progress of canary steps.
+type CloneSetCanaryStatus struct {
+	// Revision is the update revision that the canary steps are working on.
+	Revision string `json:"revision"`
+	// StableRevision is the revision that pods are reset to if a check fails.
+	StableRevision string `json:"stableRevision,omitempty"`
+	// CurrentStepIndex is the index of the current step in canarySteps.
+	CurrentStepIndex int32 `json:"currentStepIndex"`
+	// CurrentStepState is the state of the current step.
+	CurrentStepState CloneSetCanaryStepState `json:"currentStepState"`
+	// CheckFailures is the number of consecutive check failures of the current step.
+	// +optional
+	CheckFailures int32 `json:"checkFailures,omitempty"`
+	// LastCheckTime is the last time the check of the current step finished.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// LastUpdateTime is the last time the current step state changed.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// A human readable message about the current step.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // CloneSetConditionReason is type for CloneSet reasons.
@@ -263,6 +546,13 @@ const (
 	CloneSetProgressPartitionAvailable CloneSetConditionReason = "ProgressPartitionAvailable"
 	// CloneSetAvailable is added in a cloneset when it is available.
 	CloneSetAvailable CloneSetConditionReason = "CloneSetAvailable"
+
+	// CloneSetRollbackInProgress is added in a cloneset when it starts to roll back.
+	CloneSetRollbackInProgress CloneSetConditionReason = "RollbackInProgress"
+	// CloneSetRollbackCompleted is added in a cloneset when the rollback has finished.
+	CloneSetRollbackCompleted CloneSetConditionReason = "RollbackCompleted"
+	// CloneSetRollbackRevisionNotFound is added in a cloneset when the revision to roll back to is not found.
+	CloneSetRollbackRevisionNotFound CloneSetConditionReason = "RollbackRevisionNotFound"
 )
 
 // CloneSetConditionType is type for CloneSet conditions.
@@ -275,6 +565,13 @@ const (
 	CloneSetConditionFailedUpdate CloneSetConditionType = "FailedUpdate"
 	// CloneSetConditionTypeProgressing indicates cloneset controller is progressing.
 	CloneSetConditionTypeProgressing CloneSetConditionType = "Progressing"
+	// CloneSetConditionTypeRollback indicates the progress of the last rollback.
+	// It is False during rolling back and True when the rollback has finished.
+	CloneSetConditionTypeRollback CloneSetConditionType = "Rollback"
+	// CloneSetConditionTypeProgressDeadlineExceeded indicates the update has been stopped by progressDeadlineExceededPolicy.
+	// Its reason is the policy and its message tells which revision timed out. It is removed once the update
+	// is resumed or a new revision is created.
+	CloneSetConditionTypeProgressDeadlineExceeded CloneSetConditionType = "ProgressDeadlineExceeded"
 )
 
 // CloneSetCondition describes the state of a CloneSet at a certain point.
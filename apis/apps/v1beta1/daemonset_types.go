@@ -97,6 +97,15 @@ type RollingUpdateDaemonSet struct {
 	// +optional
 	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
 
+	// HostPortConflict makes the surge update safe for the pods using host ports.
+	// If set, on the nodes where the old pod and the new pod would use the same host ports,
+	// the old pod is deleted first and the new pod is created after it has terminated,
+	// while the other nodes are still updated by surging.
+	// The container ports of pods in host network are regarded as host ports.
+	// It only works with maxSurge.
+	// +optional
+	HostPortConflict *DaemonSetHostPortConflict `json:"hostPortConflict,omitempty"`
+
 	// A label query over nodes that are managed by the daemon set RollingUpdate.
 	// Must match in order to be controlled.
 	// It must match the node's labels.
@@ -108,10 +117,138 @@ type RollingUpdateDaemonSet struct {
 	// +optional
 	Partition *intstr.IntOrString `json:"partition,omitempty"`
 
+	// PriorityStrategy is the rules for calculating the priority of updating nodes.
+	// Each node to be updated will pass through these terms over its labels, and the nodes
+	// with higher priority get the new revision first, which also means the nodes remained
+	// to be old version by partition are the ones with lower priority.
+	// +optional
+	PriorityStrategy *appspub.UpdatePriorityStrategy `json:"priorityStrategy,omitempty"`
+
 	// Indicates that the daemon set is paused and will not be processed by the
 	// daemon set controller.
 	// +optional
 	Paused *bool `json:"paused,omitempty"`
+
+	// Waves is an ordered list of node groups to roll out one after another.
+	// The controller only updates the pods on the nodes of the current wave, and moves to
+	// the next wave once all pods in the current wave are updated and available for soakSeconds.
+	// A node belongs to the first wave whose selector matches it, and the nodes matching
+	// no wave are updated after all the waves.
+	// Selector and partition still apply to the nodes of each wave, and a wave is completed once
+	// the nodes of it selected to update have been updated.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=name
+	Waves []DaemonSetUpdateWave `json:"waves,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// NodeHealthCheck watches the conditions of nodes after the updated pods started on them.
+	// The unhealthy nodes are skipped to update, and the rolling update stops once too many
+	// nodes become unhealthy after updated.
+	// The stop is recorded by the RollingUpdateStopped condition in status, and keeps in effect
+	// even if the nodes become healthy again, until the DaemonSet is updated to a new revision
+	// or the nodeHealthCheck is removed.
+	// +optional
+	NodeHealthCheck *DaemonSetNodeHealthCheck `json:"nodeHealthCheck,omitempty"`
+}
+
+const (
+	// DaemonSetRolledBackFromRevisionAnnotation is the revision which the pod was rolled back from
+	// because its node became unhealthy after updated.
+	DaemonSetRolledBackFromRevisionAnnotation = "daemonset.kruise.io/rolled-back-from-revision"
+
+	// DaemonSetInPlaceUpdatedFromRevisionAnnotation is the revision which the pod was in-place updated from,
+	// and the pod will be rolled back to it if its node became unhealthy after updated.
+	DaemonSetInPlaceUpdatedFromRevisionAnnotation = "daemonset.kruise.io/in-place-updated-from-revision"
+)
+
+const (
+	// DaemonSetConditionRollingUpdateStopped means the rolling update of the update revision has been
+	// stopped because too many nodes became unhealthy after updated.
+	DaemonSetConditionRollingUpdateStopped appsv1.DaemonSetConditionType = "RollingUpdateStopped"
+)
+
+// DaemonSetUnhealthyNodePolicyType is the action to take on the nodes which become unhealthy after updated.
+type DaemonSetUnhealthyNodePolicyType string
+
+const (
+	// SkipUnhealthyNodePolicyType keeps the updated pods on the unhealthy nodes.
+	SkipUnhealthyNodePolicyType DaemonSetUnhealthyNodePolicyType = "Skip"
+
+	// RollbackUnhealthyNodePolicyType updates the pods on the unhealthy nodes back to the revisions they were
+	// in-place updated from. It only works with InPlaceIfPossible rollingUpdateType, and the pods that were
+	// recreated or whose previous revision no longer exists are skipped.
+	RollbackUnhealthyNodePolicyType DaemonSetUnhealthyNodePolicyType = "Rollback"
+)
+
+// DaemonSetNodeHealthCheck defines how to check the nodes in rolling update.
+type DaemonSetNodeHealthCheck struct {
+	// UnhealthyConditions are the node conditions regarded as unhealthy.
+	// Defaults to Ready with status False or Unknown, and MemoryPressure, DiskPressure and
+	// PIDPressure with status True.
+	// +optional
+	UnhealthyConditions []DaemonSetNodeCondition `json:"unhealthyConditions,omitempty"`
+
+	// SoakSeconds is the period after the updated pod started on a node, in which the node
+	// becoming unhealthy is regarded as failed by the update.
+	SoakSeconds int32 `json:"soakSeconds"`
+
+	// Policy is the action to take on the failed nodes, which can be Skip or Rollback. Defaults to Skip.
+	// +optional
+	Policy DaemonSetUnhealthyNodePolicyType `json:"policy,omitempty"`
+
+	// MaxFailedNodes is the maximum number of failed nodes before the rolling update stops.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// Defaults to 0, which means the rolling update stops once any node failed.
+	// +optional
+	MaxFailedNodes *intstr.IntOrString `json:"maxFailedNodes,omitempty"`
+}
+
+// DaemonSetNodeCondition is a node condition type with the status.
+type DaemonSetNodeCondition struct {
+	// Type of the node condition.
+	Type corev1.NodeConditionType `json:"type"`
+
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+}
+
+// DaemonSetHostPortConflict configures the update of the pods conflicting on host ports when surging.
+type DaemonSetHostPortConflict struct {
+	// HandoffSeconds is the maximum number of seconds to wait for the old pod to terminate
+	// before creating the new pod on the node. Defaults to 0, which means waiting until
+	// the old pod has terminated.
+	// +optional
+	HandoffSeconds int32 `json:"handoffSeconds,omitempty"`
+
+	// MaxUnavailable is the maximum number of nodes with host port conflict that can be
+	// updated by deleting the old pod first at the same time, which is independent of maxSurge.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up.
+	// Defaults to 1.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// DaemonSetUpdateWave is a group of nodes to be updated together during rolling update.
+type DaemonSetUpdateWave struct {
+	// Name of the wave, which must be unique in the waves.
+	Name string `json:"name"`
+
+	// A label query over nodes that belong to this wave.
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// The maximum number of DaemonSet pods that can be unavailable in this wave during the update.
+	// Value can be an absolute number (ex: 5) or a percentage of the nodes in this wave (ex: 10%).
+	// Defaults to the maxUnavailable of rollingUpdate. It may not be set when maxSurge of rollingUpdate is non-zero.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// Minimum number of seconds to wait after all pods in this wave are available
+	// before moving to the next wave. Defaults to 0.
+	// +optional
+	SoakSeconds int32 `json:"soakSeconds,omitempty"`
 }
 
 // DaemonSetSpec defines the desired state of DaemonSet
@@ -233,6 +370,31 @@ type DaemonSetStatus struct {
 
 	// UpdateRevision is the controller-revision-hash, which represents the latest version of the DaemonSet.
 	UpdateRevision string `json:"updateRevision,omitempty"`
+
+	// NumberUpdateFailed is the number of nodes which became unhealthy after updated,
+	// which is only counted if nodeHealthCheck is set.
+	// +optional
+	NumberUpdateFailed int32 `json:"numberUpdateFailed,omitempty"`
+
+	// CurrentUpdateWave is the wave in rolling update, which is nil if there is
+	// no waves or all the waves have been updated.
+	// +optional
+	CurrentUpdateWave *DaemonSetUpdateWaveStatus `json:"currentUpdateWave,omitempty"`
+}
+
+// DaemonSetUpdateWaveStatus describes the wave in rolling update.
+type DaemonSetUpdateWaveStatus struct {
+	// Index of the wave in rollingUpdate.waves. It equals to the length of the waves
+	// when updating the nodes matching no wave.
+	Index int32 `json:"index"`
+
+	// Name of the wave, which is empty when updating the nodes matching no wave.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// SoakingUntil is the time until which the updated wave is soaking before moving to the next wave.
+	// +optional
+	SoakingUntil *metav1.Time `json:"soakingUntil,omitempty"`
 }
 
 // +genclient
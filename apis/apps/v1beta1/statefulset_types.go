@@ -20,6 +20,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	appspub "github.com/openkruise/kruise/apis/apps/pub"
@@ -46,6 +47,22 @@ const (
 	OnPVCDeleteVolumeClaimUpdateStrategyType VolumeClaimUpdateStrategyType = "OnDelete"
 )
 
+// VolumeClaimDeletePolicyType defines when the controller can delete the volume claims
+// that can not be updated by patching, such as storageClassName or accessModes modified.
+// +enum
+type VolumeClaimDeletePolicyType string
+
+const (
+	// NeverVolumeClaimDeletePolicyType indicates that the controller never deletes the volume claims,
+	// only the storage size modification can be applied to the existing volume claims.
+	NeverVolumeClaimDeletePolicyType VolumeClaimDeletePolicyType = "Never"
+
+	// OnPodRollingUpdateVolumeClaimDeletePolicyType indicates that the controller deletes the volume claims
+	// which are not compatible with the templates, and recreates them together with the Pod during rolling update.
+	// The volume claims are recreated ordinal by ordinal, and the data in them will be lost.
+	OnPodRollingUpdateVolumeClaimDeletePolicyType VolumeClaimDeletePolicyType = "OnPodRollingUpdate"
+)
+
 // VolumeClaimStatus describes the status of a volume claim template.
 // It provides details about the compatibility and readiness of the volume claim.
 type VolumeClaimStatus struct {
@@ -61,6 +78,10 @@ type VolumeClaimStatus struct {
 	// Compatibility is determined by whether the pvc spec storage requests are greater than or equal to the template spec storage requests
 	// The "ready" status is determined by whether the PVC status capacity is greater than or equal to the PVC spec storage requests.
 	CompatibleReadyReplicas int32 `json:"compatibleReadyReplicas"`
+	// RecreatingReplicas is the number of replicas whose volume claims are being deleted to be recreated,
+	// including the ones waiting for the preDeleteHook.
+	// +optional
+	RecreatingReplicas int32 `json:"recreatingReplicas,omitempty"`
 }
 
 // StatefulSetUpdateStrategy indicates the strategy that the StatefulSet
@@ -83,6 +104,21 @@ type VolumeClaimUpdateStrategy struct {
 	// OnPodRollingUpdateVolumeClaimUpdateStrategyType: Apply the update strategy during pod rolling updates.
 	// OnPVCDeleteVolumeClaimUpdateStrategyType: Apply the update strategy when a PersistentVolumeClaim is deleted.
 	Type VolumeClaimUpdateStrategyType `json:"type,omitempty"`
+
+	// DeletePolicy specifies whether the volume claims can be deleted and recreated to apply
+	// the modification of storageClassName and accessModes, possible values include:
+	// NeverVolumeClaimDeletePolicyType: Never delete the volume claims, this is the default policy.
+	// OnPodRollingUpdateVolumeClaimDeletePolicyType: Delete and recreate the volume claims during pod rolling updates.
+	// It only takes effect when type is OnPodRollingUpdate.
+	// +optional
+	DeletePolicy VolumeClaimDeletePolicyType `json:"deletePolicy,omitempty"`
+
+	// PreDeleteHook blocks the deletion of volume claims until the hook is removed, e.g. to take a snapshot before delete.
+	// The volume claims to be deleted will be labeled with lifecycle.apps.kruise.io/state=PreparingDelete,
+	// and the controller waits until none of the labelsHandler and finalizersHandler exist on them.
+	// MarkPodNotReady in the hook is ignored.
+	// +optional
+	PreDeleteHook *appspub.LifecycleHook `json:"preDeleteHook,omitempty"`
 }
 
 // RollingUpdateStatefulSetStrategy is used to communicate parameter for RollingUpdateStatefulSetStrategyType.
@@ -122,6 +158,27 @@ type RollingUpdateStatefulSetStrategy struct {
 	// Default value is 0, max is 300.
 	// +optional
 	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+	// TopologyUpdateConstraint limits the number of unavailable pods in each topology domain during update,
+	// such as at most one pod per zone, which is useful for quorum-based applications spread across zones.
+	// It works together with MaxUnavailable, which still limits the total number of unavailable pods.
+	// With UnorderedUpdate, pods in the other domains can be updated when a domain is blocked;
+	// otherwise the update waits in ordinal sequence.
+	// +optional
+	TopologyUpdateConstraint *TopologyUpdateConstraint `json:"topologyUpdateConstraint,omitempty"`
+}
+
+// TopologyUpdateConstraint defines the update concurrency in each topology domain.
+type TopologyUpdateConstraint struct {
+	// TopologyKey is the key of node labels. Pods on nodes that have a label with this key and identical values
+	// are considered to be in the same topology domain.
+	// Pods that have not been scheduled yet are counted in all the domains if they are unavailable.
+	TopologyKey string `json:"topologyKey"`
+	// MaxUnavailable is the maximum number of pods that can be unavailable in a topology domain during update.
+	// Value can be an absolute number (ex: 1) or a percentage of pods in the domain (ex: 50%).
+	// Absolute number is calculated from percentage by rounding down, and it is at least 1.
+	// Defaults to 1.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // UnorderedUpdateStrategy defines strategies for non-ordered update.
@@ -199,6 +256,49 @@ type StatefulSetOrdinals struct {
 	Start int32 `json:"start" protobuf:"varint,1,opt,name=start"`
 }
 
+// StatefulSetOrdinalMigration describes the handoff of ordinals between two StatefulSets in the same namespace.
+// The source StatefulSet releases the ordinals by spec.reserveOrdinals, and the target StatefulSet adopts
+// the PVCs of the released ordinals in its range, which are rebound to the PVCs of target by their PersistentVolumes.
+// The pods of the released ordinals are recreated by the target with its own names.
+type StatefulSetOrdinalMigration struct {
+	// ReleaseTo is the name of the target StatefulSet that adopts the ordinals released by spec.reserveOrdinals.
+	// The PVCs of the released ordinals will be retained until they are adopted.
+	// +optional
+	ReleaseTo string `json:"releaseTo,omitempty"`
+
+	// AdoptFrom is the name of the source StatefulSet whose released ordinals should be adopted.
+	// The pod of an ordinal will not be created until the ordinal has been released by the source
+	// and the PVCs of it have been adopted. The PVCs are adopted only if the releaseTo of the source StatefulSet
+	// is this StatefulSet and the ordinal is in its reserveOrdinals. The source records the release on its PVCs,
+	// so that they can still be adopted if the source StatefulSet is deleted after releasing the ordinal.
+	// +optional
+	AdoptFrom string `json:"adoptFrom,omitempty"`
+}
+
+// OrdinalHandoffPhase is the phase of an ordinal in migration.
+type OrdinalHandoffPhase string
+
+const (
+	// OrdinalHandoffReleasing means the source StatefulSet is deleting the pod of the ordinal.
+	OrdinalHandoffReleasing OrdinalHandoffPhase = "Releasing"
+	// OrdinalHandoffReleased means the pod of the ordinal has been deleted and its PVCs are waiting to be adopted.
+	OrdinalHandoffReleased OrdinalHandoffPhase = "Released"
+	// OrdinalHandoffPending means the target StatefulSet is waiting for the source to release the ordinal.
+	OrdinalHandoffPending OrdinalHandoffPhase = "Pending"
+	// OrdinalHandoffAdopting means the target StatefulSet is rebinding the volumes of the ordinal to its PVCs.
+	OrdinalHandoffAdopting OrdinalHandoffPhase = "Adopting"
+)
+
+// StatefulSetOrdinalHandoff describes the handoff state of an ordinal.
+type StatefulSetOrdinalHandoff struct {
+	// Ordinal is the ordinal in migration.
+	Ordinal int32 `json:"ordinal"`
+	// Peer is the name of the other StatefulSet in migration.
+	Peer string `json:"peer"`
+	// Phase is the handoff phase of the ordinal.
+	Phase OrdinalHandoffPhase `json:"phase"`
+}
+
 // StatefulSetSpec defines the desired state of StatefulSet
 type StatefulSetSpec struct {
 	// replicas is the desired number of replicas of the given Template.
@@ -298,6 +398,38 @@ type StatefulSetSpec struct {
 	// enabled, which is beta.
 	// +optional
 	Ordinals *StatefulSetOrdinals `json:"ordinals,omitempty"`
+
+	// QuorumGuard makes sure that neither scale-down nor rolling update will disrupt the pods
+	// when the number of available members would drop below the quorum.
+	// +optional
+	QuorumGuard *StatefulSetQuorumGuard `json:"quorumGuard,omitempty"`
+
+	// OrdinalMigration controls the handoff of ordinals with another StatefulSet,
+	// which is used to split or merge StatefulSets without losing the data in PVCs.
+	// +optional
+	OrdinalMigration *StatefulSetOrdinalMigration `json:"ordinalMigration,omitempty"`
+
+	// OrdinalPatches are the strategic merge patches to the pod template for specific ordinals,
+	// keyed by the ordinal, e.g. {"0": {"spec": {"containers": [{"name": "main", "resources": {...}}]}}}.
+	// The patch is applied when the pod of the ordinal is created, and it is not a part of the revision,
+	// so the patched pods are still considered as the current revision. Modifying the patches
+	// will not update the existing pods, it takes effect when the pods are recreated.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	OrdinalPatches map[string]runtime.RawExtension `json:"ordinalPatches,omitempty"`
+}
+
+// StatefulSetQuorumGuard defines the quorum of members that must remain available.
+type StatefulSetQuorumGuard struct {
+	// Quorum is the minimum number of available members.
+	// Value can be an absolute number (ex: 3) or a percentage of members (ex: 51%).
+	// Absolute number is calculated from percentage by rounding up.
+	// The number of members is spec.replicas, and the quorum is never more than it, so that scale-down
+	// is not blocked. The pods to be scaled down are still counted as available members until deleted.
+	// Defaults to the majority of members, which is floor(members/2)+1.
+	// +optional
+	Quorum *intstr.IntOrString `json:"quorum,omitempty"`
 }
 
 // StatefulSetScaleStrategy defines strategies for pods scale.
@@ -307,6 +439,61 @@ type StatefulSetScaleStrategy struct {
 	// Absolute number is calculated from percentage by rounding down.
 	// It can just be allowed to work with Parallel podManagementPolicy.
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// DrainHook is called on the pods to be scaled down before they are deleted,
+	// so that the data in them can be moved to other pods.
+	// +optional
+	DrainHook *StatefulSetDrainHook `json:"drainHook,omitempty"`
+}
+
+// StatefulSetDrainHook is an HTTP endpoint polled on the pod to be scaled down until the pod is drained.
+// The pod is considered drained once the endpoint responds with a 2xx status code, any other response
+// or error means the pod is still draining. The drain state of each pod is recorded in its
+// apps.kruise.io/drain-state annotation.
+type StatefulSetDrainHook struct {
+	// HTTPGet specifies the endpoint to call on the pod IP.
+	HTTPGet v1.HTTPGetAction `json:"httpGet"`
+
+	// TimeoutSeconds is the timeout of each call. Defaults to 1. Maximum value is 30.
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// PeriodSeconds is the interval between the calls. Defaults to 5.
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// MaxWaitSeconds is the maximum time to wait for the pod to be drained,
+	// after which the pod will be deleted even if it has not been drained.
+	MaxWaitSeconds int32 `json:"maxWaitSeconds"`
+}
+
+const (
+	// StatefulSetPodDrainStateKey is the annotation of the pod being drained before scaled down,
+	// the value of which is a StatefulSetPodDrainState in JSON.
+	StatefulSetPodDrainStateKey = "apps.kruise.io/drain-state"
+)
+
+// StatefulSetPodDrainPhase is the phase of a pod drained before scaled down.
+type StatefulSetPodDrainPhase string
+
+const (
+	// PodDraining means the drain hook has been called and the pod has not been drained yet.
+	PodDraining StatefulSetPodDrainPhase = "Draining"
+	// PodDrained means the drain hook has reported the pod is drained.
+	PodDrained StatefulSetPodDrainPhase = "Drained"
+	// PodDrainTimedOut means the pod has not been drained within the maxWaitSeconds.
+	PodDrainTimedOut StatefulSetPodDrainPhase = "TimedOut"
+)
+
+// StatefulSetPodDrainState is the drain state of a pod to be scaled down.
+type StatefulSetPodDrainState struct {
+	Phase StatefulSetPodDrainPhase `json:"phase"`
+	// StartTime is the time of the first call to the drain hook.
+	StartTime metav1.Time `json:"startTime"`
+	// LastProbeTime is the time of the last call to the drain hook.
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// Message is the result of the last call to the drain hook.
+	Message string `json:"message,omitempty"`
 }
 
 // StatefulSetStatus defines the observed state of StatefulSet
@@ -369,6 +556,17 @@ type StatefulSetStatus struct {
 	// to match any changes made to the volumeClaimTemplates, ensuring synchronization
 	// between the defined templates and the actual PersistentVolumeClaims in use.
 	VolumeClaims []VolumeClaimStatus `json:"volumeClaims,omitempty"`
+
+	// QuorumHeadroom is the number of available members minus the quorum, which means how many more
+	// members can be disrupted. It is negative if the quorum has been broken.
+	// It is only set when spec.quorumGuard is set.
+	// +optional
+	QuorumHeadroom *int32 `json:"quorumHeadroom,omitempty"`
+
+	// OrdinalHandoffs is the ordinals in migration with another StatefulSet, the ordinals are removed once
+	// their PVCs have been adopted by the target.
+	// +optional
+	OrdinalHandoffs []StatefulSetOrdinalHandoff `json:"ordinalHandoffs,omitempty"`
 }
 
 // These are valid conditions of a statefulset.
@@ -372,6 +372,90 @@ func (in *CloneSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSetCanaryCheck) DeepCopyInto(out *CloneSetCanaryCheck) {
+	*out = *in
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(CloneSetCanaryHTTPGetAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetCanaryCheck.
+func (in *CloneSetCanaryCheck) DeepCopy() *CloneSetCanaryCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSetCanaryCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSetCanaryHTTPGetAction) DeepCopyInto(out *CloneSetCanaryHTTPGetAction) {
+	*out = *in
+	if in.HTTPHeaders != nil {
+		in, out := &in.HTTPHeaders, &out.HTTPHeaders
+		*out = make([]corev1.HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetCanaryHTTPGetAction.
+func (in *CloneSetCanaryHTTPGetAction) DeepCopy() *CloneSetCanaryHTTPGetAction {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSetCanaryHTTPGetAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSetCanaryStatus) DeepCopyInto(out *CloneSetCanaryStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetCanaryStatus.
+func (in *CloneSetCanaryStatus) DeepCopy() *CloneSetCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSetCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSetCanaryStep) DeepCopyInto(out *CloneSetCanaryStep) {
+	*out = *in
+	out.Partition = in.Partition
+	if in.Check != nil {
+		in, out := &in.Check, &out.Check
+		*out = new(CloneSetCanaryCheck)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetCanaryStep.
+func (in *CloneSetCanaryStep) DeepCopy() *CloneSetCanaryStep {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSetCanaryStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloneSetCondition) DeepCopyInto(out *CloneSetCondition) {
 	*out = *in
@@ -389,6 +473,21 @@ func (in *CloneSetCondition) DeepCopy() *CloneSetCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSetEndpointReadinessGate) DeepCopyInto(out *CloneSetEndpointReadinessGate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetEndpointReadinessGate.
+func (in *CloneSetEndpointReadinessGate) DeepCopy() *CloneSetEndpointReadinessGate {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSetEndpointReadinessGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloneSetList) DeepCopyInto(out *CloneSetList) {
 	*out = *in
@@ -421,6 +520,60 @@ func (in *CloneSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSetPodUpdateHold) DeepCopyInto(out *CloneSetPodUpdateHold) {
+	*out = *in
+	if in.ExpireTime != nil {
+		in, out := &in.ExpireTime, &out.ExpireTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetPodUpdateHold.
+func (in *CloneSetPodUpdateHold) DeepCopy() *CloneSetPodUpdateHold {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSetPodUpdateHold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSetRollbackConfig) DeepCopyInto(out *CloneSetRollbackConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetRollbackConfig.
+func (in *CloneSetRollbackConfig) DeepCopy() *CloneSetRollbackConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSetRollbackConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSetScaleDownPolicy) DeepCopyInto(out *CloneSetScaleDownPolicy) {
+	*out = *in
+	if in.TopologyKeys != nil {
+		in, out := &in.TopologyKeys, &out.TopologyKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetScaleDownPolicy.
+func (in *CloneSetScaleDownPolicy) DeepCopy() *CloneSetScaleDownPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSetScaleDownPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloneSetScaleStrategy) DeepCopyInto(out *CloneSetScaleStrategy) {
 	*out = *in
@@ -434,6 +587,16 @@ func (in *CloneSetScaleStrategy) DeepCopyInto(out *CloneSetScaleStrategy) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.ScaleDownPolicy != nil {
+		in, out := &in.ScaleDownPolicy, &out.ScaleDownPolicy
+		*out = new(CloneSetScaleDownPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StandbyReplicas != nil {
+		in, out := &in.StandbyReplicas, &out.StandbyReplicas
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetScaleStrategy.
@@ -467,6 +630,7 @@ func (in *CloneSetSpec) DeepCopyInto(out *CloneSetSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	out.VolumeClaimUpdateStrategy = in.VolumeClaimUpdateStrategy
 	in.ScaleStrategy.DeepCopyInto(&out.ScaleStrategy)
 	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
 	if in.RevisionHistoryLimit != nil {
@@ -484,6 +648,11 @@ func (in *CloneSetSpec) DeepCopyInto(out *CloneSetSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RollbackTo != nil {
+		in, out := &in.RollbackTo, &out.RollbackTo
+		*out = new(CloneSetRollbackConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetSpec.
@@ -511,6 +680,23 @@ func (in *CloneSetStatus) DeepCopyInto(out *CloneSetStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CanaryStatus != nil {
+		in, out := &in.CanaryStatus, &out.CanaryStatus
+		*out = new(CloneSetCanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateHeldPods != nil {
+		in, out := &in.UpdateHeldPods, &out.UpdateHeldPods
+		*out = make([]CloneSetUpdateHeldPod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeClaims != nil {
+		in, out := &in.VolumeClaims, &out.VolumeClaims
+		*out = make([]CloneSetVolumeClaimStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetStatus.
@@ -540,6 +726,22 @@ func (in *CloneSetTemplateSpec) DeepCopy() *CloneSetTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSetUpdateHeldPod) DeepCopyInto(out *CloneSetUpdateHeldPod) {
+	*out = *in
+	in.CloneSetPodUpdateHold.DeepCopyInto(&out.CloneSetPodUpdateHold)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetUpdateHeldPod.
+func (in *CloneSetUpdateHeldPod) DeepCopy() *CloneSetUpdateHeldPod {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSetUpdateHeldPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloneSetUpdateStrategy) DeepCopyInto(out *CloneSetUpdateStrategy) {
 	*out = *in
@@ -560,6 +762,36 @@ func (in *CloneSetUpdateStrategy) DeepCopy() *CloneSetUpdateStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSetVolumeClaimStatus) DeepCopyInto(out *CloneSetVolumeClaimStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetVolumeClaimStatus.
+func (in *CloneSetVolumeClaimStatus) DeepCopy() *CloneSetVolumeClaimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSetVolumeClaimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSetVolumeClaimUpdateStrategy) DeepCopyInto(out *CloneSetVolumeClaimUpdateStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSetVolumeClaimUpdateStrategy.
+func (in *CloneSetVolumeClaimUpdateStrategy) DeepCopy() *CloneSetVolumeClaimUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSetVolumeClaimUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CompletionPolicy) DeepCopyInto(out *CompletionPolicy) {
 	*out = *in
@@ -905,6 +1137,26 @@ func (in *DaemonSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetHostPortConflict) DeepCopyInto(out *DaemonSetHostPortConflict) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetHostPortConflict.
+func (in *DaemonSetHostPortConflict) DeepCopy() *DaemonSetHostPortConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetHostPortConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DaemonSetList) DeepCopyInto(out *DaemonSetList) {
 	*out = *in
@@ -937,6 +1189,46 @@ func (in *DaemonSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetNodeCondition) DeepCopyInto(out *DaemonSetNodeCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetNodeCondition.
+func (in *DaemonSetNodeCondition) DeepCopy() *DaemonSetNodeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetNodeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetNodeHealthCheck) DeepCopyInto(out *DaemonSetNodeHealthCheck) {
+	*out = *in
+	if in.UnhealthyConditions != nil {
+		in, out := &in.UnhealthyConditions, &out.UnhealthyConditions
+		*out = make([]DaemonSetNodeCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxFailedNodes != nil {
+		in, out := &in.MaxFailedNodes, &out.MaxFailedNodes
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetNodeHealthCheck.
+func (in *DaemonSetNodeHealthCheck) DeepCopy() *DaemonSetNodeHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetNodeHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DaemonSetScaleStrategy) DeepCopyInto(out *DaemonSetScaleStrategy) {
 	*out = *in
@@ -1009,6 +1301,11 @@ func (in *DaemonSetStatus) DeepCopyInto(out *DaemonSetStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CurrentUpdateWave != nil {
+		in, out := &in.CurrentUpdateWave, &out.CurrentUpdateWave
+		*out = new(DaemonSetUpdateWaveStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetStatus.
@@ -1041,6 +1338,50 @@ func (in *DaemonSetUpdateStrategy) DeepCopy() *DaemonSetUpdateStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetUpdateWave) DeepCopyInto(out *DaemonSetUpdateWave) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetUpdateWave.
+func (in *DaemonSetUpdateWave) DeepCopy() *DaemonSetUpdateWave {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetUpdateWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetUpdateWaveStatus) DeepCopyInto(out *DaemonSetUpdateWaveStatus) {
+	*out = *in
+	if in.SoakingUntil != nil {
+		in, out := &in.SoakingUntil, &out.SoakingUntil
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetUpdateWaveStatus.
+func (in *DaemonSetUpdateWaveStatus) DeepCopy() *DaemonSetUpdateWaveStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetUpdateWaveStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentTemplateSpec) DeepCopyInto(out *DeploymentTemplateSpec) {
 	*out = *in
@@ -2085,6 +2426,18 @@ func (in *RollingUpdateCloneSetStrategy) DeepCopyInto(out *RollingUpdateCloneSet
 		*out = new(pub.InPlaceUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CanarySteps != nil {
+		in, out := &in.CanarySteps, &out.CanarySteps
+		*out = make([]CloneSetCanaryStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EndpointReadinessGate != nil {
+		in, out := &in.EndpointReadinessGate, &out.EndpointReadinessGate
+		*out = new(CloneSetEndpointReadinessGate)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateCloneSetStrategy.
@@ -2110,6 +2463,11 @@ func (in *RollingUpdateDaemonSet) DeepCopyInto(out *RollingUpdateDaemonSet) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.HostPortConflict != nil {
+		in, out := &in.HostPortConflict, &out.HostPortConflict
+		*out = new(DaemonSetHostPortConflict)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Selector != nil {
 		in, out := &in.Selector, &out.Selector
 		*out = new(metav1.LabelSelector)
@@ -2120,11 +2478,28 @@ func (in *RollingUpdateDaemonSet) DeepCopyInto(out *RollingUpdateDaemonSet) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.PriorityStrategy != nil {
+		in, out := &in.PriorityStrategy, &out.PriorityStrategy
+		*out = new(pub.UpdatePriorityStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Paused != nil {
 		in, out := &in.Paused, &out.Paused
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]DaemonSetUpdateWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeHealthCheck != nil {
+		in, out := &in.NodeHealthCheck, &out.NodeHealthCheck
+		*out = new(DaemonSetNodeHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateDaemonSet.
@@ -2165,6 +2540,11 @@ func (in *RollingUpdateStatefulSetStrategy) DeepCopyInto(out *RollingUpdateState
 		*out = new(int32)
 		**out = **in
 	}
+	if in.TopologyUpdateConstraint != nil {
+		in, out := &in.TopologyUpdateConstraint, &out.TopologyUpdateConstraint
+		*out = new(TopologyUpdateConstraint)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateStatefulSetStrategy.
@@ -2225,7 +2605,7 @@ func (in *ShareVolumePolicy) DeepCopy() *ShareVolumePolicy {
 func (in *SidecarContainer) DeepCopyInto(out *SidecarContainer) {
 	*out = *in
 	in.Container.DeepCopyInto(&out.Container)
-	out.UpgradeStrategy = in.UpgradeStrategy
+	in.UpgradeStrategy.DeepCopyInto(&out.UpgradeStrategy)
 	out.ShareVolumePolicy = in.ShareVolumePolicy
 	if in.ShareVolumeDevicePolicy != nil {
 		in, out := &in.ShareVolumeDevicePolicy, &out.ShareVolumeDevicePolicy
@@ -2256,9 +2636,30 @@ func (in *SidecarContainer) DeepCopy() *SidecarContainer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarContainerHandoffProbe) DeepCopyInto(out *SidecarContainerHandoffProbe) {
+	*out = *in
+	in.HTTPGet.DeepCopyInto(&out.HTTPGet)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarContainerHandoffProbe.
+func (in *SidecarContainerHandoffProbe) DeepCopy() *SidecarContainerHandoffProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarContainerHandoffProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SidecarContainerUpgradeStrategy) DeepCopyInto(out *SidecarContainerUpgradeStrategy) {
 	*out = *in
+	if in.HandoffProbe != nil {
+		in, out := &in.HandoffProbe, &out.HandoffProbe
+		*out = new(SidecarContainerHandoffProbe)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarContainerUpgradeStrategy.
@@ -2311,6 +2712,11 @@ func (in *SidecarSetInjectRevision) DeepCopyInto(out *SidecarSetInjectRevision)
 		*out = new(string)
 		**out = **in
 	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetInjectRevision.
@@ -2375,6 +2781,51 @@ func (in *SidecarSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarSetNamespaceStatus) DeepCopyInto(out *SidecarSetNamespaceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetNamespaceStatus.
+func (in *SidecarSetNamespaceStatus) DeepCopy() *SidecarSetNamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarSetNamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarSetNamespaceWave) DeepCopyInto(out *SidecarSetNamespaceWave) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetNamespaceWave.
+func (in *SidecarSetNamespaceWave) DeepCopy() *SidecarSetNamespaceWave {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarSetNamespaceWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SidecarSetPatchPodMetadata) DeepCopyInto(out *SidecarSetPatchPodMetadata) {
 	*out = *in
@@ -2470,6 +2921,11 @@ func (in *SidecarSetStatus) DeepCopyInto(out *SidecarSetStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.NamespaceStatuses != nil {
+		in, out := &in.NamespaceStatuses, &out.NamespaceStatuses
+		*out = make([]SidecarSetNamespaceStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetStatus.
@@ -2510,6 +2966,13 @@ func (in *SidecarSetUpdateStrategy) DeepCopyInto(out *SidecarSetUpdateStrategy)
 		*out = make(UpdateScatterStrategy, len(*in))
 		copy(*out, *in)
 	}
+	if in.NamespaceWaves != nil {
+		in, out := &in.NamespaceWaves, &out.NamespaceWaves
+		*out = make([]SidecarSetNamespaceWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetUpdateStrategy.
@@ -2569,6 +3032,22 @@ func (in *StatefulSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatefulSetDrainHook) DeepCopyInto(out *StatefulSetDrainHook) {
+	*out = *in
+	in.HTTPGet.DeepCopyInto(&out.HTTPGet)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetDrainHook.
+func (in *StatefulSetDrainHook) DeepCopy() *StatefulSetDrainHook {
+	if in == nil {
+		return nil
+	}
+	out := new(StatefulSetDrainHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatefulSetList) DeepCopyInto(out *StatefulSetList) {
 	*out = *in
@@ -2601,6 +3080,36 @@ func (in *StatefulSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatefulSetOrdinalHandoff) DeepCopyInto(out *StatefulSetOrdinalHandoff) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetOrdinalHandoff.
+func (in *StatefulSetOrdinalHandoff) DeepCopy() *StatefulSetOrdinalHandoff {
+	if in == nil {
+		return nil
+	}
+	out := new(StatefulSetOrdinalHandoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatefulSetOrdinalMigration) DeepCopyInto(out *StatefulSetOrdinalMigration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetOrdinalMigration.
+func (in *StatefulSetOrdinalMigration) DeepCopy() *StatefulSetOrdinalMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(StatefulSetOrdinalMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatefulSetOrdinals) DeepCopyInto(out *StatefulSetOrdinals) {
 	*out = *in
@@ -2631,6 +3140,43 @@ func (in *StatefulSetPersistentVolumeClaimRetentionPolicy) DeepCopy() *StatefulS
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatefulSetPodDrainState) DeepCopyInto(out *StatefulSetPodDrainState) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetPodDrainState.
+func (in *StatefulSetPodDrainState) DeepCopy() *StatefulSetPodDrainState {
+	if in == nil {
+		return nil
+	}
+	out := new(StatefulSetPodDrainState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatefulSetQuorumGuard) DeepCopyInto(out *StatefulSetQuorumGuard) {
+	*out = *in
+	if in.Quorum != nil {
+		in, out := &in.Quorum, &out.Quorum
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetQuorumGuard.
+func (in *StatefulSetQuorumGuard) DeepCopy() *StatefulSetQuorumGuard {
+	if in == nil {
+		return nil
+	}
+	out := new(StatefulSetQuorumGuard)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatefulSetScaleStrategy) DeepCopyInto(out *StatefulSetScaleStrategy) {
 	*out = *in
@@ -2639,6 +3185,11 @@ func (in *StatefulSetScaleStrategy) DeepCopyInto(out *StatefulSetScaleStrategy)
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.DrainHook != nil {
+		in, out := &in.DrainHook, &out.DrainHook
+		*out = new(StatefulSetDrainHook)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetScaleStrategy.
@@ -2672,7 +3223,7 @@ func (in *StatefulSetSpec) DeepCopyInto(out *StatefulSetSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.VolumeClaimUpdateStrategy = in.VolumeClaimUpdateStrategy
+	in.VolumeClaimUpdateStrategy.DeepCopyInto(&out.VolumeClaimUpdateStrategy)
 	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
 	if in.RevisionHistoryLimit != nil {
 		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
@@ -2704,6 +3255,23 @@ func (in *StatefulSetSpec) DeepCopyInto(out *StatefulSetSpec) {
 		*out = new(StatefulSetOrdinals)
 		**out = **in
 	}
+	if in.QuorumGuard != nil {
+		in, out := &in.QuorumGuard, &out.QuorumGuard
+		*out = new(StatefulSetQuorumGuard)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrdinalMigration != nil {
+		in, out := &in.OrdinalMigration, &out.OrdinalMigration
+		*out = new(StatefulSetOrdinalMigration)
+		**out = **in
+	}
+	if in.OrdinalPatches != nil {
+		in, out := &in.OrdinalPatches, &out.OrdinalPatches
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetSpec.
@@ -2736,6 +3304,16 @@ func (in *StatefulSetStatus) DeepCopyInto(out *StatefulSetStatus) {
 		*out = make([]VolumeClaimStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.QuorumHeadroom != nil {
+		in, out := &in.QuorumHeadroom, &out.QuorumHeadroom
+		*out = new(int32)
+		**out = **in
+	}
+	if in.OrdinalHandoffs != nil {
+		in, out := &in.OrdinalHandoffs, &out.OrdinalHandoffs
+		*out = make([]StatefulSetOrdinalHandoff, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetStatus.
@@ -2938,6 +3516,26 @@ func (in *Topology) DeepCopy() *Topology {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyUpdateConstraint) DeepCopyInto(out *TopologyUpdateConstraint) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyUpdateConstraint.
+func (in *TopologyUpdateConstraint) DeepCopy() *TopologyUpdateConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyUpdateConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransferEnvVar) DeepCopyInto(out *TransferEnvVar) {
 	*out = *in
@@ -3255,6 +3853,11 @@ func (in *VolumeClaimStatus) DeepCopy() *VolumeClaimStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeClaimUpdateStrategy) DeepCopyInto(out *VolumeClaimUpdateStrategy) {
 	*out = *in
+	if in.PreDeleteHook != nil {
+		in, out := &in.PreDeleteHook, &out.PreDeleteHook
+		*out = new(pub.LifecycleHook)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeClaimUpdateStrategy.
@@ -57,3 +57,9 @@ func GetCurrentServerVersion() *version.Info {
 func ShouldUpdateResourceByResize() bool {
 	return semver.New(fmt.Sprintf("%s.%s.0", curVersion.Major, curVersion.Minor)).Compare(*semver.New("1.32.0")) >= 0
 }
+
+// SupportNativeSidecar returns whether the initContainers with restartPolicy Always are supported as sidecars.
+// The SidecarContainers feature gate is enabled by default since version 1.29, https://github.com/kubernetes/enhancements/issues/753
+func SupportNativeSidecar() bool {
+	return semver.New(fmt.Sprintf("%s.%s.0", curVersion.Major, curVersion.Minor)).Compare(*semver.New("1.29.0")) >= 0
+}
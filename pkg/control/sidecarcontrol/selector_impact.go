@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecarcontrol
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+	utilclient "github.com/openkruise/kruise/pkg/util/client"
+)
+
+// SelectorImpact is the active pods that newly match or stop matching the SidecarSet after the change of
+// its selector or namespaceSelector, grouped by their workloads.
+// Nothing is changed in these pods until they are recreated.
+type SelectorImpact struct {
+	NewlyMatched []WorkloadPods
+	Unmatched    []WorkloadPods
+}
+
+// WorkloadPods is the number of pods owned by the same controller, the pods without controller are regarded
+// as workloads of themselves.
+type WorkloadPods struct {
+	Namespace string
+	Kind      string
+	Name      string
+	Pods      int
+}
+
+func (w WorkloadPods) String() string {
+	return fmt.Sprintf("%s %s/%s (%d pods)", w.Kind, w.Namespace, w.Name, w.Pods)
+}
+
+// AffectedPods returns the number of pods that newly match or stop matching the SidecarSet.
+func (s *SelectorImpact) AffectedPods() int {
+	var count int
+	for _, w := range append(s.NewlyMatched, s.Unmatched...) {
+		count += w.Pods
+	}
+	return count
+}
+
+// AffectedWorkloads returns the number of workloads that newly match or stop matching the SidecarSet.
+func (s *SelectorImpact) AffectedWorkloads() int {
+	return len(s.NewlyMatched) + len(s.Unmatched)
+}
+
+// IsSelectorChanged returns whether the selector or namespaceSelector of SidecarSet is changed.
+func IsSelectorChanged(oldSidecarSet, newSidecarSet *appsv1beta1.SidecarSet) bool {
+	return !reflect.DeepEqual(oldSidecarSet.Spec.Selector, newSidecarSet.Spec.Selector) ||
+		!reflect.DeepEqual(oldSidecarSet.Spec.NamespaceSelector, newSidecarSet.Spec.NamespaceSelector)
+}
+
+// MaxSelectorImpactPods is the maximum number of pods examined in calculating the impact of selector changes,
+// so that the calculation in admission is bounded.
+const MaxSelectorImpactPods = 10000
+
+// CalculateSelectorImpact calculates the impact of changing the selector and namespaceSelector of oldSidecarSet
+// to the ones of newSidecarSet, using the same matching logic as the injection.
+// Only the pods matching either of the selectors are examined, and it gives up once the context is done
+// or there are more than MaxSelectorImpactPods pods to examine.
+func CalculateSelectorImpact(ctx context.Context, c client.Client, oldSidecarSet, newSidecarSet *appsv1beta1.SidecarSet) (*SelectorImpact, error) {
+	namespaces := sets.NewString()
+	if oldSidecarSet.Spec.NamespaceSelector == nil || newSidecarSet.Spec.NamespaceSelector == nil {
+		// list pods in all namespaces
+		namespaces.Insert("")
+	} else {
+		for _, sidecarSet := range []*appsv1beta1.SidecarSet{oldSidecarSet, newSidecarSet} {
+			matched, err := FetchSidecarSetMatchedNamespace(c, sidecarSet)
+			if err != nil {
+				return nil, err
+			}
+			namespaces = namespaces.Union(matched)
+		}
+	}
+
+	newlyMatched := map[WorkloadPods]int{}
+	unmatched := map[WorkloadPods]int{}
+	examined := sets.NewString()
+	for _, ns := range namespaces.List() {
+		for _, sidecarSet := range []*appsv1beta1.SidecarSet{oldSidecarSet, newSidecarSet} {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			// the pods matching neither of the selectors are not affected
+			selector, err := util.ValidatedLabelSelectorAsSelector(sidecarSet.Spec.Selector)
+			if err != nil {
+				return nil, err
+			} else if selector.Empty() {
+				continue
+			}
+			podList := &corev1.PodList{}
+			if err := c.List(ctx, podList, client.InNamespace(ns), client.MatchingLabelsSelector{Selector: selector}, utilclient.DisableDeepCopy); err != nil {
+				return nil, err
+			}
+			for i := range podList.Items {
+				pod := &podList.Items[i]
+				key := pod.Namespace + "/" + pod.Name
+				if !IsActivePod(pod) || examined.Has(key) {
+					continue
+				}
+				if examined.Insert(key); examined.Len() > MaxSelectorImpactPods {
+					return nil, fmt.Errorf("more than %d pods match the selectors", MaxSelectorImpactPods)
+				}
+				oldMatched, err := PodMatchedSidecarSet(c, pod, oldSidecarSet)
+				if err != nil {
+					return nil, err
+				}
+				newMatched, err := PodMatchedSidecarSet(c, pod, newSidecarSet)
+				if err != nil {
+					return nil, err
+				}
+				if oldMatched == newMatched {
+					continue
+				}
+				workload := WorkloadPods{Namespace: pod.Namespace, Kind: "Pod", Name: pod.Name}
+				if ref := metav1.GetControllerOf(pod); ref != nil {
+					workload.Kind, workload.Name = ref.Kind, ref.Name
+				}
+				if newMatched {
+					newlyMatched[workload]++
+				} else {
+					unmatched[workload]++
+				}
+			}
+		}
+	}
+	return &SelectorImpact{NewlyMatched: sortWorkloadPods(newlyMatched), Unmatched: sortWorkloadPods(unmatched)}, nil
+}
+
+// sortWorkloadPods sorts the workloads with more pods first.
+func sortWorkloadPods(workloads map[WorkloadPods]int) []WorkloadPods {
+	list := make([]WorkloadPods, 0, len(workloads))
+	for w, pods := range workloads {
+		w.Pods = pods
+		list = append(list, w)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Pods != list[j].Pods {
+			return list[i].Pods > list[j].Pods
+		}
+		return list[i].String() < list[j].String()
+	})
+	return list
+}
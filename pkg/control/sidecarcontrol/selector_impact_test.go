@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecarcontrol
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func TestCalculateSelectorImpact(t *testing.T) {
+	newPod := func(ns, name, owner string, labels map[string]string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, Labels: labels},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: owner, UID: "uid", Controller: ptr.To(true)},
+			}
+		}
+		return pod
+	}
+	newSidecarSet := func(app string, nsSelector *metav1.LabelSelector) *appsv1beta1.SidecarSet {
+		return &appsv1beta1.SidecarSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "sidecarset-test"},
+			Spec: appsv1beta1.SidecarSetSpec{
+				Selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+				NamespaceSelector: nsSelector,
+			},
+		}
+	}
+	objects := func() []client.Object {
+		objs := []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-a", Labels: map[string]string{"env": "test"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-b", Labels: map[string]string{"env": "prod"}}},
+			newPod("ns-a", "bare-pod", "", map[string]string{"app": "nginx"}),
+			newPod("ns-b", "redis-0", "redis", map[string]string{"app": "redis"}),
+		}
+		for i := 0; i < 3; i++ {
+			objs = append(objs, newPod("ns-a", fmt.Sprintf("nginx-%d", i), "nginx", map[string]string{"app": "nginx"}))
+			objs = append(objs, newPod("ns-b", fmt.Sprintf("nginx-%d", i), "nginx", map[string]string{"app": "nginx"}))
+		}
+		terminating := newPod("ns-a", "nginx-terminating", "nginx", map[string]string{"app": "nginx"})
+		terminating.Status.Phase = corev1.PodSucceeded
+		return append(objs, terminating)
+	}
+
+	cases := []struct {
+		name          string
+		oldSidecarSet *appsv1beta1.SidecarSet
+		newSidecarSet *appsv1beta1.SidecarSet
+		expect        *SelectorImpact
+	}{
+		{
+			name:          "selector not changed",
+			oldSidecarSet: newSidecarSet("nginx", nil),
+			newSidecarSet: newSidecarSet("nginx", nil),
+			expect:        &SelectorImpact{NewlyMatched: []WorkloadPods{}, Unmatched: []WorkloadPods{}},
+		},
+		{
+			name:          "selector changed",
+			oldSidecarSet: newSidecarSet("nginx", nil),
+			newSidecarSet: newSidecarSet("redis", nil),
+			expect: &SelectorImpact{
+				NewlyMatched: []WorkloadPods{{Namespace: "ns-b", Kind: "ReplicaSet", Name: "redis", Pods: 1}},
+				Unmatched: []WorkloadPods{
+					{Namespace: "ns-a", Kind: "ReplicaSet", Name: "nginx", Pods: 3},
+					{Namespace: "ns-b", Kind: "ReplicaSet", Name: "nginx", Pods: 3},
+					{Namespace: "ns-a", Kind: "Pod", Name: "bare-pod", Pods: 1},
+				},
+			},
+		},
+		{
+			name:          "namespaceSelector changed",
+			oldSidecarSet: newSidecarSet("nginx", &metav1.LabelSelector{MatchLabels: map[string]string{"env": "test"}}),
+			newSidecarSet: newSidecarSet("nginx", &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}),
+			expect: &SelectorImpact{
+				NewlyMatched: []WorkloadPods{{Namespace: "ns-b", Kind: "ReplicaSet", Name: "nginx", Pods: 3}},
+				Unmatched: []WorkloadPods{
+					{Namespace: "ns-a", Kind: "ReplicaSet", Name: "nginx", Pods: 3},
+					{Namespace: "ns-a", Kind: "Pod", Name: "bare-pod", Pods: 1},
+				},
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(objects()...).Build()
+			impact, err := CalculateSelectorImpact(context.TODO(), fakeClient, cs.oldSidecarSet, cs.newSidecarSet)
+			if err != nil {
+				t.Fatalf("CalculateSelectorImpact failed: %s", err.Error())
+			}
+			if !reflect.DeepEqual(cs.expect, impact) {
+				t.Fatalf("expect(%+v), but get(%+v)", cs.expect, impact)
+			}
+		})
+	}
+}
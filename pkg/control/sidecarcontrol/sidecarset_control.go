@@ -79,7 +79,7 @@ func (c *commonControl) IsPodReady(pod *v1.Pod) bool {
 	for _, sidecarContainer := range sidecarSet.Spec.Containers {
 		if IsHotUpgradeContainer(&sidecarContainer) {
 			_, emptyContainer := GetPodHotUpgradeContainers(sidecarContainer.Name, pod)
-			emptyContainers[emptyContainer] = sidecarContainer.UpgradeStrategy.HotUpgradeEmptyImage
+			emptyContainers[emptyContainer] = GetHotUpgradeEmptyImage(&sidecarContainer, pod)
 		}
 	}
 	for _, container := range pod.Spec.Containers {
@@ -121,8 +121,7 @@ func (c *commonControl) UpdatePodAnnotationsInUpgrade(changedContainers []string
 	}
 
 	cStatus := make(map[string]string, len(pod.Status.ContainerStatuses))
-	for i := range pod.Status.ContainerStatuses {
-		c := &pod.Status.ContainerStatuses[i]
+	for _, c := range getSidecarContainerStatuses(pod) {
 		cStatus[c.Name] = c.ImageID
 	}
 	for _, cName := range changedContainers {
@@ -152,7 +151,7 @@ func (c *commonControl) IsPodStateConsistent(pod *v1.Pod, sidecarContainers sets
 
 	allDigestImage := true
 	cImageIDs := util.GetPodContainerImageIDs(pod)
-	for _, container := range pod.Spec.Containers {
+	for _, container := range getSidecarContainers(pod) {
 		// only check whether sidecar container is consistent
 		if !sidecarContainers.Has(container.Name) {
 			continue
@@ -192,7 +191,7 @@ func (c *commonControl) IsSidecarSetUpgradable(pod *v1.Pod) (canUpgrade, consist
 
 	// cStatus: container.name -> containerStatus.Ready
 	cStatus := map[string]bool{}
-	for _, status := range pod.Status.ContainerStatuses {
+	for _, status := range getSidecarContainerStatuses(pod) {
 		cStatus[status.Name] = status.Ready
 	}
 	sidecarContainerList := GetSidecarContainersInPod(sidecarSet)
@@ -241,12 +240,11 @@ func IsSidecarContainerUpdateCompleted(pod *v1.Pod, sidecarSets, containers sets
 	}
 
 	containerImages := make(map[string]string, len(pod.Spec.Containers))
-	for i := range pod.Spec.Containers {
-		c := &pod.Spec.Containers[i]
+	for _, c := range getSidecarContainers(pod) {
 		containerImages[c.Name] = c.Image
 	}
 
-	for _, cs := range pod.Status.ContainerStatuses {
+	for _, cs := range getSidecarContainerStatuses(pod) {
 		// only check containers set
 		if !containers.Has(cs.Name) {
 			continue
@@ -266,3 +264,23 @@ func IsSidecarContainerUpdateCompleted(pod *v1.Pod, sidecarSets, containers sets
 
 	return true
 }
+
+// getSidecarContainers returns the containers and the native sidecar initContainers in pod,
+// which may be sidecar containers upgraded in-place.
+func getSidecarContainers(pod *v1.Pod) []v1.Container {
+	var containers []v1.Container
+	for _, c := range pod.Spec.InitContainers {
+		if IsSidecarContainer(c) {
+			containers = append(containers, c)
+		}
+	}
+	return append(containers, pod.Spec.Containers...)
+}
+
+// getSidecarContainerStatuses returns the statuses of containers and initContainers in pod,
+// the statuses of native sidecars are reported in initContainerStatuses.
+func getSidecarContainerStatuses(pod *v1.Pod) []v1.ContainerStatus {
+	statuses := make([]v1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	return append(statuses, pod.Status.ContainerStatuses...)
+}
@@ -38,6 +38,7 @@ import (
 
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	kubeclient "github.com/openkruise/kruise/pkg/client"
 	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
 	utilclient "github.com/openkruise/kruise/pkg/util/client"
@@ -263,7 +264,7 @@ func UpdatePodSidecarSetHash(pod *corev1.Pod, sidecarSet *appsv1beta1.SidecarSet
 
 func GetSidecarContainersInPod(sidecarSet *appsv1beta1.SidecarSet) sets.String {
 	names := sets.NewString()
-	for _, sidecarContainer := range sidecarSet.Spec.Containers {
+	for _, sidecarContainer := range GetUpgradableSidecarContainers(sidecarSet) {
 		if IsHotUpgradeContainer(&sidecarContainer) {
 			name1, name2 := GetHotUpgradeContainerName(sidecarContainer.Name)
 			names.Insert(name2)
@@ -610,6 +611,23 @@ func IsSidecarContainer(container corev1.Container) bool {
 	return false
 }
 
+// GetUpgradableSidecarContainers returns the containers and the native sidecar initContainers of SidecarSet,
+// which can be upgraded in-place in pods.
+func GetUpgradableSidecarContainers(sidecarSet *appsv1beta1.SidecarSet) []appsv1beta1.SidecarContainer {
+	var containers []appsv1beta1.SidecarContainer
+	for _, sidecar := range sidecarSet.Spec.InitContainers {
+		if IsSidecarContainer(sidecar.Container) {
+			containers = append(containers, sidecar)
+		}
+	}
+	return append(containers, sidecarSet.Spec.Containers...)
+}
+
+// IsNativeSidecarInjection returns whether the containers of SidecarSet should be injected as native sidecars.
+func IsNativeSidecarInjection(sidecarSet *appsv1beta1.SidecarSet) bool {
+	return sidecarSet.Spec.InjectionStrategy.NativeSidecar && kubeclient.SupportNativeSidecar()
+}
+
 // listSidecarNameInSidecarSet list always init containers and sidecar containers
 func listSidecarNameInSidecarSet(sidecarSet *appsv1beta1.SidecarSet) sets.String {
 	sidecarList := sets.NewString()
@@ -26,6 +26,7 @@ import (
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
 )
 
 const (
@@ -57,11 +58,36 @@ func GetPodSidecarSetVersionAltAnnotation(cName string) string {
 	return fmt.Sprintf("versionalt.sidecarset.kruise.io/%s", cName)
 }
 
+// GetPodHandoffProbeTimeAnnotation records the last time of the handoff probe in hot upgrade
+// sidecarName is the name of sidecar container in SidecarSet, e.g. mesh
+func GetPodHandoffProbeTimeAnnotation(sidecarName string) string {
+	return fmt.Sprintf("handoff-probe-time.sidecarset.kruise.io/%s", sidecarName)
+}
+
 // IsHotUpgradeContainer indicates whether sidecar container update strategy is HotUpdate
 func IsHotUpgradeContainer(sidecarContainer *appsv1beta1.SidecarContainer) bool {
 	return sidecarContainer.UpgradeStrategy.UpgradeType == appsv1beta1.SidecarContainerHotUpgrade
 }
 
+// IsHotUpgradeWithHandoff indicates whether the hot upgrade of sidecar container is confirmed by the handoff probe,
+// in which the non-working container is the standby with the latest image instead of HotUpgradeEmptyImage.
+func IsHotUpgradeWithHandoff(sidecarContainer *appsv1beta1.SidecarContainer) bool {
+	return IsHotUpgradeContainer(sidecarContainer) && sidecarContainer.UpgradeStrategy.HandoffProbe != nil
+}
+
+// GetHotUpgradeEmptyImage returns the expected image of the non-working hot upgrade container in pod,
+// which is the image of the working container if the hot upgrade is confirmed by the handoff probe.
+func GetHotUpgradeEmptyImage(sidecarContainer *appsv1beta1.SidecarContainer, pod *corev1.Pod) string {
+	if !IsHotUpgradeWithHandoff(sidecarContainer) {
+		return sidecarContainer.UpgradeStrategy.HotUpgradeEmptyImage
+	}
+	workContainer, _ := GetPodHotUpgradeContainers(sidecarContainer.Name, pod)
+	if container := util.GetContainer(workContainer, pod); container != nil {
+		return container.Image
+	}
+	return sidecarContainer.Image
+}
+
 // GetPodHotUpgradeInfoInAnnotations checks which hot upgrade sidecar container is working now
 // format: sidecarset.spec.container[x].name -> pod.spec.container[x].name
 // for example: mesh -> mesh-1, envoy -> envoy-2
@@ -83,7 +109,7 @@ func GetPodHotUpgradeInfoInAnnotations(pod *corev1.Pod) map[string]string {
 // GetPodHotUpgradeContainers return two hot upgrade sidecar containers
 // workContainer: currently working sidecar container, record in pod annotations[kruise.io/sidecarset-working-hotupgrade-container]
 // otherContainer:
-//  1. empty container
+//  1. empty container, or the standby container when the hot upgrade is confirmed by the handoff probe
 //  2. when in hot upgrading process, the older sidecar container
 func GetPodHotUpgradeContainers(sidecarName string, pod *corev1.Pod) (workContainer, otherContainer string) {
 	hotUpgradeWorkContainer := GetPodHotUpgradeInfoInAnnotations(pod)
@@ -110,11 +136,14 @@ func findContainerToHotUpgrade(sidecarContainer *appsv1beta1.SidecarContainer, p
 	c1, c2 := containerInPods[name1], containerInPods[name2]
 
 	// First, empty hot sidecar container will be upgraded with the latest sidecarSet specification
-	if c1.Image == sidecarContainer.UpgradeStrategy.HotUpgradeEmptyImage {
-		return c1.Name, c2.Name
-	}
-	if c2.Image == sidecarContainer.UpgradeStrategy.HotUpgradeEmptyImage {
-		return c2.Name, c1.Name
+	// the standby container with handoff probe has the same image as the working one, so that it is found in the third step
+	if !IsHotUpgradeWithHandoff(sidecarContainer) {
+		if c1.Image == sidecarContainer.UpgradeStrategy.HotUpgradeEmptyImage {
+			return c1.Name, c2.Name
+		}
+		if c2.Image == sidecarContainer.UpgradeStrategy.HotUpgradeEmptyImage {
+			return c2.Name, c1.Name
+		}
 	}
 
 	// Second, Not ready sidecar container will be upgraded
@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloneset
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesetcore "github.com/openkruise/kruise/pkg/controller/cloneset/core"
+	synccontrol "github.com/openkruise/kruise/pkg/controller/cloneset/sync"
+	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+const (
+	// canaryCheckRetryInterval is the interval to retry a failed canary check before it reaches the failure threshold.
+	canaryCheckRetryInterval = 10 * time.Second
+	// canaryCheckPollInterval is the interval to requeue the CloneSet while its canary check is running.
+	canaryCheckPollInterval = time.Second
+)
+
+var (
+	// canaryCheck runs the check gate of a canary step, it can be replaced in tests.
+	canaryCheck = httpCanaryCheck
+
+	// canaryChecks runs the canary checks in background, so that slow checks never block the workers.
+	canaryChecks = &canaryCheckRunner{results: map[string]*canaryCheckResult{}}
+)
+
+type canaryCheckResult struct {
+	id   string
+	done bool
+	err  error
+}
+
+type canaryCheckRunner struct {
+	sync.Mutex
+	// key of CloneSet -> the result of the latest check
+	results map[string]*canaryCheckResult
+}
+
+// Run starts the check in background if the check with the id has not been started for the CloneSet,
+// and returns the result once it has finished. The result is returned only once.
+func (r *canaryCheckRunner) Run(key, id string, check *appsv1beta1.CloneSetCanaryCheck) (done bool, err error) {
+	r.Lock()
+	defer r.Unlock()
+	if result, ok := r.results[key]; ok && result.id == id {
+		if !result.done {
+			return false, nil
+		}
+		delete(r.results, key)
+		return true, result.err
+	}
+
+	result := &canaryCheckResult{id: id}
+	r.results[key] = result
+	go func() {
+		err := canaryCheck(check)
+		r.Lock()
+		defer r.Unlock()
+		result.done, result.err = true, err
+	}()
+	return false, nil
+}
+
+// syncCanarySteps works out the progress of canary steps for the update revision, records it into newStatus,
+// and returns a copy of the CloneSet with the partition of the current step applied.
+// The returned CloneSet should only be used to scale and update pods, never be written back.
+func (r *ReconcileCloneSet) syncCanarySteps(cs *appsv1beta1.CloneSet, newStatus *appsv1beta1.CloneSetStatus, pods []*v1.Pod) *appsv1beta1.CloneSet {
+	if cs.Spec.UpdateStrategy.RollingUpdate == nil || len(cs.Spec.UpdateStrategy.RollingUpdate.CanarySteps) == 0 {
+		newStatus.CanaryStatus = nil
+		return cs
+	}
+
+	now := timer.Now()
+	canaryStatus := cs.Status.CanaryStatus.DeepCopy()
+	if canaryStatus == nil || canaryStatus.Revision != newStatus.UpdateRevision {
+		canaryStatus = &appsv1beta1.CloneSetCanaryStatus{
+			Revision:         newStatus.UpdateRevision,
+			StableRevision:   newStatus.CurrentRevision,
+			CurrentStepState: appsv1beta1.CanaryStepStateUpgrade,
+			LastUpdateTime:   &metav1.Time{Time: now},
+		}
+		if newStatus.CurrentRevision == newStatus.UpdateRevision {
+			canaryStatus.CurrentStepState = appsv1beta1.CanaryStepStateCompleted
+		}
+	}
+	newStatus.CanaryStatus = canaryStatus
+
+	if !clonesetutils.CloneSetBePaused(cs) {
+		r.advanceCanarySteps(cs, canaryStatus, pods, now)
+	}
+
+	partition := getCanaryPartition(cs, canaryStatus)
+	clone := cs.DeepCopy()
+	clone.Spec.UpdateStrategy.RollingUpdate.Partition = &partition
+	return clone
+}
+
+// advanceCanarySteps moves the canary status forward as far as it can in this reconcile.
+func (r *ReconcileCloneSet) advanceCanarySteps(cs *appsv1beta1.CloneSet, canaryStatus *appsv1beta1.CloneSetCanaryStatus, pods []*v1.Pod, now time.Time) {
+	steps := cs.Spec.UpdateStrategy.RollingUpdate.CanarySteps
+	key := clonesetutils.GetControllerKey(cs)
+	setState := func(state appsv1beta1.CloneSetCanaryStepState, message string) {
+		canaryStatus.CurrentStepState = state
+		canaryStatus.Message = message
+		canaryStatus.LastUpdateTime = &metav1.Time{Time: now}
+	}
+
+	for {
+		if int(canaryStatus.CurrentStepIndex) >= len(steps) {
+			if canaryStatus.CurrentStepState != appsv1beta1.CanaryStepStateCompleted {
+				setState(appsv1beta1.CanaryStepStateCompleted, "all canary steps have been completed")
+			}
+			return
+		}
+		step := &steps[canaryStatus.CurrentStepIndex]
+
+		switch canaryStatus.CurrentStepState {
+		case appsv1beta1.CanaryStepStateCompleted, appsv1beta1.CanaryStepStateFailed:
+			return
+
+		case appsv1beta1.CanaryStepStateUpgrade:
+			partition, _ := util.CalculatePartitionReplicas(&step.Partition, cs.Spec.Replicas)
+			expectedUpdated := int(*cs.Spec.Replicas) - partition
+			if updatedAvailable := countUpdatedAvailablePods(cs, pods, canaryStatus.Revision); updatedAvailable < expectedUpdated {
+				return
+			}
+			setState(appsv1beta1.CanaryStepStatePaused, fmt.Sprintf("pods of step %d are available", canaryStatus.CurrentStepIndex))
+
+		case appsv1beta1.CanaryStepStatePaused:
+			if step.PauseSeconds > 0 {
+				pauseEnd := canaryStatus.LastUpdateTime.Add(time.Duration(step.PauseSeconds) * time.Second)
+				if now.Before(pauseEnd) {
+					clonesetutils.DurationStore.Push(key, pauseEnd.Sub(now))
+					return
+				}
+			}
+
+			if step.Check != nil {
+				if canaryStatus.LastCheckTime != nil {
+					if retryTime := canaryStatus.LastCheckTime.Add(canaryCheckRetryInterval); now.Before(retryTime) {
+						clonesetutils.DurationStore.Push(key, retryTime.Sub(now))
+						return
+					}
+				}
+				checkID := fmt.Sprintf("%s/%d/%d", canaryStatus.Revision, canaryStatus.CurrentStepIndex, canaryStatus.CheckFailures)
+				done, err := canaryChecks.Run(key, checkID, step.Check)
+				if !done {
+					canaryStatus.Message = fmt.Sprintf("check of step %d is running", canaryStatus.CurrentStepIndex)
+					clonesetutils.DurationStore.Push(key, canaryCheckPollInterval)
+					return
+				}
+				canaryStatus.LastCheckTime = &metav1.Time{Time: now}
+				if err != nil {
+					canaryStatus.CheckFailures++
+					failureThreshold := step.Check.FailureThreshold
+					if failureThreshold <= 0 {
+						failureThreshold = 1
+					}
+					if canaryStatus.CheckFailures < failureThreshold {
+						canaryStatus.Message = fmt.Sprintf("check of step %d failed %d times: %v", canaryStatus.CurrentStepIndex, canaryStatus.CheckFailures, err)
+						clonesetutils.DurationStore.Push(key, canaryCheckRetryInterval)
+						return
+					}
+					klog.InfoS("CloneSet canary check failed, reset partition to stable revision", "cloneSet", klog.KObj(cs),
+						"step", canaryStatus.CurrentStepIndex, "stableRevision", canaryStatus.StableRevision, "err", err)
+					r.recorder.Eventf(cs, v1.EventTypeWarning, "CanaryCheckFailed",
+						"check of canary step %d failed, rollout stopped and partition reset to stable revision %s: %v",
+						canaryStatus.CurrentStepIndex, canaryStatus.StableRevision, err)
+					setState(appsv1beta1.CanaryStepStateFailed, fmt.Sprintf("check of step %d failed: %v", canaryStatus.CurrentStepIndex, err))
+					return
+				}
+			}
+
+			canaryStatus.CheckFailures = 0
+			canaryStatus.LastCheckTime = nil
+			canaryStatus.CurrentStepIndex++
+			r.recorder.Eventf(cs, v1.EventTypeNormal, "CanaryStepCompleted", "canary step %d of revision %s completed",
+				canaryStatus.CurrentStepIndex-1, canaryStatus.Revision)
+			setState(appsv1beta1.CanaryStepStateUpgrade, fmt.Sprintf("step %d is upgrading", canaryStatus.CurrentStepIndex))
+
+		default:
+			setState(appsv1beta1.CanaryStepStateUpgrade, fmt.Sprintf("step %d is upgrading", canaryStatus.CurrentStepIndex))
+		}
+	}
+}
+
+// getCanaryPartition returns the partition that should be used for the current canary state.
+func getCanaryPartition(cs *appsv1beta1.CloneSet, canaryStatus *appsv1beta1.CloneSetCanaryStatus) intstr.IntOrString {
+	steps := cs.Spec.UpdateStrategy.RollingUpdate.CanarySteps
+	switch {
+	case canaryStatus.CurrentStepState == appsv1beta1.CanaryStepStateFailed:
+		return intstr.FromString("100%")
+	case canaryStatus.CurrentStepState == appsv1beta1.CanaryStepStateCompleted || int(canaryStatus.CurrentStepIndex) >= len(steps):
+		return intstr.FromInt32(0)
+	default:
+		return steps[canaryStatus.CurrentStepIndex].Partition
+	}
+}
+
+func countUpdatedAvailablePods(cs *appsv1beta1.CloneSet, pods []*v1.Pod, updateRevision string) int {
+	coreControl := clonesetcore.New(cs)
+	var count int
+	for _, pod := range pods {
+		if clonesetutils.EqualToRevisionHash("", pod, updateRevision) && synccontrol.IsPodAvailable(coreControl, pod, cs.Spec.MinReadySeconds) {
+			count++
+		}
+	}
+	return count
+}
+
+func httpCanaryCheck(check *appsv1beta1.CloneSetCanaryCheck) error {
+	if check.HTTPGet == nil {
+		return nil
+	}
+	code, err := util.CallHTTPGet(check.HTTPGet.URL, check.HTTPGet.HTTPHeaders, time.Duration(check.TimeoutSeconds)*time.Second)
+	if err != nil {
+		return err
+	}
+	// redirects are not followed, so only 2xx is regarded as success
+	if code < http.StatusOK || code >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d from %s", code, check.HTTPGet.URL)
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloneset
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+func newCanaryTestPods(updated, notUpdated int) []*v1.Pod {
+	var pods []*v1.Pod
+	for i := 0; i < updated+notUpdated; i++ {
+		revision := "rev-new"
+		if i >= updated {
+			revision = "rev-old"
+		}
+		pods = append(pods, &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("pod-%d", i),
+				Labels: map[string]string{apps.ControllerRevisionHashLabelKey: revision},
+			},
+			Status: v1.PodStatus{
+				Phase:      v1.PodRunning,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+			},
+		})
+	}
+	return pods
+}
+
+func newCanaryTestCloneSet(canaryStatus *appsv1beta1.CloneSetCanaryStatus, steps ...appsv1beta1.CloneSetCanaryStep) *appsv1beta1.CloneSet {
+	return &appsv1beta1.CloneSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cs"},
+		Spec: appsv1beta1.CloneSetSpec{
+			Replicas: ptr.To(int32(10)),
+			UpdateStrategy: appsv1beta1.CloneSetUpdateStrategy{
+				RollingUpdate: &appsv1beta1.RollingUpdateCloneSetStrategy{
+					Partition:   ptr.To(intstr.FromInt32(3)),
+					CanarySteps: steps,
+				},
+			},
+		},
+		Status: appsv1beta1.CloneSetStatus{CanaryStatus: canaryStatus},
+	}
+}
+
+func TestSyncCanarySteps(t *testing.T) {
+	now := time.Unix(1000, 0)
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer okServer.Close()
+	failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failServer.Close()
+
+	steps := func(url string) []appsv1beta1.CloneSetCanaryStep {
+		return []appsv1beta1.CloneSetCanaryStep{
+			{Partition: intstr.FromInt32(9), PauseSeconds: 60},
+			{Partition: intstr.FromString("50%"), Check: &appsv1beta1.CloneSetCanaryCheck{HTTPGet: &appsv1beta1.CloneSetCanaryHTTPGetAction{URL: url}}},
+		}
+	}
+
+	cases := []struct {
+		name              string
+		cs                *appsv1beta1.CloneSet
+		pods              []*v1.Pod
+		expectedPartition *intstr.IntOrString
+		expectedStatus    *appsv1beta1.CloneSetCanaryStatus
+		// waitCheck indicates the check runs in background during the first sync, and finishes in the second sync
+		waitCheck bool
+	}{
+		{
+			name:              "no canary steps",
+			cs:                newCanaryTestCloneSet(&appsv1beta1.CloneSetCanaryStatus{Revision: "rev-new"}),
+			pods:              newCanaryTestPods(0, 10),
+			expectedPartition: ptr.To(intstr.FromInt32(3)),
+			expectedStatus:    nil,
+		},
+		{
+			name:              "new revision starts from the first step",
+			cs:                newCanaryTestCloneSet(nil, steps(okServer.URL)...),
+			pods:              newCanaryTestPods(0, 10),
+			expectedPartition: ptr.To(intstr.FromInt32(9)),
+			expectedStatus: &appsv1beta1.CloneSetCanaryStatus{
+				Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 0,
+				CurrentStepState: appsv1beta1.CanaryStepStateUpgrade, LastUpdateTime: &metav1.Time{Time: now},
+			},
+		},
+		{
+			name:              "first step available and begins to pause",
+			cs:                newCanaryTestCloneSet(nil, steps(okServer.URL)...),
+			pods:              newCanaryTestPods(1, 9),
+			expectedPartition: ptr.To(intstr.FromInt32(9)),
+			expectedStatus: &appsv1beta1.CloneSetCanaryStatus{
+				Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 0,
+				CurrentStepState: appsv1beta1.CanaryStepStatePaused, LastUpdateTime: &metav1.Time{Time: now},
+				Message: "pods of step 0 are available",
+			},
+		},
+		{
+			name: "pause finished and moves to the next step",
+			cs: newCanaryTestCloneSet(&appsv1beta1.CloneSetCanaryStatus{
+				Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 0,
+				CurrentStepState: appsv1beta1.CanaryStepStatePaused, LastUpdateTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			}, steps(okServer.URL)...),
+			pods:              newCanaryTestPods(1, 9),
+			expectedPartition: ptr.To(intstr.FromString("50%")),
+			expectedStatus: &appsv1beta1.CloneSetCanaryStatus{
+				Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 1,
+				CurrentStepState: appsv1beta1.CanaryStepStateUpgrade, LastUpdateTime: &metav1.Time{Time: now},
+				Message: "step 1 is upgrading",
+			},
+		},
+		{
+			name: "check passed and all steps completed",
+			cs: newCanaryTestCloneSet(&appsv1beta1.CloneSetCanaryStatus{
+				Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 1,
+				CurrentStepState: appsv1beta1.CanaryStepStateUpgrade, LastUpdateTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			}, steps(okServer.URL)...),
+			pods:              newCanaryTestPods(5, 5),
+			waitCheck:         true,
+			expectedPartition: ptr.To(intstr.FromInt32(0)),
+			expectedStatus: &appsv1beta1.CloneSetCanaryStatus{
+				Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 2,
+				CurrentStepState: appsv1beta1.CanaryStepStateCompleted, LastUpdateTime: &metav1.Time{Time: now},
+				Message: "all canary steps have been completed",
+			},
+		},
+		{
+			name: "check failed and partition reset",
+			cs: newCanaryTestCloneSet(&appsv1beta1.CloneSetCanaryStatus{
+				Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 1,
+				CurrentStepState: appsv1beta1.CanaryStepStatePaused, LastUpdateTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			}, steps(failServer.URL)...),
+			pods:              newCanaryTestPods(5, 5),
+			waitCheck:         true,
+			expectedPartition: ptr.To(intstr.FromString("100%")),
+			expectedStatus: &appsv1beta1.CloneSetCanaryStatus{
+				Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 1, CheckFailures: 1,
+				CurrentStepState: appsv1beta1.CanaryStepStateFailed, LastUpdateTime: &metav1.Time{Time: now},
+				LastCheckTime: &metav1.Time{Time: now},
+				Message:       fmt.Sprintf("check of step 1 failed: unexpected status code 500 from %s", failServer.URL),
+			},
+		},
+		{
+			name: "failed check waits for the retry interval",
+			cs: func() *appsv1beta1.CloneSet {
+				cs := newCanaryTestCloneSet(&appsv1beta1.CloneSetCanaryStatus{
+					Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 1, CheckFailures: 1,
+					CurrentStepState: appsv1beta1.CanaryStepStatePaused, LastUpdateTime: &metav1.Time{Time: now.Add(-time.Minute)},
+					LastCheckTime: &metav1.Time{Time: now.Add(-time.Second)},
+				}, steps(failServer.URL)...)
+				cs.Spec.UpdateStrategy.RollingUpdate.CanarySteps[1].Check.FailureThreshold = 3
+				return cs
+			}(),
+			pods:              newCanaryTestPods(5, 5),
+			expectedPartition: ptr.To(intstr.FromString("50%")),
+			expectedStatus: &appsv1beta1.CloneSetCanaryStatus{
+				Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 1, CheckFailures: 1,
+				CurrentStepState: appsv1beta1.CanaryStepStatePaused, LastUpdateTime: &metav1.Time{Time: now.Add(-time.Minute)},
+				LastCheckTime: &metav1.Time{Time: now.Add(-time.Second)},
+			},
+		},
+		{
+			name: "paused cloneset does not move forward",
+			cs: func() *appsv1beta1.CloneSet {
+				cs := newCanaryTestCloneSet(&appsv1beta1.CloneSetCanaryStatus{
+					Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 0,
+					CurrentStepState: appsv1beta1.CanaryStepStatePaused, LastUpdateTime: &metav1.Time{Time: now.Add(-time.Hour)},
+				}, steps(okServer.URL)...)
+				cs.Spec.UpdateStrategy.RollingUpdate.Paused = true
+				return cs
+			}(),
+			pods:              newCanaryTestPods(1, 9),
+			expectedPartition: ptr.To(intstr.FromInt32(9)),
+			expectedStatus: &appsv1beta1.CloneSetCanaryStatus{
+				Revision: "rev-new", StableRevision: "rev-old", CurrentStepIndex: 0,
+				CurrentStepState: appsv1beta1.CanaryStepStatePaused, LastUpdateTime: &metav1.Time{Time: now.Add(-time.Hour)},
+			},
+		},
+	}
+
+	oldTimer := timer
+	defer func() { timer = oldTimer }()
+	timer = testingclock.NewFakeClock(now)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &ReconcileCloneSet{recorder: record.NewFakeRecorder(10)}
+			newStatus := &appsv1beta1.CloneSetStatus{CurrentRevision: "rev-old", UpdateRevision: "rev-new"}
+			syncSet := r.syncCanarySteps(tc.cs, newStatus, tc.pods)
+			if tc.waitCheck {
+				if msg := newStatus.CanaryStatus.Message; msg != "check of step 1 is running" {
+					t.Fatalf("expected check running, got message %q", msg)
+				}
+				waitCanaryCheckDone(t, "default/cs")
+				newStatus = &appsv1beta1.CloneSetStatus{CurrentRevision: "rev-old", UpdateRevision: "rev-new"}
+				syncSet = r.syncCanarySteps(tc.cs, newStatus, tc.pods)
+			}
+
+			if gotPartition := syncSet.Spec.UpdateStrategy.RollingUpdate.Partition; gotPartition.String() != tc.expectedPartition.String() {
+				t.Fatalf("expected partition %v, got %v", tc.expectedPartition, gotPartition)
+			}
+			if tc.cs.Spec.UpdateStrategy.RollingUpdate.Partition.String() != "3" {
+				t.Fatalf("expected original cloneset not modified, got partition %v", tc.cs.Spec.UpdateStrategy.RollingUpdate.Partition)
+			}
+			if !reflect.DeepEqual(newStatus.CanaryStatus, tc.expectedStatus) {
+				t.Fatalf("expected canary status %s, got %s", util.DumpJSON(tc.expectedStatus), util.DumpJSON(newStatus.CanaryStatus))
+			}
+		})
+	}
+}
+
+func waitCanaryCheckDone(t *testing.T, key string) {
+	for i := 0; i < 100; i++ {
+		canaryChecks.Lock()
+		result := canaryChecks.results[key]
+		done := result != nil && result.done
+		canaryChecks.Unlock()
+		if done {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("canary check of %s not finished", key)
+}
+
+func TestHTTPCanaryCheck(t *testing.T) {
+	cases := []struct {
+		name      string
+		code      int
+		expectErr bool
+	}{
+		{name: "ok", code: http.StatusOK},
+		{name: "no content", code: http.StatusNoContent},
+		{name: "redirect to login page", code: http.StatusFound, expectErr: true},
+		{name: "server error", code: http.StatusInternalServerError, expectErr: true},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if cs.code == http.StatusFound {
+					http.Redirect(w, r, "/login", http.StatusFound)
+					return
+				}
+				w.WriteHeader(cs.code)
+			}))
+			defer server.Close()
+			err := httpCanaryCheck(&appsv1beta1.CloneSetCanaryCheck{HTTPGet: &appsv1beta1.CloneSetCanaryHTTPGetAction{URL: server.URL}})
+			if (err != nil) != cs.expectErr {
+				t.Fatalf("expected error %v, got %v", cs.expectErr, err)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -165,6 +166,14 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to EndpointSlice, for the CloneSets with endpointReadinessGate
+	if utilfeature.DefaultFeatureGate.Enabled(features.CloneSetEndpointReadinessGate) {
+		err = c.Watch(source.Kind(mgr.GetCache(), &discoveryv1.EndpointSlice{}, &endpointSliceEventHandler{Reader: mgr.GetCache()}))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -188,6 +197,8 @@ type ReconcileCloneSet struct {
 // +kubebuilder:rbac:groups=core,resources=pods/resize,verbs=get;patch;update
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=clonesets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=clonesets/status,verbs=get;update;patch
@@ -309,6 +320,11 @@ func (r *ReconcileCloneSet) doReconcile(request reconcile.Request) (res reconcil
 	}
 	history.SortControllerRevisions(revisions)
 
+	// restore the template from history if rollback is required, it will be synced in the next reconcile
+	if modified, err := r.syncRollback(instance, revisions); err != nil || modified {
+		return reconcile.Result{}, err
+	}
+
 	// get the current, and update revisions
 	currentRevision, updateRevision, collisionCount, err := r.getActiveRevisions(instance, revisions)
 	if err != nil {
@@ -379,10 +395,23 @@ func (r *ReconcileCloneSet) doReconcile(request reconcile.Request) (res reconcil
 		}
 	}
 
+	// work out the progress of canary steps, and use the partition of current step to scale and update pods
+	servingPods, _ := clonesetutils.GroupStandbyPods(filteredPods)
+	syncSet := r.syncCanarySteps(instance, &newStatus, servingPods)
+
 	// scale and update pods
-	syncErr := r.syncCloneSet(instance, &newStatus, currentRevision, updateRevision, revisions, filteredPods, filteredPVCs)
+	syncErr := r.syncCloneSet(syncSet, &newStatus, currentRevision, updateRevision, revisions, filteredPods, filteredPVCs)
+	// expand pvcs in place and count the progress
+	if err = r.syncPVCExpansion(instance, filteredPods, filteredPVCs); err != nil && syncErr == nil {
+		syncErr = err
+	}
+	calculateVolumeClaimStatus(instance, &newStatus, filteredPods, filteredPVCs)
 	// update new status
-	if err = r.statusUpdater.UpdateCloneSetStatus(instance, &newStatus, filteredPods); err != nil {
+	if err = r.statusUpdater.UpdateCloneSetStatus(syncSet, &newStatus, filteredPods); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err = r.syncProgressDeadlineExceeded(instance, &newStatus); err != nil {
 		return reconcile.Result{}, err
 	}
 
@@ -438,7 +467,9 @@ func (r *ReconcileCloneSet) syncCloneSet(
 		return podsScaleErr
 	}
 
-	podsUpdateErr = r.syncControl.Update(updateSet, currentRevision, updateRevision, revisions, filteredPods, filteredPVCs)
+	// standby pods are always created with update revision and recreated by Scale if outdated
+	servingPods, _ := clonesetutils.GroupStandbyPods(filteredPods)
+	podsUpdateErr = r.syncControl.Update(updateSet, currentRevision, updateRevision, revisions, servingPods, filteredPVCs)
 	if podsUpdateErr != nil {
 		cond := appsv1beta1.CloneSetCondition{
 			Type:               appsv1beta1.CloneSetConditionFailedUpdate,
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -40,6 +42,7 @@ import (
 	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util/expectations"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/fieldindex"
 )
 
 var (
@@ -298,6 +301,16 @@ func (e *pvcEventHandler) Update(ctx context.Context, evt event.TypedUpdateEvent
 	pvc := evt.ObjectNew
 	if pvc.DeletionTimestamp != nil {
 		e.Delete(ctx, event.TypedDeleteEvent[*v1.PersistentVolumeClaim]{Object: evt.ObjectNew}, q)
+		return
+	}
+
+	// refresh the progress of in-place expansion
+	if !apiequality.Semantic.DeepEqual(evt.ObjectOld.Status.Capacity, pvc.Status.Capacity) {
+		if controllerRef := metav1.GetControllerOf(pvc); controllerRef != nil {
+			if req := resolveControllerRef(pvc.Namespace, controllerRef); req != nil {
+				q.Add(*req)
+			}
+		}
 	}
 }
 
@@ -314,3 +327,45 @@ func (e *pvcEventHandler) Delete(ctx context.Context, evt event.TypedDeleteEvent
 func (e *pvcEventHandler) Generic(ctx context.Context, evt event.TypedGenericEvent[*v1.PersistentVolumeClaim], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 
 }
+
+type endpointSliceEventHandler struct {
+	client.Reader
+}
+
+var _ handler.TypedEventHandler[*discoveryv1.EndpointSlice, reconcile.Request] = &endpointSliceEventHandler{}
+
+func (e *endpointSliceEventHandler) Create(ctx context.Context, evt event.TypedCreateEvent[*discoveryv1.EndpointSlice], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.enqueueCloneSets(evt.Object, q)
+}
+
+func (e *endpointSliceEventHandler) Update(ctx context.Context, evt event.TypedUpdateEvent[*discoveryv1.EndpointSlice], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if evt.ObjectNew.ResourceVersion == evt.ObjectOld.ResourceVersion {
+		return
+	}
+	e.enqueueCloneSets(evt.ObjectNew, q)
+}
+
+func (e *endpointSliceEventHandler) Delete(ctx context.Context, evt event.TypedDeleteEvent[*discoveryv1.EndpointSlice], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.enqueueCloneSets(evt.Object, q)
+}
+
+func (e *endpointSliceEventHandler) Generic(ctx context.Context, evt event.TypedGenericEvent[*discoveryv1.EndpointSlice], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+// enqueueCloneSets enqueues the CloneSets whose endpointReadinessGate refers to the Service of the EndpointSlice.
+func (e *endpointSliceEventHandler) enqueueCloneSets(slice *discoveryv1.EndpointSlice, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	serviceName := slice.Labels[discoveryv1.LabelServiceName]
+	if serviceName == "" {
+		return
+	}
+	csList := appsv1beta1.CloneSetList{}
+	if err := e.List(context.TODO(), &csList, client.InNamespace(slice.Namespace),
+		client.MatchingFields{fieldindex.IndexNameForCloneSetEndpointReadinessService: serviceName}); err != nil {
+		klog.ErrorS(err, "Failed to list CloneSets for EndpointSlice", "endpointSlice", klog.KObj(slice))
+		return
+	}
+	for i := range csList.Items {
+		cs := &csList.Items[i]
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cs.Namespace, Name: cs.Name}})
+	}
+}
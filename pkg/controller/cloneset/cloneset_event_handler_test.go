@@ -22,6 +22,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,6 +35,7 @@ import (
 	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util/expectations"
 	"github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/fieldindex"
 )
 
 func newTestPodEventHandler(reader client.Reader) *podEventHandler {
@@ -823,3 +825,40 @@ func TestGetPodCloneSets(t *testing.T) {
 		}
 	}
 }
+
+func TestEnqueueRequestForEndpointSlice(t *testing.T) {
+	newCloneSet := func(name, namespace, serviceName string) *appsv1beta1.CloneSet {
+		cs := &appsv1beta1.CloneSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		if serviceName != "" {
+			cs.Spec.UpdateStrategy.RollingUpdate = &appsv1beta1.RollingUpdateCloneSetStrategy{
+				EndpointReadinessGate: &appsv1beta1.CloneSetEndpointReadinessGate{ServiceName: serviceName},
+			}
+		}
+		return cs
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(newCloneSet("cs01", "default", "svc"), newCloneSet("cs02", "default", "other"),
+			newCloneSet("cs03", "default", ""), newCloneSet("cs04", "test", "svc")).
+		WithIndex(&appsv1beta1.CloneSet{}, fieldindex.IndexNameForCloneSetEndpointReadinessService, fieldindex.IndexCloneSetEndpointReadinessService).
+		Build()
+
+	enqueueHandler := &endpointSliceEventHandler{Reader: fakeClient}
+	q := workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
+		workqueue.TypedRateLimitingQueueConfig[reconcile.Request]{
+			Name: "test-queue",
+		},
+	)
+	slice := &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{
+		Name:      "svc-abc",
+		Namespace: "default",
+		Labels:    map[string]string{discoveryv1.LabelServiceName: "svc"},
+	}}
+	enqueueHandler.Create(context.TODO(), event.TypedCreateEvent[*discoveryv1.EndpointSlice]{Object: slice}, q)
+	if q.Len() != 1 {
+		t.Fatalf("expected queue len 1, got %d", q.Len())
+	}
+	if item, _ := q.Get(); item.Name != "cs01" {
+		t.Fatalf("expected cs01 enqueued, got %v", item)
+	}
+}
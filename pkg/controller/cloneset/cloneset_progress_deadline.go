@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloneset
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
+)
+
+// syncProgressDeadlineExceeded pauses the update or rolls back the partition of CloneSet according to
+// spec.progressDeadlineExceededPolicy, once the ProgressDeadlineExceeded condition has been set.
+func (r *ReconcileCloneSet) syncProgressDeadlineExceeded(cs *appsv1beta1.CloneSet, newStatus *appsv1beta1.CloneSetStatus) error {
+	policy := cs.Spec.ProgressDeadlineExceededPolicy
+	if cs.DeletionTimestamp != nil || policy == "" || policy == appsv1beta1.NoneProgressDeadlineExceededPolicyType {
+		return nil
+	}
+	condition := clonesetutils.GetCloneSetCondition(*newStatus, appsv1beta1.CloneSetConditionTypeProgressing)
+	if condition == nil || condition.Reason != string(appsv1beta1.CloneSetProgressDeadlineExceeded) {
+		return nil
+	}
+
+	clone := cs.DeepCopy()
+	if clone.Spec.UpdateStrategy.RollingUpdate == nil {
+		clone.Spec.UpdateStrategy.RollingUpdate = &appsv1beta1.RollingUpdateCloneSetStrategy{}
+	}
+	rollingUpdate := clone.Spec.UpdateStrategy.RollingUpdate
+	var action string
+	switch policy {
+	case appsv1beta1.PauseProgressDeadlineExceededPolicyType:
+		if rollingUpdate.Paused {
+			return nil
+		}
+		rollingUpdate.Paused = true
+		action = "paused the update"
+	case appsv1beta1.RollbackPartitionProgressDeadlineExceededPolicyType:
+		partition := intstr.FromString("100%")
+		if rollingUpdate.Partition != nil && *rollingUpdate.Partition == partition {
+			return nil
+		}
+		rollingUpdate.Partition = &partition
+		action = "rolled back the partition to 100%"
+	default:
+		return nil
+	}
+
+	klog.InfoS("CloneSet exceeded progress deadline", "cloneSet", klog.KObj(cs), "policy", policy)
+	if err := r.Patch(context.TODO(), clone, client.MergeFrom(cs)); err != nil {
+		return err
+	}
+	r.recorder.Eventf(cs, v1.EventTypeWarning, string(appsv1beta1.CloneSetProgressDeadlineExceeded), "%s, and %s", condition.Message, action)
+	return nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloneset
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesettest "github.com/openkruise/kruise/pkg/controller/cloneset/test"
+	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
+)
+
+func TestSyncProgressDeadlineExceeded(t *testing.T) {
+	cases := []struct {
+		name              string
+		policy            appsv1beta1.CloneSetProgressDeadlineExceededPolicyType
+		reason            appsv1beta1.CloneSetConditionReason
+		expectedPaused    bool
+		expectedPartition *intstr.IntOrString
+	}{
+		{
+			name:   "none policy",
+			policy: appsv1beta1.NoneProgressDeadlineExceededPolicyType,
+			reason: appsv1beta1.CloneSetProgressDeadlineExceeded,
+		},
+		{
+			name:   "not exceeded",
+			policy: appsv1beta1.PauseProgressDeadlineExceededPolicyType,
+			reason: appsv1beta1.CloneSetProgressUpdated,
+		},
+		{
+			name:           "pause",
+			policy:         appsv1beta1.PauseProgressDeadlineExceededPolicyType,
+			reason:         appsv1beta1.CloneSetProgressDeadlineExceeded,
+			expectedPaused: true,
+		},
+		{
+			name:              "rollback partition",
+			policy:            appsv1beta1.RollbackPartitionProgressDeadlineExceededPolicyType,
+			reason:            appsv1beta1.CloneSetProgressDeadlineExceeded,
+			expectedPartition: &intstr.IntOrString{Type: intstr.String, StrVal: "100%"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := clonesettest.NewCloneSet(3)
+			cs.Spec.ProgressDeadlineExceededPolicy = tc.policy
+			cs.Spec.UpdateStrategy.RollingUpdate = &appsv1beta1.RollingUpdateCloneSetStrategy{}
+			newStatus := &appsv1beta1.CloneSetStatus{}
+			clonesetutils.SetCloneSetCondition(newStatus, *clonesetutils.NewCloneSetCondition(appsv1beta1.CloneSetConditionTypeProgressing,
+				v1.ConditionFalse, tc.reason, "CloneSet revision 2 has timed out progressing, 1 updated pods are not ready", timer.Now()))
+
+			fakeClient := fake.NewClientBuilder().WithScheme(testscheme).WithObjects(cs).Build()
+			r := &ReconcileCloneSet{Client: fakeClient, recorder: record.NewFakeRecorder(10)}
+			if err := r.syncProgressDeadlineExceeded(cs, newStatus); err != nil {
+				t.Fatal(err)
+			}
+
+			got := &appsv1beta1.CloneSet{}
+			if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(cs), got); err != nil {
+				t.Fatal(err)
+			}
+			rollingUpdate := got.Spec.UpdateStrategy.RollingUpdate
+			if rollingUpdate.Paused != tc.expectedPaused {
+				t.Fatalf("expected paused %v, got %v", tc.expectedPaused, rollingUpdate.Paused)
+			}
+			if (tc.expectedPartition == nil) != (rollingUpdate.Partition == nil) ||
+				(tc.expectedPartition != nil && *tc.expectedPartition != *rollingUpdate.Partition) {
+				t.Fatalf("expected partition %v, got %v", tc.expectedPartition, rollingUpdate.Partition)
+			}
+		})
+	}
+}
+
+func TestProgressDeadlineExceededConditionKept(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy appsv1beta1.CloneSetProgressDeadlineExceededPolicyType
+		resume func(rollingUpdate *appsv1beta1.RollingUpdateCloneSetStrategy)
+	}{
+		{
+			name:   "pause",
+			policy: appsv1beta1.PauseProgressDeadlineExceededPolicyType,
+			resume: func(rollingUpdate *appsv1beta1.RollingUpdateCloneSetStrategy) {
+				rollingUpdate.Paused = false
+			},
+		},
+		{
+			name:   "rollback partition",
+			policy: appsv1beta1.RollbackPartitionProgressDeadlineExceededPolicyType,
+			resume: func(rollingUpdate *appsv1beta1.RollingUpdateCloneSetStrategy) {
+				rollingUpdate.Partition = nil
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := clonesettest.NewCloneSet(3)
+			cs.Spec.ProgressDeadlineSeconds = ptr.To[int32](60)
+			cs.Spec.ProgressDeadlineExceededPolicy = tc.policy
+			cs.Spec.UpdateStrategy.RollingUpdate = &appsv1beta1.RollingUpdateCloneSetStrategy{}
+			cs.Status.UpdateRevision = "rev-2"
+			clonesetutils.SetCloneSetCondition(&cs.Status, *clonesetutils.NewCloneSetCondition(appsv1beta1.CloneSetConditionTypeProgressing,
+				v1.ConditionTrue, appsv1beta1.CloneSetProgressUpdated, "CloneSet is progressing", timer.Now().Add(-time.Hour)))
+
+			fakeClient := fake.NewClientBuilder().WithScheme(testscheme).WithObjects(cs).WithStatusSubresource(&appsv1beta1.CloneSet{}).Build()
+			r := &ReconcileCloneSet{Client: fakeClient, recorder: record.NewFakeRecorder(10), statusUpdater: newStatusUpdater(fakeClient)}
+			reconcileOnce := func() *appsv1beta1.CloneSetCondition {
+				got := &appsv1beta1.CloneSet{}
+				if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(cs), got); err != nil {
+					t.Fatal(err)
+				}
+				newStatus := &appsv1beta1.CloneSetStatus{UpdateRevision: "rev-2", CurrentRevision: "rev-1", Conditions: got.Status.Conditions}
+				if err := r.statusUpdater.UpdateCloneSetStatus(got, newStatus, nil); err != nil {
+					t.Fatal(err)
+				}
+				if err := r.syncProgressDeadlineExceeded(got, newStatus); err != nil {
+					t.Fatal(err)
+				}
+				if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(cs), got); err != nil {
+					t.Fatal(err)
+				}
+				return clonesetutils.GetCloneSetCondition(got.Status, appsv1beta1.CloneSetConditionTypeProgressDeadlineExceeded)
+			}
+
+			// the first reconcile finds the deadline exceeded and applies the policy
+			condition := reconcileOnce()
+			if condition == nil || condition.Reason != string(tc.policy) || !strings.Contains(condition.Message, "rev-2 has timed out progressing") {
+				t.Fatalf("expected ProgressDeadlineExceeded condition after the first reconcile, got %v", condition)
+			}
+			// the second reconcile sees the update stopped by the policy and keeps the condition
+			condition = reconcileOnce()
+			if condition == nil || condition.Reason != string(tc.policy) || !strings.Contains(condition.Message, "rev-2 has timed out progressing") {
+				t.Fatalf("expected ProgressDeadlineExceeded condition kept after the second reconcile, got %v", condition)
+			}
+
+			got := &appsv1beta1.CloneSet{}
+			if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(cs), got); err != nil {
+				t.Fatal(err)
+			}
+			tc.resume(got.Spec.UpdateStrategy.RollingUpdate)
+			if err := fakeClient.Update(context.TODO(), got); err != nil {
+				t.Fatal(err)
+			}
+			if condition = reconcileOnce(); condition != nil {
+				t.Fatalf("expected ProgressDeadlineExceeded condition removed after the update is resumed, got %v", condition)
+			}
+		})
+	}
+}
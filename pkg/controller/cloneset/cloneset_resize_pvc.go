@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloneset
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
+	"github.com/openkruise/kruise/pkg/util/pvc"
+)
+
+// handleClaimFn handles a claim of pod and its template, it returns false to stop handling the rest claims.
+type handleClaimFn = func(claim, template *v1.PersistentVolumeClaim) (bool, error)
+
+// handleOwnedClaims calls fn for each existing claim of the pods, the terminating claims are ignored.
+func handleOwnedClaims(cs *appsv1beta1.CloneSet, pods []*v1.Pod, pvcs []*v1.PersistentVolumeClaim, fn handleClaimFn) error {
+	claimsByName := make(map[string]*v1.PersistentVolumeClaim, len(pvcs))
+	for _, claim := range pvcs {
+		claimsByName[claim.Name] = claim
+	}
+	templates := cs.Spec.VolumeClaimTemplates
+	for _, pod := range pods {
+		claims := clonesetutils.GetPersistentVolumeClaims(cs, pod)
+		for i := range templates {
+			expected := claims[templates[i].Name]
+			claim, ok := claimsByName[expected.Name]
+			if !ok || claim.DeletionTimestamp != nil {
+				continue
+			}
+			if goOn, err := fn(claim, &templates[i]); err != nil || !goOn {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// syncPVCExpansion expands the bound PVCs of pods in place, if only the storage requests in volumeClaimTemplates
+// grow and spec.volumeClaimUpdateStrategy.type is InPlaceExpand.
+func (r *ReconcileCloneSet) syncPVCExpansion(cs *appsv1beta1.CloneSet, pods []*v1.Pod, pvcs []*v1.PersistentVolumeClaim) error {
+	if cs.DeletionTimestamp != nil || len(cs.Spec.VolumeClaimTemplates) == 0 ||
+		cs.Spec.VolumeClaimUpdateStrategy.Type != appsv1beta1.InPlaceExpandCloneSetVolumeClaimUpdateStrategyType {
+		return nil
+	}
+
+	expandable := map[string]bool{}
+	fn := func(claim, template *v1.PersistentVolumeClaim) (bool, error) {
+		if claim.Status.Phase != v1.ClaimBound {
+			return true, nil
+		}
+		matched, needExpand := pvc.CompareWithCheckFn(claim, template, pvc.IsPVCNeedExpand)
+		if matched || !needExpand {
+			return true, nil
+		}
+
+		// only pvc expand => check storage class allow expansion
+		if claim.Spec.StorageClassName != nil {
+			scName := *claim.Spec.StorageClassName
+			allowed, ok := expandable[scName]
+			if !ok {
+				sc := &storagev1.StorageClass{}
+				if err := r.Get(context.TODO(), types.NamespacedName{Name: scName}, sc); err != nil {
+					return false, fmt.Errorf("could not get sc %s for %s when checking PVC spec: %v", scName, claim.Name, err)
+				}
+				allowed = sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion
+				expandable[scName] = allowed
+			}
+			if !allowed {
+				r.recorder.Eventf(cs, v1.EventTypeWarning, "FailedResizePVC", "storage class %s for %s does not support volume expansion", scName, claim.Name)
+				return true, nil
+			}
+		}
+
+		claimClone := claim.DeepCopy()
+		claimClone.Spec.Resources = template.Spec.Resources
+		if err := r.Update(context.TODO(), claimClone); err != nil {
+			r.recorder.Eventf(cs, v1.EventTypeWarning, "FailedResizePVC", "failed to resize pvc %s: %v", claim.Name, err)
+			return false, fmt.Errorf("could not update claim %s: %w", claim.Name, err)
+		}
+		klog.V(3).InfoS("CloneSet resized pvc in place", "cloneSet", klog.KObj(cs), "pvc", klog.KObj(claim))
+		r.recorder.Eventf(cs, v1.EventTypeNormal, "SuccessfulResizePVC", "succeed to resize pvc %s", claim.Name)
+		return true, nil
+	}
+	return handleOwnedClaims(cs, pods, pvcs, fn)
+}
+
+// calculateVolumeClaimStatus counts the PVCs of pods which are compatible with volumeClaimTemplates
+// and have been resized.
+func calculateVolumeClaimStatus(cs *appsv1beta1.CloneSet, newStatus *appsv1beta1.CloneSetStatus, pods []*v1.Pod, pvcs []*v1.PersistentVolumeClaim) {
+	templates := cs.Spec.VolumeClaimTemplates
+	if len(templates) == 0 {
+		newStatus.VolumeClaims = nil
+		return
+	}
+	newStatus.VolumeClaims = make([]appsv1beta1.CloneSetVolumeClaimStatus, len(templates))
+	templateStatus := make(map[string]*appsv1beta1.CloneSetVolumeClaimStatus, len(templates))
+	for i := range templates {
+		newStatus.VolumeClaims[i].VolumeClaimName = templates[i].Name
+		templateStatus[templates[i].Name] = &newStatus.VolumeClaims[i]
+	}
+
+	fn := func(claim, template *v1.PersistentVolumeClaim) (bool, error) {
+		if compatible, ready := pvc.IsPVCCompatibleAndReady(claim, template); compatible {
+			templateStatus[template.Name].CompatibleReplicas++
+			if ready {
+				templateStatus[template.Name].CompatibleReadyReplicas++
+			}
+		}
+		return true, nil
+	}
+	_ = handleOwnedClaims(cs, pods, pvcs, fn)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloneset
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesettest "github.com/openkruise/kruise/pkg/controller/cloneset/test"
+)
+
+func newResizeTestPod(id string) *v1.Pod {
+	return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "foo-" + id,
+		Namespace: v1.NamespaceDefault,
+		Labels:    map[string]string{appsv1beta1.CloneSetInstanceID: id},
+	}}
+}
+
+func newResizeTestPVC(id, sc, request, capacity string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datadir-foo-" + id,
+			Namespace: v1.NamespaceDefault,
+			Labels:    map[string]string{appsv1beta1.CloneSetInstanceID: id},
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: utilpointer.String(sc),
+			Resources: v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(request)},
+			},
+		},
+		Status: v1.PersistentVolumeClaimStatus{
+			Phase:    v1.ClaimBound,
+			Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse(capacity)},
+		},
+	}
+}
+
+func TestSyncPVCExpansion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1.AddToScheme(scheme)
+	_ = storagev1.AddToScheme(scheme)
+	_ = appsv1beta1.AddToScheme(scheme)
+	storageClasses := []client.Object{
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "expandable"}, AllowVolumeExpansion: utilpointer.Bool(true)},
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fixed"}},
+	}
+
+	cases := []struct {
+		name             string
+		strategy         appsv1beta1.CloneSetVolumeClaimUpdateStrategyType
+		templateSC       *string
+		pvc              *v1.PersistentVolumeClaim
+		expectedRequest  string
+		expectedStatuses []appsv1beta1.CloneSetVolumeClaimStatus
+	}{
+		{
+			name:            "expand the claim in place",
+			strategy:        appsv1beta1.InPlaceExpandCloneSetVolumeClaimUpdateStrategyType,
+			pvc:             newResizeTestPVC("a", "expandable", "1Gi", "1Gi"),
+			expectedRequest: "2Gi",
+			expectedStatuses: []appsv1beta1.CloneSetVolumeClaimStatus{
+				{VolumeClaimName: "datadir", CompatibleReplicas: 1},
+			},
+		},
+		{
+			name:            "storage class does not allow expansion",
+			strategy:        appsv1beta1.InPlaceExpandCloneSetVolumeClaimUpdateStrategyType,
+			pvc:             newResizeTestPVC("a", "fixed", "1Gi", "1Gi"),
+			expectedRequest: "1Gi",
+			expectedStatuses: []appsv1beta1.CloneSetVolumeClaimStatus{
+				{VolumeClaimName: "datadir"},
+			},
+		},
+		{
+			name:            "storage class changed",
+			strategy:        appsv1beta1.InPlaceExpandCloneSetVolumeClaimUpdateStrategyType,
+			templateSC:      utilpointer.String("fixed"),
+			pvc:             newResizeTestPVC("a", "expandable", "1Gi", "1Gi"),
+			expectedRequest: "1Gi",
+			expectedStatuses: []appsv1beta1.CloneSetVolumeClaimStatus{
+				{VolumeClaimName: "datadir"},
+			},
+		},
+		{
+			name:            "on delete strategy",
+			strategy:        appsv1beta1.OnDeleteCloneSetVolumeClaimUpdateStrategyType,
+			pvc:             newResizeTestPVC("a", "expandable", "1Gi", "1Gi"),
+			expectedRequest: "1Gi",
+			expectedStatuses: []appsv1beta1.CloneSetVolumeClaimStatus{
+				{VolumeClaimName: "datadir"},
+			},
+		},
+		{
+			name:            "claim already expanded",
+			strategy:        appsv1beta1.InPlaceExpandCloneSetVolumeClaimUpdateStrategyType,
+			pvc:             newResizeTestPVC("a", "expandable", "2Gi", "2Gi"),
+			expectedRequest: "2Gi",
+			expectedStatuses: []appsv1beta1.CloneSetVolumeClaimStatus{
+				{VolumeClaimName: "datadir", CompatibleReplicas: 1, CompatibleReadyReplicas: 1},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := clonesettest.NewCloneSet(1)
+			cs.Spec.VolumeClaimUpdateStrategy.Type = tc.strategy
+			cs.Spec.VolumeClaimTemplates[0].Spec.StorageClassName = tc.templateSC
+			cs.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[v1.ResourceStorage] = resource.MustParse("2Gi")
+			pods := []*v1.Pod{newResizeTestPod("a")}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(storageClasses...).WithObjects(tc.pvc).Build()
+			r := &ReconcileCloneSet{Client: fakeClient, recorder: record.NewFakeRecorder(10)}
+			if err := r.syncPVCExpansion(cs, pods, []*v1.PersistentVolumeClaim{tc.pvc}); err != nil {
+				t.Fatal(err)
+			}
+
+			got := &v1.PersistentVolumeClaim{}
+			if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(tc.pvc), got); err != nil {
+				t.Fatal(err)
+			}
+			if request := got.Spec.Resources.Requests[v1.ResourceStorage]; request.Cmp(resource.MustParse(tc.expectedRequest)) != 0 {
+				t.Fatalf("expected request %s, got %s", tc.expectedRequest, request.String())
+			}
+
+			newStatus := &appsv1beta1.CloneSetStatus{}
+			calculateVolumeClaimStatus(cs, newStatus, pods, []*v1.PersistentVolumeClaim{got})
+			if !reflect.DeepEqual(newStatus.VolumeClaims, tc.expectedStatuses) {
+				t.Fatalf("expected volume claim status %v, got %v", tc.expectedStatuses, newStatus.VolumeClaims)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloneset
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
+)
+
+// syncRollback restores the template of CloneSet from the revision specified by spec.rollbackTo or the
+// rollback annotation, and then clears them. It returns true if the CloneSet has been modified.
+func (r *ReconcileCloneSet) syncRollback(cs *appsv1beta1.CloneSet, revisions []*apps.ControllerRevision) (bool, error) {
+	if cs.DeletionTimestamp != nil {
+		return false, nil
+	}
+	toRevision, found, parseErr := getRollbackToRevision(cs)
+	if !found {
+		return false, nil
+	}
+
+	clone := cs.DeepCopy()
+	clone.Spec.RollbackTo = nil
+	delete(clone.Annotations, appsv1beta1.CloneSetRollbackToRevisionAnnotation)
+
+	var condition *appsv1beta1.CloneSetCondition
+	if parseErr != nil {
+		msg := fmt.Sprintf("Invalid annotation %s: %v", appsv1beta1.CloneSetRollbackToRevisionAnnotation, parseErr)
+		r.recorder.Event(cs, v1.EventTypeWarning, string(appsv1beta1.CloneSetRollbackRevisionNotFound), msg)
+		condition = clonesetutils.NewCloneSetCondition(appsv1beta1.CloneSetConditionTypeRollback,
+			v1.ConditionFalse, appsv1beta1.CloneSetRollbackRevisionNotFound, msg, timer.Now())
+	} else {
+		rollbackRevision, err := r.revisionControl.GetRollbackRevision(cs, revisions, toRevision)
+		if err != nil {
+			return false, err
+		}
+		if rollbackRevision == nil {
+			msg := fmt.Sprintf("Unable to find revision %d to roll back to", toRevision)
+			r.recorder.Event(cs, v1.EventTypeWarning, string(appsv1beta1.CloneSetRollbackRevisionNotFound), msg)
+			condition = clonesetutils.NewCloneSetCondition(appsv1beta1.CloneSetConditionTypeRollback,
+				v1.ConditionFalse, appsv1beta1.CloneSetRollbackRevisionNotFound, msg, timer.Now())
+		} else {
+			restoredSet, err := r.revisionControl.ApplyRevision(cs, rollbackRevision)
+			if err != nil {
+				return false, err
+			}
+			clone.Spec.Template = restoredSet.Spec.Template
+
+			msg := fmt.Sprintf("CloneSet is rolling back to revision %d (%s)", rollbackRevision.Revision, rollbackRevision.Name)
+			r.recorder.Event(cs, v1.EventTypeNormal, string(appsv1beta1.CloneSetRollbackInProgress), msg)
+			condition = clonesetutils.NewCloneSetCondition(appsv1beta1.CloneSetConditionTypeRollback,
+				v1.ConditionFalse, appsv1beta1.CloneSetRollbackInProgress, msg, timer.Now())
+		}
+	}
+
+	klog.InfoS("CloneSet began to roll back", "cloneSet", klog.KObj(cs), "revision", toRevision, "reason", condition.Reason)
+	if err := r.Update(context.TODO(), clone); err != nil {
+		return false, err
+	}
+	clonesetutils.SetCloneSetCondition(&clone.Status, *condition)
+	if err := r.Status().Update(context.TODO(), clone); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// getRollbackToRevision returns the revision to roll back to, spec.rollbackTo takes precedence over the annotation.
+func getRollbackToRevision(cs *appsv1beta1.CloneSet) (revision int64, found bool, err error) {
+	if cs.Spec.RollbackTo != nil {
+		return cs.Spec.RollbackTo.Revision, true, nil
+	}
+	value, ok := cs.Annotations[appsv1beta1.CloneSetRollbackToRevisionAnnotation]
+	if !ok {
+		return 0, false, nil
+	}
+	revision, err = strconv.ParseInt(value, 10, 64)
+	if err == nil && revision < 0 {
+		err = fmt.Errorf("revision %d must be non-negative", revision)
+	}
+	return revision, true, err
+}
+
+// calculateRollbackStatus marks the rollback in progress as completed once the expected pods have been
+// updated to the restored revision and become ready.
+func calculateRollbackStatus(newStatus *appsv1beta1.CloneSetStatus) {
+	condition := clonesetutils.GetCloneSetCondition(*newStatus, appsv1beta1.CloneSetConditionTypeRollback)
+	if condition == nil || condition.Reason != string(appsv1beta1.CloneSetRollbackInProgress) {
+		return
+	}
+	if newStatus.UpdatedReadyReplicas < newStatus.ExpectedUpdatedReplicas {
+		return
+	}
+	msg := fmt.Sprintf("CloneSet has finished rolling back to %s", newStatus.UpdateRevision)
+	clonesetutils.SetCloneSetCondition(newStatus, *clonesetutils.NewCloneSetCondition(appsv1beta1.CloneSetConditionTypeRollback,
+		v1.ConditionTrue, appsv1beta1.CloneSetRollbackCompleted, msg, timer.Now()))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloneset
+
+import (
+	"context"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	revisioncontrol "github.com/openkruise/kruise/pkg/controller/cloneset/revision"
+	clonesettest "github.com/openkruise/kruise/pkg/controller/cloneset/test"
+	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
+)
+
+func TestSyncRollback(t *testing.T) {
+	revisionControl := revisioncontrol.NewRevisionControl()
+	newRevisions := func(cs *appsv1beta1.CloneSet) []*apps.ControllerRevision {
+		var revisions []*apps.ControllerRevision
+		for i, image := range []string{"nginx:1", "nginx:2"} {
+			clone := cs.DeepCopy()
+			clone.Spec.Template.Spec.Containers[0].Image = image
+			revision, err := revisionControl.NewRevision(clone, int64(i+1), clone.Status.CollisionCount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			revisions = append(revisions, revision)
+		}
+		return revisions
+	}
+
+	cases := []struct {
+		name             string
+		setup            func(cs *appsv1beta1.CloneSet)
+		expectedModified bool
+		expectedImage    string
+		expectedReason   appsv1beta1.CloneSetConditionReason
+	}{
+		{
+			name:             "no rollback",
+			setup:            func(cs *appsv1beta1.CloneSet) {},
+			expectedModified: false,
+			expectedImage:    "nginx:2",
+		},
+		{
+			name: "rollback to the last revision by spec",
+			setup: func(cs *appsv1beta1.CloneSet) {
+				cs.Spec.RollbackTo = &appsv1beta1.CloneSetRollbackConfig{}
+			},
+			expectedModified: true,
+			expectedImage:    "nginx:1",
+			expectedReason:   appsv1beta1.CloneSetRollbackInProgress,
+		},
+		{
+			name: "rollback to the specified revision by annotation",
+			setup: func(cs *appsv1beta1.CloneSet) {
+				cs.Annotations = map[string]string{appsv1beta1.CloneSetRollbackToRevisionAnnotation: "1"}
+			},
+			expectedModified: true,
+			expectedImage:    "nginx:1",
+			expectedReason:   appsv1beta1.CloneSetRollbackInProgress,
+		},
+		{
+			name: "revision not found",
+			setup: func(cs *appsv1beta1.CloneSet) {
+				cs.Spec.RollbackTo = &appsv1beta1.CloneSetRollbackConfig{Revision: 5}
+			},
+			expectedModified: true,
+			expectedImage:    "nginx:2",
+			expectedReason:   appsv1beta1.CloneSetRollbackRevisionNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := clonesettest.NewCloneSet(3)
+			cs.Status.CollisionCount = new(int32)
+			cs.Spec.Template.Spec.Containers[0].Image = "nginx:2"
+			tc.setup(cs)
+			revisions := newRevisions(cs)
+
+			fakeClient := fake.NewClientBuilder().WithScheme(testscheme).WithObjects(cs).WithStatusSubresource(&appsv1beta1.CloneSet{}).Build()
+			r := &ReconcileCloneSet{Client: fakeClient, recorder: record.NewFakeRecorder(10), revisionControl: revisionControl}
+			modified, err := r.syncRollback(cs, revisions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if modified != tc.expectedModified {
+				t.Fatalf("expected modified %v, got %v", tc.expectedModified, modified)
+			}
+
+			got := &appsv1beta1.CloneSet{}
+			if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(cs), got); err != nil {
+				t.Fatal(err)
+			}
+			if image := got.Spec.Template.Spec.Containers[0].Image; image != tc.expectedImage {
+				t.Fatalf("expected image %s, got %s", tc.expectedImage, image)
+			}
+			if got.Spec.RollbackTo != nil || got.Annotations[appsv1beta1.CloneSetRollbackToRevisionAnnotation] != "" {
+				t.Fatalf("expected rollbackTo cleared, got %v, %v", got.Spec.RollbackTo, got.Annotations)
+			}
+			condition := clonesetutils.GetCloneSetCondition(got.Status, appsv1beta1.CloneSetConditionTypeRollback)
+			if tc.expectedReason == "" {
+				if condition != nil {
+					t.Fatalf("expected no rollback condition, got %v", condition)
+				}
+				return
+			}
+			if condition == nil || condition.Reason != string(tc.expectedReason) || condition.Status != v1.ConditionFalse {
+				t.Fatalf("expected rollback condition %s, got %v", tc.expectedReason, condition)
+			}
+		})
+	}
+}
+
+func TestCalculateRollbackStatus(t *testing.T) {
+	inProgress := clonesetutils.NewCloneSetCondition(appsv1beta1.CloneSetConditionTypeRollback,
+		v1.ConditionFalse, appsv1beta1.CloneSetRollbackInProgress, "", timer.Now())
+
+	status := &appsv1beta1.CloneSetStatus{
+		UpdateRevision:          "rev-1",
+		ExpectedUpdatedReplicas: 3,
+		UpdatedReadyReplicas:    2,
+		Conditions:              []appsv1beta1.CloneSetCondition{*inProgress},
+	}
+	calculateRollbackStatus(status)
+	if condition := clonesetutils.GetCloneSetCondition(*status, appsv1beta1.CloneSetConditionTypeRollback); condition.Status != v1.ConditionFalse {
+		t.Fatalf("expected rollback in progress, got %v", condition)
+	}
+
+	status.UpdatedReadyReplicas = 3
+	calculateRollbackStatus(status)
+	condition := clonesetutils.GetCloneSetCondition(*status, appsv1beta1.CloneSetConditionTypeRollback)
+	if condition.Status != v1.ConditionTrue || condition.Reason != string(appsv1beta1.CloneSetRollbackCompleted) {
+		t.Fatalf("expected rollback completed, got %v", condition)
+	}
+}
@@ -19,10 +19,13 @@ package cloneset
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
@@ -89,13 +92,27 @@ func (r *realStatusUpdater) inconsistentStatus(cs *appsv1beta1.CloneSet, newStat
 		newStatus.UpdateRevision != oldStatus.UpdateRevision ||
 		newStatus.CurrentRevision != oldStatus.CurrentRevision ||
 		newStatus.LabelSelector != oldStatus.LabelSelector ||
-		hasProgressingConditionChanged(cs.Status, *newStatus)
+		newStatus.StandbyReplicas != oldStatus.StandbyReplicas ||
+		!apiequality.Semantic.DeepEqual(newStatus.UpdateHeldPods, oldStatus.UpdateHeldPods) ||
+		!apiequality.Semantic.DeepEqual(newStatus.VolumeClaims, oldStatus.VolumeClaims) ||
+		!apiequality.Semantic.DeepEqual(newStatus.CanaryStatus, oldStatus.CanaryStatus) ||
+		hasProgressingConditionChanged(cs.Status, *newStatus) ||
+		hasRollbackConditionChanged(cs.Status, *newStatus) ||
+		hasProgressDeadlineExceededConditionChanged(cs.Status, *newStatus)
 }
 
 func (r *realStatusUpdater) calculateStatus(cs *appsv1beta1.CloneSet, newStatus *appsv1beta1.CloneSetStatus, pods []*v1.Pod) {
 	coreControl := clonesetcore.New(cs)
 	for _, pod := range pods {
+		// standby pods are not serving, so they are only counted in standbyReplicas
+		if clonesetutils.IsStandbyPod(pod) {
+			newStatus.StandbyReplicas++
+			continue
+		}
 		newStatus.Replicas++
+		if hold, held := clonesetutils.GetPodUpdateHold(pod, timer.Now()); held {
+			newStatus.UpdateHeldPods = append(newStatus.UpdateHeldPods, appsv1beta1.CloneSetUpdateHeldPod{Name: pod.Name, CloneSetPodUpdateHold: *hold})
+		}
 		if coreControl.IsPodUpdateReady(pod, 0) {
 			newStatus.ReadyReplicas++
 		}
@@ -112,6 +129,9 @@ func (r *realStatusUpdater) calculateStatus(cs *appsv1beta1.CloneSet, newStatus
 			newStatus.UpdatedAvailableReplicas++
 		}
 	}
+	sort.Slice(newStatus.UpdateHeldPods, func(i, j int) bool {
+		return newStatus.UpdateHeldPods[i].Name < newStatus.UpdateHeldPods[j].Name
+	})
 	// Consider the update revision as stable if revisions of all pods are consistent to it and have the expected number of replicas, no need to wait all of them ready
 	if newStatus.UpdatedReplicas == newStatus.Replicas && newStatus.Replicas == *cs.Spec.Replicas {
 		newStatus.CurrentRevision = newStatus.UpdateRevision
@@ -124,8 +144,44 @@ func (r *realStatusUpdater) calculateStatus(cs *appsv1beta1.CloneSet, newStatus
 	} else {
 		newStatus.ExpectedUpdatedReplicas = *cs.Spec.Replicas
 	}
+	calculateRollbackStatus(newStatus)
 	duration := r.calculateProgressingStatus(cs, newStatus)
 	clonesetutils.DurationStore.Push(clonesetutils.GetControllerKey(cs), duration)
+	calculateProgressDeadlineExceededStatus(cs, newStatus)
+}
+
+// calculateProgressDeadlineExceededStatus keeps the ProgressDeadlineExceeded condition as long as the update
+// is stopped by progressDeadlineExceededPolicy, because the Progressing condition turns to CloneSetPaused or
+// ProgressPartitionAvailable once the policy has been applied.
+func calculateProgressDeadlineExceededStatus(cs *appsv1beta1.CloneSet, newStatus *appsv1beta1.CloneSetStatus) {
+	policy := cs.Spec.ProgressDeadlineExceededPolicy
+	progressing := clonesetutils.GetCloneSetCondition(*newStatus, appsv1beta1.CloneSetConditionTypeProgressing)
+	if policy == "" || policy == appsv1beta1.NoneProgressDeadlineExceededPolicyType || progressing == nil ||
+		newStatus.UpdateRevision != cs.Status.UpdateRevision {
+		clonesetutils.RemoveCloneSetCondition(newStatus, appsv1beta1.CloneSetConditionTypeProgressDeadlineExceeded)
+		return
+	}
+
+	if progressing.Reason == string(appsv1beta1.CloneSetProgressDeadlineExceeded) {
+		condition := clonesetutils.NewCloneSetCondition(appsv1beta1.CloneSetConditionTypeProgressDeadlineExceeded,
+			v1.ConditionTrue, appsv1beta1.CloneSetConditionReason(policy), progressing.Message, progressing.LastUpdateTime.Time)
+		clonesetutils.SetCloneSetCondition(newStatus, *condition)
+		return
+	}
+
+	// remove it once the update is resumed by users
+	var stopped bool
+	if rollingUpdate := cs.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		switch policy {
+		case appsv1beta1.PauseProgressDeadlineExceededPolicyType:
+			stopped = rollingUpdate.Paused
+		case appsv1beta1.RollbackPartitionProgressDeadlineExceededPolicyType:
+			stopped = rollingUpdate.Partition != nil && *rollingUpdate.Partition == intstr.FromString("100%")
+		}
+	}
+	if !stopped {
+		clonesetutils.RemoveCloneSetCondition(newStatus, appsv1beta1.CloneSetConditionTypeProgressDeadlineExceeded)
+	}
 }
 
 func (r *realStatusUpdater) calculateProgressingStatus(cs *appsv1beta1.CloneSet, newStatus *appsv1beta1.CloneSetStatus) time.Duration {
@@ -159,7 +215,8 @@ func (r *realStatusUpdater) calculateProgressingStatus(cs *appsv1beta1.CloneSet,
 
 	case clonesetutils.CloneSetDeadlineExceeded(cs, newStatus, timeNow):
 		klog.V(5).InfoS("CloneSet is timed out progressing", "cloneSet", klog.KObj(cs))
-		msg := fmt.Sprintf("CloneSet revision %s has timed out progressing", newStatus.UpdateRevision)
+		msg := fmt.Sprintf("CloneSet revision %s has timed out progressing, %d updated pods are not ready",
+			newStatus.UpdateRevision, newStatus.UpdatedReplicas-newStatus.UpdatedReadyReplicas)
 		condition := clonesetutils.NewCloneSetCondition(appsv1beta1.CloneSetConditionTypeProgressing,
 			v1.ConditionFalse, appsv1beta1.CloneSetProgressDeadlineExceeded, msg, timeNow)
 		clonesetutils.SetCloneSetCondition(newStatus, *condition)
@@ -187,6 +244,18 @@ func hasProgressingConditionChanged(oldStatus appsv1beta1.CloneSetStatus, newSta
 	return oldCond.Status != newCond.Status || oldCond.Reason != newCond.Reason
 }
 
+func hasRollbackConditionChanged(oldStatus appsv1beta1.CloneSetStatus, newStatus appsv1beta1.CloneSetStatus) bool {
+	oldCond := clonesetutils.GetCloneSetCondition(oldStatus, appsv1beta1.CloneSetConditionTypeRollback)
+	newCond := clonesetutils.GetCloneSetCondition(newStatus, appsv1beta1.CloneSetConditionTypeRollback)
+	return !apiequality.Semantic.DeepEqual(oldCond, newCond)
+}
+
+func hasProgressDeadlineExceededConditionChanged(oldStatus appsv1beta1.CloneSetStatus, newStatus appsv1beta1.CloneSetStatus) bool {
+	oldCond := clonesetutils.GetCloneSetCondition(oldStatus, appsv1beta1.CloneSetConditionTypeProgressDeadlineExceeded)
+	newCond := clonesetutils.GetCloneSetCondition(newStatus, appsv1beta1.CloneSetConditionTypeProgressDeadlineExceeded)
+	return !apiequality.Semantic.DeepEqual(oldCond, newCond)
+}
+
 func getRequeueSecondsFromCondition(condition *appsv1beta1.CloneSetCondition, progressDeadlineSeconds int32, now time.Time) time.Duration {
 	if condition == nil {
 		return -1
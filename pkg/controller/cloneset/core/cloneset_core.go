@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/appscode/jsonpatch"
 	v1 "k8s.io/api/core/v1"
@@ -119,7 +120,8 @@ func (c *commonControl) GetPodSpreadConstraint() []clonesetutils.PodSpreadConstr
 }
 
 func (c *commonControl) IsPodUpdatePaused(pod *v1.Pod) bool {
-	return false
+	_, held := clonesetutils.GetPodUpdateHold(pod, time.Now())
+	return held
 }
 
 func (c *commonControl) IsPodUpdateReady(pod *v1.Pod, minReadySeconds int32) bool {
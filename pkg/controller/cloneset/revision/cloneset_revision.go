@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 
 	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -40,6 +41,7 @@ var (
 type Interface interface {
 	NewRevision(cs *appsv1beta1.CloneSet, revision int64, collisionCount *int32) (*apps.ControllerRevision, error)
 	ApplyRevision(cs *appsv1beta1.CloneSet, revision *apps.ControllerRevision) (*appsv1beta1.CloneSet, error)
+	GetRollbackRevision(cs *appsv1beta1.CloneSet, revisions []*apps.ControllerRevision, revision int64) (*apps.ControllerRevision, error)
 }
 
 // NewRevisionControl create a normal revision control.
@@ -71,10 +73,25 @@ func (c *realControl) NewRevision(cs *appsv1beta1.CloneSet, revision int64, coll
 	for key, value := range cs.Annotations {
 		cr.ObjectMeta.Annotations[key] = value
 	}
-	volumeclaimtemplate.PatchVCTemplateHash(cr, cs.Spec.VolumeClaimTemplates)
+	volumeclaimtemplate.PatchVCTemplateHash(cr, getVolumeClaimTemplatesToHash(cs))
 	return cr, nil
 }
 
+// getVolumeClaimTemplatesToHash returns the volumeClaimTemplates to calculate the hash annotation of revision.
+// The storage requests are excluded for InPlaceExpand, so that the PVCs expanded in place do not make pods
+// recreated when RecreatePodWhenChangeVCTInCloneSetGate is enabled.
+func getVolumeClaimTemplatesToHash(cs *appsv1beta1.CloneSet) []v1.PersistentVolumeClaim {
+	if cs.Spec.VolumeClaimUpdateStrategy.Type != appsv1beta1.InPlaceExpandCloneSetVolumeClaimUpdateStrategyType {
+		return cs.Spec.VolumeClaimTemplates
+	}
+	templates := make([]v1.PersistentVolumeClaim, len(cs.Spec.VolumeClaimTemplates))
+	for i := range cs.Spec.VolumeClaimTemplates {
+		cs.Spec.VolumeClaimTemplates[i].DeepCopyInto(&templates[i])
+		delete(templates[i].Spec.Resources.Requests, v1.ResourceStorage)
+	}
+	return templates
+}
+
 // getPatch returns a strategic merge patch that can be applied to restore a CloneSet to a
 // previous version. If the returned error is nil the patch is valid. The current state that we save is just the
 // PodSpecTemplate. We can modify this later to encompass more state (or less) and remain compatible with previously
@@ -110,3 +127,30 @@ func (c *realControl) ApplyRevision(cs *appsv1beta1.CloneSet, revision *apps.Con
 	coreControl := clonesetcore.New(clone)
 	return coreControl.ApplyRevisionPatch(patched)
 }
+
+// GetRollbackRevision returns the ControllerRevision with the given revision number in history, or nil if not found.
+// If revision is 0, it returns the latest revision that is different from the current template.
+func (c *realControl) GetRollbackRevision(cs *appsv1beta1.CloneSet, revisions []*apps.ControllerRevision, revision int64) (*apps.ControllerRevision, error) {
+	if revision > 0 {
+		for _, cr := range revisions {
+			if cr.Revision == revision {
+				return cr, nil
+			}
+		}
+		return nil, nil
+	}
+
+	current, err := c.NewRevision(cs, 0, cs.Status.CollisionCount)
+	if err != nil {
+		return nil, err
+	}
+	sorted := make([]*apps.ControllerRevision, len(revisions))
+	copy(sorted, revisions)
+	history.SortControllerRevisions(sorted)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if !history.EqualRevision(sorted[i], current) {
+			return sorted[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"testing"
 
+	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,7 +31,12 @@ import (
 	"k8s.io/kubernetes/pkg/controller/history"
 
 	"github.com/openkruise/kruise/apis"
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesetcore "github.com/openkruise/kruise/pkg/controller/cloneset/core"
 	clonesettest "github.com/openkruise/kruise/pkg/controller/cloneset/test"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/inplaceupdate"
 )
 
 func TestMain(m *testing.M) {
@@ -169,3 +175,122 @@ func TestCreateApplyRevisionWithVCTemplates(t *testing.T) {
 		t.Errorf("for annotation %s wanted %s got %s", key, expectedValue, value)
 	}
 }
+
+func TestNewRevisionWithInPlaceExpand(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.RecreatePodWhenChangeVCTInCloneSetGate, true)()
+
+	control := NewRevisionControl()
+	newSet := func(image, storage string, strategy appsv1beta1.CloneSetVolumeClaimUpdateStrategyType) *appsv1beta1.CloneSet {
+		set := clonesettest.NewCloneSet(1)
+		set.Status.CollisionCount = new(int32)
+		set.Spec.Template.Spec.Containers[0].Image = image
+		set.Spec.VolumeClaimUpdateStrategy.Type = strategy
+		set.Spec.VolumeClaimTemplates = []v1.PersistentVolumeClaim{{
+			ObjectMeta: metav1.ObjectMeta{Name: "www-data"},
+			Spec: v1.PersistentVolumeClaimSpec{
+				Resources: v1.VolumeResourceRequirements{
+					Requests: map[v1.ResourceName]resource.Quantity{v1.ResourceStorage: resource.MustParse(storage)},
+				},
+				AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			},
+		}}
+		return set
+	}
+
+	cases := []struct {
+		name            string
+		strategy        appsv1beta1.CloneSetVolumeClaimUpdateStrategyType
+		newAccessMode   v1.PersistentVolumeAccessMode
+		expectedInPlace bool
+	}{
+		{
+			name:            "OnDelete recreates pods when storage changed",
+			strategy:        appsv1beta1.OnDeleteCloneSetVolumeClaimUpdateStrategyType,
+			expectedInPlace: false,
+		},
+		{
+			name:            "InPlaceExpand updates pods in place when only storage changed",
+			strategy:        appsv1beta1.InPlaceExpandCloneSetVolumeClaimUpdateStrategyType,
+			expectedInPlace: true,
+		},
+		{
+			name:            "InPlaceExpand recreates pods when other fields changed",
+			strategy:        appsv1beta1.InPlaceExpandCloneSetVolumeClaimUpdateStrategyType,
+			newAccessMode:   v1.ReadWriteMany,
+			expectedInPlace: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldSet := newSet("nginx:1.0", "1Gi", tc.strategy)
+			oldRevision, err := control.NewRevision(oldSet, 1, oldSet.Status.CollisionCount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			updatedSet := newSet("nginx:2.0", "2Gi", tc.strategy)
+			if tc.newAccessMode != "" {
+				updatedSet.Spec.VolumeClaimTemplates[0].Spec.AccessModes = []v1.PersistentVolumeAccessMode{tc.newAccessMode}
+			}
+			updatedRevision, err := control.NewRevision(updatedSet, 2, updatedSet.Status.CollisionCount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updatedSet.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String() != "2Gi" {
+				t.Fatalf("expected volumeClaimTemplates of CloneSet unchanged")
+			}
+
+			coreControl := clonesetcore.New(updatedSet)
+			opts := inplaceupdate.SetOptionsDefaults(coreControl.GetUpdateOptions())
+			if inPlace := opts.CalculateSpec(oldRevision, updatedRevision, opts) != nil; inPlace != tc.expectedInPlace {
+				t.Fatalf("expected in-place update %v, got %v", tc.expectedInPlace, inPlace)
+			}
+		})
+	}
+}
+
+func TestGetRollbackRevision(t *testing.T) {
+	control := NewRevisionControl()
+	set := clonesettest.NewCloneSet(1)
+	set.Status.CollisionCount = new(int32)
+
+	var revisions []*apps.ControllerRevision
+	for i, image := range []string{"nginx:1", "nginx:2", "nginx:3"} {
+		set.Spec.Template.Spec.Containers[0].Image = image
+		revision, err := control.NewRevision(set, int64(i+1), set.Status.CollisionCount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	cases := []struct {
+		name             string
+		image            string
+		revision         int64
+		expectedRevision int64
+	}{
+		{name: "specified revision", image: "nginx:3", revision: 1, expectedRevision: 1},
+		{name: "revision not found", image: "nginx:3", revision: 5, expectedRevision: 0},
+		{name: "last revision", image: "nginx:3", revision: 0, expectedRevision: 2},
+		{name: "last revision different from current template", image: "nginx:2", revision: 0, expectedRevision: 3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := set.DeepCopy()
+			cs.Spec.Template.Spec.Containers[0].Image = tc.image
+			got, err := control.GetRollbackRevision(cs, revisions, tc.revision)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotRevision int64
+			if got != nil {
+				gotRevision = got.Revision
+			}
+			if gotRevision != tc.expectedRevision {
+				t.Fatalf("expected revision %d, got %d", tc.expectedRevision, gotRevision)
+			}
+		})
+	}
+}
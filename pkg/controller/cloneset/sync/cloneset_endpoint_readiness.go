@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesetcore "github.com/openkruise/kruise/pkg/controller/cloneset/core"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+// endpointReadinessControl counts a pod as ready for update only if it is also ready in the EndpointSlices of Service.
+type endpointReadinessControl struct {
+	clonesetcore.Control
+	readyPods sets.String
+}
+
+func (c *endpointReadinessControl) IsPodUpdateReady(pod *v1.Pod, minReadySeconds int32) bool {
+	return c.readyPods.Has(pod.Name) && c.Control.IsPodUpdateReady(pod, minReadySeconds)
+}
+
+// withEndpointReadinessGate wraps the core control if spec.updateStrategy.rollingUpdate.endpointReadinessGate is set
+// and CloneSetEndpointReadinessGate is enabled.
+func (c *realControl) withEndpointReadinessGate(cs *appsv1beta1.CloneSet, coreControl clonesetcore.Control) (clonesetcore.Control, error) {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.CloneSetEndpointReadinessGate) ||
+		cs.Spec.UpdateStrategy.RollingUpdate == nil || cs.Spec.UpdateStrategy.RollingUpdate.EndpointReadinessGate == nil {
+		return coreControl, nil
+	}
+	readyPods, err := c.getEndpointReadyPods(cs.Namespace, cs.Spec.UpdateStrategy.RollingUpdate.EndpointReadinessGate.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	return &endpointReadinessControl{Control: coreControl, readyPods: readyPods}, nil
+}
+
+// getEndpointReadyPods returns the names of pods that are ready in the EndpointSlices of the Service.
+func (c *realControl) getEndpointReadyPods(namespace, serviceName string) (sets.String, error) {
+	sliceList := &discoveryv1.EndpointSliceList{}
+	if err := c.List(context.TODO(), sliceList, client.InNamespace(namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: serviceName}); err != nil {
+		return nil, err
+	}
+
+	readyPods := sets.NewString()
+	for i := range sliceList.Items {
+		for _, endpoint := range sliceList.Items[i].Endpoints {
+			if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" {
+				continue
+			}
+			// nil should be interpreted as ready
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				readyPods.Insert(endpoint.TargetRef.Name)
+			}
+		}
+	}
+	return readyPods, nil
+}
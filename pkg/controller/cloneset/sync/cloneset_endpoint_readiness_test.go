@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilpointer "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesetcore "github.com/openkruise/kruise/pkg/controller/cloneset/core"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+func TestLimitUpdateIndexesWithEndpointReadinessGate(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.CloneSetEndpointReadinessGate, true)()
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1.AddToScheme(scheme))
+	utilruntime.Must(discoveryv1.AddToScheme(scheme))
+
+	newSlice := func(name, service string, ready map[string]*bool) *discoveryv1.EndpointSlice {
+		slice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+		}
+		for podName, r := range ready {
+			slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+				Addresses:  []string{"10.0.0.1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: r},
+				TargetRef:  &v1.ObjectReference{Kind: "Pod", Namespace: metav1.NamespaceDefault, Name: podName},
+			})
+		}
+		return slice
+	}
+
+	cases := []struct {
+		name           string
+		slices         []*discoveryv1.EndpointSlice
+		expectedResult int
+	}{
+		{
+			name: "all pods ready in endpoints",
+			slices: []*discoveryv1.EndpointSlice{
+				newSlice("svc-a", "svc", map[string]*bool{"p0": utilpointer.Bool(true), "p1": nil}),
+				newSlice("svc-b", "svc", map[string]*bool{"p2": utilpointer.Bool(true)}),
+			},
+			expectedResult: 1,
+		},
+		{
+			name: "pod not ready in endpoints",
+			slices: []*discoveryv1.EndpointSlice{
+				newSlice("svc-a", "svc", map[string]*bool{"p0": utilpointer.Bool(true), "p1": nil, "p2": utilpointer.Bool(false)}),
+			},
+			expectedResult: 0,
+		},
+		{
+			name: "pod missing from endpoints of the service",
+			slices: []*discoveryv1.EndpointSlice{
+				newSlice("svc-a", "svc", map[string]*bool{"p0": utilpointer.Bool(true), "p1": nil}),
+				newSlice("other-a", "other", map[string]*bool{"p2": utilpointer.Bool(true)}),
+			},
+			expectedResult: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			replicas := int32(3)
+			cs := &appsv1beta1.CloneSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "sample"},
+				Spec: appsv1beta1.CloneSetSpec{
+					Replicas: &replicas,
+					UpdateStrategy: appsv1beta1.CloneSetUpdateStrategy{
+						Type: appsv1beta1.RollingUpdateCloneSetUpdateStrategyType,
+						RollingUpdate: &appsv1beta1.RollingUpdateCloneSetStrategy{
+							EndpointReadinessGate: &appsv1beta1.CloneSetEndpointReadinessGate{ServiceName: "svc"},
+						},
+					},
+				},
+			}
+			var pods []*v1.Pod
+			for _, name := range []string{"p0", "p1", "p2"} {
+				pods = append(pods, &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{apps.ControllerRevisionHashLabelKey: "rev1"}},
+					Status:     v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}},
+				})
+			}
+
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, slice := range tc.slices {
+				builder.WithObjects(slice)
+			}
+			ctrl := &realControl{Client: builder.Build()}
+			coreControl, err := ctrl.withEndpointReadinessGate(cs, clonesetcore.New(cs))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diffRes := calculateDiffsWithExpectation(cs, pods, "rev1", "rev2", nil)
+			res := limitUpdateIndexes(coreControl, 0, diffRes, []int{0, 1, 2}, pods, "rev2")
+			if len(res) != tc.expectedResult {
+				t.Fatalf("expected %d, got %d (res=%v)", tc.expectedResult, len(res), res)
+			}
+		})
+	}
+}
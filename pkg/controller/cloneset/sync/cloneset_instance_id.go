@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
+)
+
+// getAvailableIDs returns num instance-ids for new pods according to spec.scaleStrategy.instanceIDPolicy.
+func (r *realControl) getAvailableIDs(cs *appsv1beta1.CloneSet, num int, pods []*v1.Pod, pvcs []*v1.PersistentVolumeClaim) (sets.String, error) {
+	if cs.Spec.ScaleStrategy.InstanceIDPolicy != appsv1beta1.OrdinalInstanceIDPolicyType {
+		return getOrGenAvailableIDs(num, pods, pvcs), nil
+	}
+	reservedIDs, err := r.getReservedInstanceIDs(cs)
+	if err != nil {
+		return nil, err
+	}
+	return genAvailableOrdinalIDs(num, pods, pvcs, reservedIDs), nil
+}
+
+// getReservedInstanceIDs returns the instance-ids that can not be reused by new pods for now, including the ids of
+// pods and PVCs that are terminating, and the ids of pods in scaleStrategy.podsToDelete which have not been truncated.
+// Otherwise the new pods or PVCs may conflict with the old ones in names, or be deleted by mistake.
+func (r *realControl) getReservedInstanceIDs(cs *appsv1beta1.CloneSet) (sets.String, error) {
+	reservedIDs := sets.NewString()
+	prefix := cs.Name + "-"
+	for _, name := range cs.Spec.ScaleStrategy.PodsToDelete {
+		if strings.HasPrefix(name, prefix) {
+			reservedIDs.Insert(strings.TrimPrefix(name, prefix))
+		}
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(cs.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	opts := []client.ListOption{client.InNamespace(cs.Namespace), client.MatchingLabelsSelector{Selector: selector}}
+
+	podList := &v1.PodList{}
+	if err := r.List(context.TODO(), podList, opts...); err != nil {
+		return nil, err
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if isControlledBy(pod, cs) && !kubecontroller.IsPodActive(pod) {
+			reservedIDs.Insert(clonesetutils.GetInstanceID(pod))
+		}
+	}
+
+	pvcList := &v1.PersistentVolumeClaimList{}
+	if err := r.List(context.TODO(), pvcList, opts...); err != nil {
+		return nil, err
+	}
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if isControlledBy(pvc, cs) && pvc.DeletionTimestamp != nil {
+			reservedIDs.Insert(clonesetutils.GetInstanceID(pvc))
+		}
+	}
+	return reservedIDs, nil
+}
+
+func isControlledBy(obj metav1.Object, cs *appsv1beta1.CloneSet) bool {
+	ref := metav1.GetControllerOf(obj)
+	return ref != nil && ref.UID == cs.UID
+}
+
+// genAvailableOrdinalIDs allocates the instance-ids of free PVCs first, which are left by the previous pods if
+// scaleStrategy.enablePVCReuse is true, like getOrGenAvailableIDs, so that the new pods reuse the existing data.
+// Then it allocates the smallest non-negative integers that are neither held by the pods or PVCs nor reserved.
+func genAvailableOrdinalIDs(num int, pods []*v1.Pod, pvcs []*v1.PersistentVolumeClaim, reservedIDs sets.String) sets.String {
+	existingIDs := sets.NewString(reservedIDs.UnsortedList()...)
+	for _, pod := range pods {
+		if id := clonesetutils.GetInstanceID(pod); len(id) > 0 {
+			existingIDs.Insert(id)
+		}
+	}
+
+	var freeIDs []string
+	for _, pvc := range pvcs {
+		if id := clonesetutils.GetInstanceID(pvc); len(id) > 0 && !existingIDs.Has(id) {
+			existingIDs.Insert(id)
+			freeIDs = append(freeIDs, id)
+		}
+	}
+	sort.Slice(freeIDs, func(i, j int) bool {
+		return lessOrdinalID(freeIDs[i], freeIDs[j])
+	})
+
+	retIDs := sets.NewString()
+	for _, id := range freeIDs {
+		if retIDs.Len() >= num {
+			break
+		}
+		retIDs.Insert(id)
+	}
+	for ordinal := 0; retIDs.Len() < num; ordinal++ {
+		if id := strconv.Itoa(ordinal); !existingIDs.Has(id) {
+			retIDs.Insert(id)
+		}
+	}
+	return retIDs
+}
+
+// lessOrdinalID sorts the ordinal ids by their numbers, and the others by names after them.
+func lessOrdinalID(a, b string) bool {
+	ordinalA, errA := strconv.Atoi(a)
+	ordinalB, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return ordinalA < ordinalB
+	case errA == nil || errB == nil:
+		return errA == nil
+	default:
+		return a < b
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesettest "github.com/openkruise/kruise/pkg/controller/cloneset/test"
+	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
+)
+
+func TestGetAvailableOrdinalIDs(t *testing.T) {
+	cs := clonesettest.NewCloneSet(5)
+	cs.Spec.ScaleStrategy.InstanceIDPolicy = appsv1beta1.OrdinalInstanceIDPolicyType
+	cs.Spec.ScaleStrategy.PodsToDelete = []string{"foo-4"}
+	ownerRef := metav1.NewControllerRef(cs, clonesetutils.ControllerKind)
+	objectMeta := func(name, id string, terminating bool) metav1.ObjectMeta {
+		meta := metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       cs.Namespace,
+			Labels:          map[string]string{"foo": "bar", appsv1beta1.CloneSetInstanceID: id},
+			OwnerReferences: []metav1.OwnerReference{*ownerRef},
+		}
+		if terminating {
+			now := metav1.Now()
+			meta.DeletionTimestamp = &now
+			meta.Finalizers = []string{"test"}
+		}
+		return meta
+	}
+
+	pods := []*v1.Pod{
+		{ObjectMeta: objectMeta("foo-0", "0", false)},
+		{ObjectMeta: objectMeta("foo-2", "2", false)},
+	}
+	objects := []client.Object{
+		pods[0], pods[1],
+		&v1.Pod{ObjectMeta: objectMeta("foo-1", "1", true)},
+		&v1.PersistentVolumeClaim{ObjectMeta: objectMeta("datadir-foo-5", "5", false)},
+		&v1.PersistentVolumeClaim{ObjectMeta: objectMeta("datadir-foo-6", "6", true)},
+	}
+	fClient := fake.NewClientBuilder().WithScheme(kscheme).WithObjects(objects...).Build()
+	ctrl := &realControl{Client: fClient, recorder: record.NewFakeRecorder(10)}
+
+	gotIDs, err := ctrl.getAvailableIDs(cs, 3, pods, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1 and 6 are terminating, 4 is still in podsToDelete, and the PVC of 5 will be reused
+	if expected := []string{"3", "5", "7"}; !reflect.DeepEqual(gotIDs.List(), expected) {
+		t.Fatalf("expected ids %v, got %v", expected, gotIDs.List())
+	}
+
+	cs.Spec.ScaleStrategy.InstanceIDPolicy = appsv1beta1.RandomInstanceIDPolicyType
+	if gotIDs, err = ctrl.getAvailableIDs(cs, 3, pods, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range gotIDs.List() {
+		if len(id) != LengthOfInstanceID {
+			t.Fatalf("expected random id, got %s", id)
+		}
+	}
+}
+
+func TestGenAvailableOrdinalIDs(t *testing.T) {
+	newPod := func(id string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{appsv1beta1.CloneSetInstanceID: id}}}
+	}
+	newPVC := func(id string) *v1.PersistentVolumeClaim {
+		return &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{appsv1beta1.CloneSetInstanceID: id}}}
+	}
+
+	cases := []struct {
+		name        string
+		num         int
+		pods        []*v1.Pod
+		pvcs        []*v1.PersistentVolumeClaim
+		reservedIDs []string
+		expectedIDs []string
+	}{
+		{
+			name:        "lowest gaps without free pvcs",
+			num:         2,
+			pods:        []*v1.Pod{newPod("0"), newPod("2")},
+			expectedIDs: []string{"1", "3"},
+		},
+		{
+			name:        "free pvc id is not the lowest gap",
+			num:         2,
+			pods:        []*v1.Pod{newPod("0"), newPod("1"), newPod("2")},
+			pvcs:        []*v1.PersistentVolumeClaim{newPVC("0"), newPVC("7")},
+			expectedIDs: []string{"3", "7"},
+		},
+		{
+			name:        "free pvc ids are preferred in ordinal order",
+			num:         2,
+			pods:        []*v1.Pod{newPod("0")},
+			pvcs:        []*v1.PersistentVolumeClaim{newPVC("10"), newPVC("9"), newPVC("abc"), newPVC("8")},
+			reservedIDs: []string{"8"},
+			expectedIDs: []string{"10", "9"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotIDs := genAvailableOrdinalIDs(tc.num, tc.pods, tc.pvcs, sets.NewString(tc.reservedIDs...))
+			if !reflect.DeepEqual(gotIDs.List(), tc.expectedIDs) {
+				t.Fatalf("expected ids %v, got %v", tc.expectedIDs, gotIDs.List())
+			}
+		})
+	}
+}
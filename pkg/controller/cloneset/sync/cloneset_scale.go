@@ -61,6 +61,13 @@ func (r *realControl) Scale(
 		return false, nil
 	}
 
+	// standby pods are not counted in replicas, but their instance-ids are still in use
+	allPods := pods
+	pods, standbyPods := clonesetutils.GroupStandbyPods(allPods)
+	if modified, err := r.syncPromotedPods(updateCS, pods); err != nil || modified {
+		return modified, err
+	}
+
 	// 1. manage pods to delete and in preDelete
 	podsSpecifiedToDelete, podsInPreDelete, numToDelete := getPlannedDeletedPods(updateCS, pods)
 	if modified, err := r.managePreparingDelete(updateCS, pods, podsInPreDelete, numToDelete); err != nil || modified {
@@ -77,6 +84,11 @@ func (r *realControl) Scale(
 
 	// 3. scale out
 	if diffRes.scaleUpNum > 0 {
+		// promote the ready standby pods to serve first, they are all in update revision
+		if promoted, err := r.promoteStandbyPods(updateCS, standbyPods, updateRevision, diffRes.scaleUpNum-diffRes.scaleUpNumOldRevision); err != nil || promoted {
+			return promoted, err
+		}
+
 		// total number of this creation
 		expectedCreations := diffRes.scaleUpLimit
 		// lack number of current version
@@ -86,7 +98,10 @@ func (r *realControl) Scale(
 			"cloneSet", klog.KObj(updateCS), "expectedCreations", expectedCreations, "expectedCurrentCreations", expectedCurrentCreations)
 
 		// available instance-id come from free pvc
-		availableIDs := getOrGenAvailableIDs(expectedCreations, pods, pvcs)
+		availableIDs, err := r.getAvailableIDs(updateCS, expectedCreations, allPods, pvcs)
+		if err != nil {
+			return false, err
+		}
 		// existing pvc names
 		existingPVCNames := sets.NewString()
 		for _, pvc := range pvcs {
@@ -137,7 +152,7 @@ func (r *realControl) Scale(
 		klog.V(3).InfoS("CloneSet began to scale in", "cloneSet", klog.KObj(updateCS), "scaleDownNum", diffRes.scaleDownNum,
 			"oldRevision", diffRes.scaleDownNumOldRevision, "deleteReadyLimit", diffRes.deleteReadyLimit)
 
-		podsPreparingToDelete := r.choosePodsToDelete(updateCS, diffRes.scaleDownNum, diffRes.scaleDownNumOldRevision, notUpdatedPods, updatedPods)
+		podsPreparingToDelete, chosenReasons := r.choosePodsToDelete(updateCS, diffRes.scaleDownNum, diffRes.scaleDownNumOldRevision, notUpdatedPods, updatedPods)
 		podsToDelete := make([]*v1.Pod, 0, len(podsPreparingToDelete))
 		for _, pod := range podsPreparingToDelete {
 			if !isPodReady(coreControl, pod) {
@@ -148,10 +163,16 @@ func (r *realControl) Scale(
 			}
 		}
 
+		for _, pod := range podsToDelete {
+			if reason, ok := chosenReasons[pod.Name]; ok {
+				r.recorder.Eventf(updateCS, v1.EventTypeNormal, "ChosenToScaleDown", "Pod %s is chosen to scale down: %s", pod.Name, reason)
+			}
+		}
 		return r.deletePods(updateCS, podsToDelete, pvcs)
 	}
 
-	return false, nil
+	// 7. keep the standby pool in the desired size
+	return r.manageStandbyPods(updateCS, updateRevision, allPods, standbyPods, pvcs)
 }
 
 func (r *realControl) managePreparingDelete(cs *appsv1beta1.CloneSet, pods, podsInPreDelete []*v1.Pod, numToDelete int) (bool, error) {
@@ -199,7 +220,14 @@ func (r *realControl) createPods(
 	if err != nil {
 		return false, err
 	}
+	return r.createNewPods(currentCS, updateCS, currentRevision, newPods, existingPVCNames)
+}
 
+// createNewPods creates the new pods slowly in batches, and returns true if any pod has been created.
+func (r *realControl) createNewPods(
+	currentCS, updateCS *appsv1beta1.CloneSet, currentRevision string,
+	newPods []*v1.Pod, existingPVCNames sets.String,
+) (bool, error) {
 	podsCreationChan := make(chan *v1.Pod, len(newPods))
 	for _, p := range newPods {
 		clonesetutils.ScaleExpectations.ExpectScale(clonesetutils.GetControllerKey(updateCS), expectations.Create, p.Name)
@@ -208,7 +236,7 @@ func (r *realControl) createPods(
 
 	var created int64
 	successPodNames := sync.Map{}
-	_, err = clonesetutils.DoItSlowly(len(newPods), initialBatchSize, func() error {
+	_, err := clonesetutils.DoItSlowly(len(newPods), initialBatchSize, func() error {
 		pod := <-podsCreationChan
 
 		cs := updateCS
@@ -280,31 +308,40 @@ func (r *realControl) deletePods(cs *appsv1beta1.CloneSet, podsToDelete []*v1.Po
 			continue
 		}
 
-		clonesetutils.ScaleExpectations.ExpectScale(clonesetutils.GetControllerKey(cs), expectations.Delete, pod.Name)
-		if err := r.Delete(context.TODO(), pod); err != nil {
-			clonesetutils.ScaleExpectations.ObserveScale(clonesetutils.GetControllerKey(cs), expectations.Delete, pod.Name)
-			r.recorder.Eventf(cs, v1.EventTypeWarning, "FailedDelete", "failed to delete pod %s: %v", pod.Name, err)
-			return modified, err
+		if deleted, err := r.deletePodAndPVCs(cs, pod, pvcs); err != nil {
+			return modified || deleted, err
 		}
 		modified = true
-		r.recorder.Event(cs, v1.EventTypeNormal, "SuccessfulDelete", fmt.Sprintf("succeed to delete pod %s", pod.Name))
+	}
 
-		// delete pvcs which have the same instance-id
-		for _, pvc := range pvcs {
-			if pvc.Labels[appsv1beta1.CloneSetInstanceID] != pod.Labels[appsv1beta1.CloneSetInstanceID] {
-				continue
-			}
+	return modified, nil
+}
 
-			clonesetutils.ScaleExpectations.ExpectScale(clonesetutils.GetControllerKey(cs), expectations.Delete, pvc.Name)
-			if err := r.Delete(context.TODO(), pvc); err != nil {
-				clonesetutils.ScaleExpectations.ObserveScale(clonesetutils.GetControllerKey(cs), expectations.Delete, pvc.Name)
-				r.recorder.Eventf(cs, v1.EventTypeWarning, "FailedDelete", "failed to delete pvc %s: %v", pvc.Name, err)
-				return modified, err
-			}
-		}
+// deletePodAndPVCs deletes the pod and the pvcs which have the same instance-id without lifecycle hooks,
+// it returns true if the pod has been deleted.
+func (r *realControl) deletePodAndPVCs(cs *appsv1beta1.CloneSet, pod *v1.Pod, pvcs []*v1.PersistentVolumeClaim) (bool, error) {
+	clonesetutils.ScaleExpectations.ExpectScale(clonesetutils.GetControllerKey(cs), expectations.Delete, pod.Name)
+	if err := r.Delete(context.TODO(), pod); err != nil {
+		clonesetutils.ScaleExpectations.ObserveScale(clonesetutils.GetControllerKey(cs), expectations.Delete, pod.Name)
+		r.recorder.Eventf(cs, v1.EventTypeWarning, "FailedDelete", "failed to delete pod %s: %v", pod.Name, err)
+		return false, err
 	}
+	r.recorder.Event(cs, v1.EventTypeNormal, "SuccessfulDelete", fmt.Sprintf("succeed to delete pod %s", pod.Name))
 
-	return modified, nil
+	// delete pvcs which have the same instance-id
+	for _, pvc := range pvcs {
+		if pvc.Labels[appsv1beta1.CloneSetInstanceID] != pod.Labels[appsv1beta1.CloneSetInstanceID] {
+			continue
+		}
+
+		clonesetutils.ScaleExpectations.ExpectScale(clonesetutils.GetControllerKey(cs), expectations.Delete, pvc.Name)
+		if err := r.Delete(context.TODO(), pvc); err != nil {
+			clonesetutils.ScaleExpectations.ObserveScale(clonesetutils.GetControllerKey(cs), expectations.Delete, pvc.Name)
+			r.recorder.Eventf(cs, v1.EventTypeWarning, "FailedDelete", "failed to delete pvc %s: %v", pvc.Name, err)
+			return true, err
+		}
+	}
+	return true, nil
 }
 
 func getPlannedDeletedPods(cs *appsv1beta1.CloneSet, pods []*v1.Pod) ([]*v1.Pod, []*v1.Pod, int) {
@@ -367,9 +404,34 @@ func getOrGenInstanceID(existingIDs, availableIDs sets.String) string {
 	return id
 }
 
-func (r *realControl) choosePodsToDelete(cs *appsv1beta1.CloneSet, totalDiff int, currentRevDiff int, notUpdatedPods, updatedPods []*v1.Pod) []*v1.Pod {
+// choosePodsToDelete returns the pods to delete, and the reasons by pod name if they are chosen by TopologySpread policy.
+func (r *realControl) choosePodsToDelete(cs *appsv1beta1.CloneSet, totalDiff int, currentRevDiff int, notUpdatedPods, updatedPods []*v1.Pod) ([]*v1.Pod, map[string]string) {
 	coreControl := clonesetcore.New(cs)
+	availableFunc := func(pod *v1.Pod) bool {
+		return IsPodAvailable(coreControl, pod, cs.Spec.MinReadySeconds)
+	}
+
+	var spreadChooser *clonesetutils.TopologySpreadChooser
+	if topologyKeys := getScaleDownSpreadTopologyKeys(cs, coreControl); len(topologyKeys) > 0 {
+		allPods := make([]*v1.Pod, 0, len(notUpdatedPods)+len(updatedPods))
+		allPods = append(allPods, notUpdatedPods...)
+		allPods = append(allPods, updatedPods...)
+		spreadChooser = clonesetutils.NewTopologySpreadChooser(allPods, topologyKeys, r.Client, availableFunc)
+	}
+
+	var reasons map[string]string
 	choose := func(pods []*v1.Pod, diff int) []*v1.Pod {
+		if spreadChooser != nil {
+			chosen := spreadChooser.Choose(pods, diff)
+			if reasons == nil {
+				reasons = make(map[string]string, len(chosen))
+			}
+			for _, pod := range chosen {
+				reasons[pod.Name] = spreadChooser.Reason(pod)
+			}
+			return chosen
+		}
+
 		// No need to sort pods if we are about to delete all of them.
 		if diff < len(pods) {
 			var ranker clonesetutils.Ranker
@@ -379,11 +441,9 @@ func (r *realControl) choosePodsToDelete(cs *appsv1beta1.CloneSet, totalDiff int
 				ranker = clonesetutils.NewSameNodeRanker(pods)
 			}
 			sort.Sort(clonesetutils.ActivePodsWithRanks{
-				Pods:   pods,
-				Ranker: ranker,
-				AvailableFunc: func(pod *v1.Pod) bool {
-					return IsPodAvailable(coreControl, pod, cs.Spec.MinReadySeconds)
-				},
+				Pods:          pods,
+				Ranker:        ranker,
+				AvailableFunc: availableFunc,
 			})
 		} else if diff > len(pods) {
 			klog.InfoS("Diff > len(pods) in choosePodsToDelete func which is not expected")
@@ -402,5 +462,22 @@ func (r *realControl) choosePodsToDelete(cs *appsv1beta1.CloneSet, totalDiff int
 		podsToDelete = choose(updatedPods, totalDiff)
 	}
 
-	return podsToDelete
+	return podsToDelete, reasons
+}
+
+// getScaleDownSpreadTopologyKeys returns the topology keys to spread pods when scaling in,
+// empty if TopologySpread scale down policy is not used.
+func getScaleDownSpreadTopologyKeys(cs *appsv1beta1.CloneSet, coreControl clonesetcore.Control) []string {
+	policy := cs.Spec.ScaleStrategy.ScaleDownPolicy
+	if policy == nil || policy.Type != appsv1beta1.TopologySpreadScaleDownPolicyType {
+		return nil
+	}
+	if len(policy.TopologyKeys) > 0 {
+		return policy.TopologyKeys
+	}
+	var topologyKeys []string
+	for _, constraint := range coreControl.GetPodSpreadConstraint() {
+		topologyKeys = append(topologyKeys, constraint.TopologyKey)
+	}
+	return topologyKeys
 }
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesetcore "github.com/openkruise/kruise/pkg/controller/cloneset/core"
+	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+func getStandbyReplicas(cs *appsv1beta1.CloneSet) int {
+	if cs.Spec.ScaleStrategy.StandbyReplicas == nil {
+		return 0
+	}
+	return int(*cs.Spec.ScaleStrategy.StandbyReplicas)
+}
+
+// isStandbyPodPromotable returns true if the standby pod is in update revision and its containers are ready.
+func isStandbyPodPromotable(pod *v1.Pod, updateRevision string) bool {
+	if pod.DeletionTimestamp != nil || !clonesetutils.EqualToRevisionHash("", pod, updateRevision) {
+		return false
+	}
+	cond := util.GetCondition(pod, v1.ContainersReady)
+	return cond != nil && cond.Status == v1.ConditionTrue
+}
+
+// isStandbyPodPromoted returns true if the pod was created as standby and its readiness gate has been set.
+func isStandbyPodPromoted(pod *v1.Pod) bool {
+	for _, g := range pod.Spec.ReadinessGates {
+		if g.ConditionType == appsv1beta1.CloneSetStandbyPromoted {
+			cond := util.GetCondition(pod, appsv1beta1.CloneSetStandbyPromoted)
+			return cond != nil && cond.Status == v1.ConditionTrue
+		}
+	}
+	return true
+}
+
+// promoteStandbyPods promotes at most limit ready standby pods to serve, it returns true if any pod has been promoted.
+func (r *realControl) promoteStandbyPods(cs *appsv1beta1.CloneSet, standbyPods []*v1.Pod, updateRevision string, limit int) (bool, error) {
+	var promotable []*v1.Pod
+	for _, pod := range standbyPods {
+		if isStandbyPodPromotable(pod, updateRevision) {
+			promotable = append(promotable, pod)
+		}
+	}
+	if limit <= 0 || len(promotable) == 0 {
+		return false, nil
+	}
+	sort.Slice(promotable, func(i, j int) bool { return promotable[i].Name < promotable[j].Name })
+	if len(promotable) > limit {
+		promotable = promotable[:limit]
+	}
+
+	klog.V(3).InfoS("CloneSet began to promote standby pods", "cloneSet", klog.KObj(cs), "pods", util.GetPodNames(promotable).List())
+	var modified bool
+	for _, pod := range promotable {
+		pod = pod.DeepCopy()
+		body := fmt.Sprintf(`{"metadata":{"labels":{"%s":null}}}`, appsv1beta1.CloneSetStandbyLabelKey)
+		if err := r.Patch(context.TODO(), pod, client.RawPatch(types.StrategicMergePatchType, []byte(body))); err != nil {
+			r.recorder.Eventf(cs, v1.EventTypeWarning, "FailedPromote", "failed to promote standby pod %s: %v", pod.Name, err)
+			return modified, err
+		}
+		modified = true
+		clonesetutils.ResourceVersionExpectations.Expect(pod)
+
+		if err := r.setStandbyPromotedCondition(pod); err != nil {
+			return modified, err
+		}
+		r.recorder.Eventf(cs, v1.EventTypeNormal, "SuccessfulPromote", "succeed to promote standby pod %s", pod.Name)
+	}
+	return modified, nil
+}
+
+// syncPromotedPods makes sure the readiness gate of promoted pods has been set, in case it failed after
+// the standby label was removed.
+func (r *realControl) syncPromotedPods(cs *appsv1beta1.CloneSet, pods []*v1.Pod) (bool, error) {
+	var modified bool
+	for _, pod := range pods {
+		if isStandbyPodPromoted(pod) {
+			continue
+		}
+		klog.V(3).InfoS("CloneSet set readiness gate for promoted pod", "cloneSet", klog.KObj(cs), "pod", klog.KObj(pod))
+		if err := r.setStandbyPromotedCondition(pod.DeepCopy()); err != nil {
+			return modified, err
+		}
+		modified = true
+	}
+	return modified, nil
+}
+
+func (r *realControl) setStandbyPromotedCondition(pod *v1.Pod) error {
+	util.SetPodCondition(pod, v1.PodCondition{
+		Type:               appsv1beta1.CloneSetStandbyPromoted,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+	})
+	if err := r.Status().Update(context.TODO(), pod); err != nil {
+		return err
+	}
+	clonesetutils.ResourceVersionExpectations.Expect(pod)
+	return nil
+}
+
+// manageStandbyPods deletes the outdated and excess standby pods, and creates new ones to top up the pool.
+func (r *realControl) manageStandbyPods(
+	cs *appsv1beta1.CloneSet, updateRevision string,
+	allPods, standbyPods []*v1.Pod, pvcs []*v1.PersistentVolumeClaim,
+) (bool, error) {
+	var podsToDelete, currentPods []*v1.Pod
+	for _, pod := range standbyPods {
+		if !clonesetutils.EqualToRevisionHash("", pod, updateRevision) || isSpecifiedDelete(cs, pod) {
+			podsToDelete = append(podsToDelete, pod)
+		} else {
+			currentPods = append(currentPods, pod)
+		}
+	}
+	desired := getStandbyReplicas(cs)
+	if diff := len(currentPods) - desired; diff > 0 {
+		sort.Sort(kubecontroller.ActivePods(currentPods))
+		podsToDelete = append(podsToDelete, currentPods[:diff]...)
+	}
+
+	// standby pods have never served, so they are deleted without lifecycle hooks
+	if len(podsToDelete) > 0 {
+		klog.V(3).InfoS("CloneSet began to delete standby pods", "cloneSet", klog.KObj(cs), "pods", util.GetPodNames(podsToDelete).List())
+		var modified bool
+		for _, pod := range podsToDelete {
+			deleted, err := r.deletePodAndPVCs(cs, pod, pvcs)
+			modified = modified || deleted
+			if err != nil {
+				return modified, err
+			}
+		}
+		return modified, nil
+	}
+
+	diff := desired - len(currentPods)
+	if diff <= 0 {
+		return false, nil
+	}
+	klog.V(3).InfoS("CloneSet began to create standby pods", "cloneSet", klog.KObj(cs), "count", diff)
+	availableIDs, err := r.getAvailableIDs(cs, diff, allPods, pvcs)
+	if err != nil {
+		return false, err
+	}
+	existingPVCNames := sets.NewString()
+	for _, pvc := range pvcs {
+		existingPVCNames.Insert(pvc.Name)
+	}
+	newPods, err := clonesetcore.New(cs).NewVersionedPods(cs, cs, updateRevision, updateRevision, diff, 0, availableIDs.List())
+	if err != nil {
+		return false, err
+	}
+	for _, pod := range newPods {
+		pod.Labels[appsv1beta1.CloneSetStandbyLabelKey] = "true"
+		util.InjectReadinessGateToPod(pod, appsv1beta1.CloneSetStandbyPromoted)
+	}
+	return r.createNewPods(cs, cs, updateRevision, newPods, existingPVCNames)
+}
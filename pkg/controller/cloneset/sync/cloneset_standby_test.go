@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	clonesettest "github.com/openkruise/kruise/pkg/controller/cloneset/test"
+	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+func TestScaleWithStandbyPods(t *testing.T) {
+	const revision = "sample-rev2"
+	newPod := func(name, rev string, standby, containersReady bool) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					apps.ControllerRevisionHashLabelKey: rev,
+					appsv1beta1.CloneSetInstanceID:      name,
+				},
+			},
+			Spec:   v1.PodSpec{Containers: []v1.Container{{Name: "main", Image: "nginx"}}},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		}
+		if standby {
+			pod.Labels[appsv1beta1.CloneSetStandbyLabelKey] = "true"
+			pod.Spec.ReadinessGates = []v1.PodReadinessGate{{ConditionType: appsv1beta1.CloneSetStandbyPromoted}}
+		}
+		if containersReady {
+			pod.Status.Conditions = []v1.PodCondition{{Type: v1.ContainersReady, Status: v1.ConditionTrue}}
+			if !standby {
+				pod.Status.Conditions = append(pod.Status.Conditions, v1.PodCondition{Type: v1.PodReady, Status: v1.ConditionTrue})
+			}
+		}
+		return pod
+	}
+
+	cases := []struct {
+		name             string
+		replicas         int32
+		standbyReplicas  int32
+		pods             []*v1.Pod
+		expectedServing  []string
+		expectedStandby  int
+		expectedPromoted []string
+	}{
+		{
+			name:            "top up the standby pool",
+			replicas:        2,
+			standbyReplicas: 2,
+			pods:            []*v1.Pod{newPod("p0", revision, false, true), newPod("p1", revision, false, true)},
+			expectedServing: []string{"p0", "p1"},
+			expectedStandby: 2,
+		},
+		{
+			name:            "promote ready standby pods when scaling out",
+			replicas:        3,
+			standbyReplicas: 2,
+			pods: []*v1.Pod{
+				newPod("p0", revision, false, true), newPod("p1", revision, false, true),
+				newPod("s0", revision, true, false), newPod("s1", revision, true, true),
+			},
+			expectedServing:  []string{"p0", "p1", "s1"},
+			expectedStandby:  1,
+			expectedPromoted: []string{"s1"},
+		},
+		{
+			name:            "delete outdated and excess standby pods",
+			replicas:        1,
+			standbyReplicas: 1,
+			pods: []*v1.Pod{
+				newPod("p0", revision, false, true),
+				newPod("s0", "sample-rev1", true, true), newPod("s1", revision, true, true), newPod("s2", revision, true, false),
+			},
+			expectedServing: []string{"p0"},
+			expectedStandby: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := clonesettest.NewCloneSet(int(tc.replicas))
+			cs.Spec.ScaleStrategy.StandbyReplicas = utilpointer.Int32(tc.standbyReplicas)
+
+			fClient := fake.NewClientBuilder().WithScheme(kscheme).WithStatusSubresource(&v1.Pod{}).Build()
+			for _, pod := range tc.pods {
+				if err := fClient.Create(context.TODO(), pod); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ctrl := &realControl{Client: fClient, recorder: record.NewFakeRecorder(10)}
+			modified, err := ctrl.Scale(cs, cs, revision, revision, tc.pods, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !modified {
+				t.Fatalf("expected modified")
+			}
+
+			podList := &v1.PodList{}
+			if err := fClient.List(context.TODO(), podList); err != nil {
+				t.Fatal(err)
+			}
+			var serving []string
+			var standby int
+			for i := range podList.Items {
+				pod := &podList.Items[i]
+				if !clonesetutils.IsStandbyPod(pod) {
+					serving = append(serving, pod.Name)
+					continue
+				}
+				standby++
+				if !clonesetutils.EqualToRevisionHash("", pod, revision) {
+					t.Fatalf("expected standby pod %s in update revision", pod.Name)
+				}
+				var gated bool
+				for _, g := range pod.Spec.ReadinessGates {
+					gated = gated || g.ConditionType == appsv1beta1.CloneSetStandbyPromoted
+				}
+				if !gated {
+					t.Fatalf("expected readiness gate of standby pod %s, got %v", pod.Name, pod.Spec.ReadinessGates)
+				}
+			}
+			sort.Strings(serving)
+			if !reflect.DeepEqual(serving, tc.expectedServing) {
+				t.Fatalf("expected serving pods %v, got %v", tc.expectedServing, serving)
+			}
+			if standby != tc.expectedStandby {
+				t.Fatalf("expected %d standby pods, got %d", tc.expectedStandby, standby)
+			}
+			for _, name := range tc.expectedPromoted {
+				pod := &v1.Pod{}
+				if err := fClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, pod); err != nil {
+					t.Fatal(err)
+				}
+				if cond := util.GetCondition(pod, appsv1beta1.CloneSetStandbyPromoted); cond == nil || cond.Status != v1.ConditionTrue {
+					t.Fatalf("expected pod %s promoted, got condition %v", name, cond)
+				}
+			}
+		})
+	}
+}
@@ -97,6 +97,10 @@ func (c *realControl) Update(cs *appsv1beta1.CloneSet,
 	var waitUpdateIndexes []int
 	for i, pod := range pods {
 		if coreControl.IsPodUpdatePaused(pod) {
+			// requeue when the hold expires
+			if hold, _ := clonesetutils.GetPodUpdateHold(pod, time.Now()); hold != nil && hold.ExpireTime != nil {
+				clonesetutils.DurationStore.Push(key, time.Until(hold.ExpireTime.Time))
+			}
 			continue
 		}
 
@@ -133,7 +137,11 @@ func (c *realControl) Update(cs *appsv1beta1.CloneSet,
 	waitUpdateIndexes = SortUpdateIndexes(coreControl, cs.Spec.UpdateStrategy, pods, waitUpdateIndexes)
 
 	// 5. limit max count of pods can update
-	waitUpdateIndexes = limitUpdateIndexes(coreControl, cs.Spec.MinReadySeconds, diffRes, waitUpdateIndexes, pods, targetRevision.Name)
+	limitControl, err := c.withEndpointReadinessGate(cs, coreControl)
+	if err != nil {
+		return err
+	}
+	waitUpdateIndexes = limitUpdateIndexes(limitControl, cs.Spec.MinReadySeconds, diffRes, waitUpdateIndexes, pods, targetRevision.Name)
 
 	// 6. update pods
 	for _, idx := range waitUpdateIndexes {
@@ -372,6 +380,10 @@ func limitUpdateIndexes(coreControl clonesetcore.Control, minReadySeconds int32,
 
 	var unavailableCount, targetRevisionUnavailableCount, canUpdateCount int
 	for _, p := range pods {
+		// pods held from updating are not counted in maxUnavailable
+		if coreControl.IsPodUpdatePaused(p) {
+			continue
+		}
 		if !IsPodAvailable(coreControl, p, minReadySeconds) {
 			unavailableCount++
 			if clonesetutils.EqualToRevisionHash("", p, targetRevisionHash) {
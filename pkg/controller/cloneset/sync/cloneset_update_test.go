@@ -1162,6 +1162,9 @@ func TestCalculateUpdateCount(t *testing.T) {
 	readyPod := func() *v1.Pod {
 		return &v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}}}
 	}
+	heldPod := func() *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{appsv1beta1.CloneSetPodUpdateHoldAnnotation: `{"reason":"debugging"}`}}}
+	}
 	cases := []struct {
 		strategy           appsv1beta1.CloneSetUpdateStrategy
 		totalReplicas      int
@@ -1193,6 +1196,19 @@ func TestCalculateUpdateCount(t *testing.T) {
 			pods:               []*v1.Pod{readyPod(), {}, readyPod()},
 			expectedResult:     0,
 		},
+		{
+			// unavailable pod held from updating is not counted in maxUnavailable
+			strategy: appsv1beta1.CloneSetUpdateStrategy{
+				Type: appsv1beta1.RollingUpdateCloneSetUpdateStrategyType,
+				RollingUpdate: &appsv1beta1.RollingUpdateCloneSetStrategy{
+					PodUpdatePolicy: appsv1beta1.RecreateCloneSetPodUpdateStrategyType,
+				},
+			},
+			totalReplicas:      3,
+			oldRevisionIndexes: []int{1, 2},
+			pods:               []*v1.Pod{heldPod(), readyPod(), readyPod()},
+			expectedResult:     1,
+		},
 		{
 			strategy: appsv1beta1.CloneSetUpdateStrategy{
 				Type: appsv1beta1.RollingUpdateCloneSetUpdateStrategyType,
@@ -18,6 +18,7 @@ package utils
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"strings"
@@ -28,6 +29,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	kubecontroller "k8s.io/kubernetes/pkg/controller"
 	"k8s.io/utils/integer"
@@ -158,6 +160,41 @@ func GroupUpdateAndNotUpdatePods(pods []*v1.Pod, updateRevision string) (update,
 	return
 }
 
+// IsStandbyPod returns true if the pod is a standby pod that has not been promoted to serve.
+func IsStandbyPod(pod *v1.Pod) bool {
+	return pod.Labels[appsv1beta1.CloneSetStandbyLabelKey] == "true"
+}
+
+// GroupStandbyPods splits pods into serving pods and standby pods.
+func GroupStandbyPods(pods []*v1.Pod) (serving, standby []*v1.Pod) {
+	for _, p := range pods {
+		if IsStandbyPod(p) {
+			standby = append(standby, p)
+		} else {
+			serving = append(serving, p)
+		}
+	}
+	return
+}
+
+// GetPodUpdateHold returns the update hold of the pod and whether it is still in effect at now.
+// A hold with invalid value is ignored.
+func GetPodUpdateHold(pod *v1.Pod, now time.Time) (*appsv1beta1.CloneSetPodUpdateHold, bool) {
+	value, ok := pod.Annotations[appsv1beta1.CloneSetPodUpdateHoldAnnotation]
+	if !ok {
+		return nil, false
+	}
+	hold := &appsv1beta1.CloneSetPodUpdateHold{}
+	if err := json.Unmarshal([]byte(value), hold); err != nil {
+		klog.ErrorS(err, "Failed to parse update hold of pod", "pod", klog.KObj(pod), "value", value)
+		return nil, false
+	}
+	if hold.ExpireTime != nil && !now.Before(hold.ExpireTime.Time) {
+		return hold, false
+	}
+	return hold, true
+}
+
 // UpdateStorage insert volumes generated by cs.Spec.VolumeClaimTemplates into Pod.
 func UpdateStorage(cs *appsv1beta1.CloneSet, pod *v1.Pod) {
 	currentVolumes := pod.Spec.Volumes
@@ -653,3 +653,48 @@ func TestRemoveCloneSetCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPodUpdateHold(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name           string
+		annotation     string
+		expectedHeld   bool
+		expectedReason string
+	}{
+		{name: "no hold"},
+		{name: "invalid hold", annotation: "debugging"},
+		{name: "hold without expiry", annotation: `{"reason":"debugging"}`, expectedHeld: true, expectedReason: "debugging"},
+		{
+			name:           "hold not expired",
+			annotation:     `{"reason":"debugging","expireTime":"` + now.Add(time.Hour).UTC().Format(time.RFC3339) + `"}`,
+			expectedHeld:   true,
+			expectedReason: "debugging",
+		},
+		{
+			name:           "hold expired",
+			annotation:     `{"reason":"debugging","expireTime":"` + now.Add(-time.Hour).UTC().Format(time.RFC3339) + `"}`,
+			expectedReason: "debugging",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &v1.Pod{}
+			if tt.annotation != "" {
+				pod.Annotations = map[string]string{appsv1beta1.CloneSetPodUpdateHoldAnnotation: tt.annotation}
+			}
+			hold, held := GetPodUpdateHold(pod, now)
+			if held != tt.expectedHeld {
+				t.Fatalf("expected held %v, got %v", tt.expectedHeld, held)
+			}
+			var reason string
+			if hold != nil {
+				reason = hold.Reason
+			}
+			if reason != tt.expectedReason {
+				t.Fatalf("expected reason %q, got %q", tt.expectedReason, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TopologySpreadChooser chooses pods to delete one by one from the largest topology domains,
+// so that the skew of pods among domains is kept as low as possible after scaling in.
+type TopologySpreadChooser struct {
+	topologyKeys  []string
+	availableFunc func(*v1.Pod) bool
+
+	// podDomains is the topology value of each pod for every topology key, empty if the node has no such label.
+	podDomains map[types.UID][]string
+	// domainCounts is the number of remaining pods in each domain for every topology key.
+	domainCounts []map[string]int
+	// reasons records why each pod has been chosen.
+	reasons map[types.UID]string
+}
+
+// NewTopologySpreadChooser returns a TopologySpreadChooser for all active pods of a workload.
+func NewTopologySpreadChooser(pods []*v1.Pod, topologyKeys []string, reader client.Reader, availableFunc func(*v1.Pod) bool) *TopologySpreadChooser {
+	c := &TopologySpreadChooser{
+		topologyKeys:  topologyKeys,
+		availableFunc: availableFunc,
+		podDomains:    make(map[types.UID][]string, len(pods)),
+		domainCounts:  make([]map[string]int, len(topologyKeys)),
+		reasons:       make(map[types.UID]string),
+	}
+	for i := range topologyKeys {
+		c.domainCounts[i] = make(map[string]int)
+	}
+
+	nodes := make(map[string]*v1.Node)
+	for _, pod := range pods {
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" {
+			continue
+		}
+		node, ok := nodes[nodeName]
+		if !ok {
+			node = &v1.Node{}
+			if err := reader.Get(context.TODO(), types.NamespacedName{Name: nodeName}, node); err != nil {
+				node = nil
+			}
+			nodes[nodeName] = node
+		}
+		if node == nil {
+			continue
+		}
+
+		domains := make([]string, len(topologyKeys))
+		for i, key := range topologyKeys {
+			domains[i] = node.Labels[key]
+		}
+		c.podDomains[pod.UID] = domains
+		for i, domain := range domains {
+			if domain != "" {
+				c.domainCounts[i][domain]++
+			}
+		}
+	}
+	return c
+}
+
+// Choose returns diff pods in candidates to delete.
+// Pods not scheduled are chosen first, and then the pods that decrease the skew of domains most.
+// Pods with the same skew are sorted by ActivePodsWithRanks, so not-ready pods and pods with lower
+// deletion-cost are chosen before the others.
+// The chosen pods are removed from their domains, so that the following calls can take them into account.
+func (c *TopologySpreadChooser) Choose(candidates []*v1.Pod, diff int) []*v1.Pod {
+	if diff <= 0 {
+		return nil
+	}
+
+	rest := make([]*v1.Pod, len(candidates))
+	copy(rest, candidates)
+	sort.Sort(ActivePodsWithRanks{Pods: rest, AvailableFunc: c.availableFunc})
+
+	chosen := make([]*v1.Pod, 0, diff)
+	for len(chosen) < diff && len(rest) > 0 {
+		bestIdx, bestScore := 0, -1
+		for i, pod := range rest {
+			if score := c.skewScore(pod); score > bestScore {
+				bestIdx, bestScore = i, score
+			}
+		}
+
+		pod := rest[bestIdx]
+		c.reasons[pod.UID] = c.describe(pod)
+		c.remove(pod)
+		chosen = append(chosen, pod)
+		rest = append(rest[:bestIdx], rest[bestIdx+1:]...)
+	}
+	return chosen
+}
+
+// Reason returns why the pod has been chosen.
+func (c *TopologySpreadChooser) Reason(pod *v1.Pod) string {
+	return c.reasons[pod.UID]
+}
+
+// skewScore is the sum of how many pods the domains of this pod exceed the smallest domains.
+// Pods not scheduled always have the highest score.
+func (c *TopologySpreadChooser) skewScore(pod *v1.Pod) int {
+	if pod.Spec.NodeName == "" {
+		return math.MaxInt
+	}
+	domains, ok := c.podDomains[pod.UID]
+	if !ok {
+		return 0
+	}
+	var score int
+	for i, domain := range domains {
+		if domain == "" {
+			continue
+		}
+		score += c.domainCounts[i][domain] - minDomainCount(c.domainCounts[i])
+	}
+	return score
+}
+
+func (c *TopologySpreadChooser) remove(pod *v1.Pod) {
+	for i, domain := range c.podDomains[pod.UID] {
+		if domain != "" && c.domainCounts[i][domain] > 0 {
+			c.domainCounts[i][domain]--
+		}
+	}
+}
+
+func (c *TopologySpreadChooser) describe(pod *v1.Pod) string {
+	if pod.Spec.NodeName == "" {
+		return "pod is not scheduled"
+	}
+	domains, ok := c.podDomains[pod.UID]
+	if !ok {
+		return fmt.Sprintf("node %s not found", pod.Spec.NodeName)
+	}
+	var details []string
+	for i, domain := range domains {
+		if domain == "" {
+			details = append(details, fmt.Sprintf("node %s has no %s label", pod.Spec.NodeName, c.topologyKeys[i]))
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s=%s has %d pods (min %d)",
+			c.topologyKeys[i], domain, c.domainCounts[i][domain], minDomainCount(c.domainCounts[i])))
+	}
+	return strings.Join(details, ", ")
+}
+
+func minDomainCount(counts map[string]int) int {
+	first := true
+	var minCount int
+	for _, count := range counts {
+		if first || count < minCount {
+			minCount = count
+			first = false
+		}
+	}
+	return minCount
+}
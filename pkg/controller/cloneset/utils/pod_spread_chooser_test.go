@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTopologySpreadChooser(t *testing.T) {
+	nodes := []client.Object{
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1", Labels: map[string]string{v1.LabelTopologyZone: "z1"}}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n2", Labels: map[string]string{v1.LabelTopologyZone: "z1"}}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n3", Labels: map[string]string{v1.LabelTopologyZone: "z2"}}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n4", Labels: map[string]string{v1.LabelTopologyZone: "z3"}}},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(nodes...).Build()
+
+	now := time.Now()
+	newPod := func(name, node string, age time.Duration, ready bool, cost string) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				UID:               types.UID(name),
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec:   v1.PodSpec{NodeName: node},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		}
+		if node == "" {
+			pod.Status.Phase = v1.PodPending
+		}
+		if ready {
+			pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-age))}}
+		}
+		if cost != "" {
+			pod.Annotations = map[string]string{PodDeletionCost: cost}
+		}
+		return pod
+	}
+
+	// z1: 4 pods, z2: 2 pods, z3: 1 pod, and 1 pod not scheduled
+	genPods := func() []*v1.Pod {
+		return []*v1.Pod{
+			newPod("n1-0", "n1", time.Minute*30, true, ""),
+			newPod("n1-1", "n1", time.Hour, false, ""),
+			newPod("n2-0", "n2", time.Hour, true, ""),
+			newPod("n2-1", "n2", time.Hour, true, "-10"),
+			newPod("n3-0", "n3", time.Minute, true, ""),
+			newPod("n3-1", "n3", time.Minute*2, true, ""),
+			newPod("n4-0", "n4", time.Hour*2, true, ""),
+			newPod("pending", "", 0, false, ""),
+		}
+	}
+
+	cases := []struct {
+		name            string
+		diff            int
+		expectedChosen  []string
+		expectedReasons map[string]string
+	}{
+		{
+			name:           "choose from the largest zone by readiness and deletion-cost",
+			diff:           5,
+			expectedChosen: []string{"pending", "n1-1", "n2-1", "n3-0", "n1-0"},
+			expectedReasons: map[string]string{
+				"pending": "pod is not scheduled",
+				"n1-1":    "topology.kubernetes.io/zone=z1 has 4 pods (min 1)",
+				"n2-1":    "topology.kubernetes.io/zone=z1 has 3 pods (min 1)",
+				"n3-0":    "topology.kubernetes.io/zone=z2 has 2 pods (min 1)",
+				"n1-0":    "topology.kubernetes.io/zone=z1 has 2 pods (min 1)",
+			},
+		},
+		{
+			name:           "choose all",
+			diff:           10,
+			expectedChosen: []string{"pending", "n1-1", "n2-1", "n3-0", "n1-0", "n3-1", "n2-0", "n4-0"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pods := genPods()
+			chooser := NewTopologySpreadChooser(pods, []string{v1.LabelTopologyZone}, fakeClient, nil)
+			chosen := chooser.Choose(pods, tc.diff)
+			var gotChosen []string
+			for _, pod := range chosen {
+				gotChosen = append(gotChosen, pod.Name)
+				if expectedReason, ok := tc.expectedReasons[pod.Name]; ok && chooser.Reason(pod) != expectedReason {
+					t.Fatalf("expected reason of %s: %q, got %q", pod.Name, expectedReason, chooser.Reason(pod))
+				}
+			}
+			if !reflect.DeepEqual(gotChosen, tc.expectedChosen) {
+				t.Fatalf("expected chosen %v, got %v", tc.expectedChosen, gotChosen)
+			}
+		})
+	}
+}
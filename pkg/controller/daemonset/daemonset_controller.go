@@ -28,6 +28,7 @@ import (
 
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -561,7 +562,30 @@ func (dsc *ReconcileDaemonSet) updateDaemonSetStatus(ctx context.Context, ds *ap
 	}
 	numberUnavailable := desiredNumberScheduled - numberAvailable
 
-	err = dsc.storeDaemonSetStatus(ctx, ds, desiredNumberScheduled, currentNumberScheduled, numberMisscheduled, numberReady, updatedNumberScheduled, numberAvailable, numberUnavailable, updateObservedGen, hash)
+	health, err := checkNodeHealth(ds, nodeList, nodeToDaemonPods, hash)
+	if err != nil {
+		return fmt.Errorf("couldn't check node health for DaemonSet %q: %v", ds.Name, err)
+	}
+	var numberUpdateFailed int
+	var rollingUpdateStopped bool
+	if health != nil {
+		numberUpdateFailed = len(health.failedNodes)
+		rollingUpdateStopped = health.stopped
+	}
+	conditions := updateRollingUpdateStoppedCondition(ds.Status.Conditions, rollingUpdateStopped, numberUpdateFailed)
+	var wave *updateWave
+	if len(getUpdateWaves(ds)) > 0 {
+		healthyNodeList := filterNodesByHealth(nodeList, health)
+		nodeNamesToUpdate, err := dsc.getNodeNamesToUpdate(ds, healthyNodeList, hash, nodeToDaemonPods)
+		if err != nil {
+			return fmt.Errorf("couldn't get nodes to update for DaemonSet %q: %v", ds.Name, err)
+		}
+		if wave, err = getCurrentUpdateWave(ds, healthyNodeList, nodeToDaemonPods, nodeNamesToUpdate, hash, now); err != nil {
+			return fmt.Errorf("couldn't get current update wave for DaemonSet %q: %v", ds.Name, err)
+		}
+	}
+
+	err = dsc.storeDaemonSetStatus(ctx, ds, desiredNumberScheduled, currentNumberScheduled, numberMisscheduled, numberReady, updatedNumberScheduled, numberAvailable, numberUnavailable, numberUpdateFailed, updateObservedGen, hash, wave.status(), conditions)
 	if err != nil {
 		return fmt.Errorf("error storing status for DaemonSet %v: %v", ds.Name, err)
 	}
@@ -582,9 +606,12 @@ func (dsc *ReconcileDaemonSet) storeDaemonSetStatus(
 	numberReady,
 	updatedNumberScheduled,
 	numberAvailable,
-	numberUnavailable int,
+	numberUnavailable,
+	numberUpdateFailed int,
 	updateObservedGen bool,
-	hash string) error {
+	hash string,
+	currentUpdateWave *appsv1beta1.DaemonSetUpdateWaveStatus,
+	conditions []apps.DaemonSetCondition) error {
 	if int(ds.Status.DesiredNumberScheduled) == desiredNumberScheduled &&
 		int(ds.Status.CurrentNumberScheduled) == currentNumberScheduled &&
 		int(ds.Status.NumberMisscheduled) == numberMisscheduled &&
@@ -592,8 +619,11 @@ func (dsc *ReconcileDaemonSet) storeDaemonSetStatus(
 		int(ds.Status.UpdatedNumberScheduled) == updatedNumberScheduled &&
 		int(ds.Status.NumberAvailable) == numberAvailable &&
 		int(ds.Status.NumberUnavailable) == numberUnavailable &&
+		int(ds.Status.NumberUpdateFailed) == numberUpdateFailed &&
 		ds.Status.ObservedGeneration >= ds.Generation &&
-		ds.Status.UpdateRevision == hash {
+		ds.Status.UpdateRevision == hash &&
+		apiequality.Semantic.DeepEqual(ds.Status.CurrentUpdateWave, currentUpdateWave) &&
+		apiequality.Semantic.DeepEqual(ds.Status.Conditions, conditions) {
 		return nil
 	}
 
@@ -611,7 +641,10 @@ func (dsc *ReconcileDaemonSet) storeDaemonSetStatus(
 		toUpdate.Status.UpdatedNumberScheduled = int32(updatedNumberScheduled)
 		toUpdate.Status.NumberAvailable = int32(numberAvailable)
 		toUpdate.Status.NumberUnavailable = int32(numberUnavailable)
+		toUpdate.Status.NumberUpdateFailed = int32(numberUpdateFailed)
 		toUpdate.Status.UpdateRevision = hash
+		toUpdate.Status.CurrentUpdateWave = currentUpdateWave
+		toUpdate.Status.Conditions = conditions
 
 		if _, updateErr = dsClient.UpdateStatus(ctx, toUpdate, metav1.UpdateOptions{}); updateErr == nil {
 			klog.InfoS("Updated DaemonSet status", "daemonSet", klog.KObj(ds), "status", kruiseutil.DumpJSON(toUpdate.Status))
@@ -913,6 +946,14 @@ func (dsc *ReconcileDaemonSet) podsShouldBeOnNode(
 		if len(daemonPodsRunning) <= 1 {
 			// // There are no excess pods to be pruned
 			if len(daemonPodsRunning) == 0 && shouldRun {
+				// Advanced: wait for the old pod with host port conflict to terminate
+				if wait, waiting := hostPortHandoffWaitingTime(ds, daemonPods, dsc.failedPodsBackoff.Clock.Now()); waiting {
+					klog.V(5).InfoS("DaemonSet was waiting for the old pod with host port conflict to terminate", "daemonSet", klog.KObj(ds), "nodeName", node.Name)
+					if wait > 0 {
+						durationStore.Push(keyFunc(ds), wait)
+					}
+					break
+				}
 				// We are surging so we need to have at least one non-deleted pod on the node
 				nodesNeedingDaemonPods = append(nodesNeedingDaemonPods, node.Name)
 			}
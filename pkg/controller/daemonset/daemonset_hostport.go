@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package daemonset
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+type hostPort struct {
+	protocol corev1.Protocol
+	port     int32
+}
+
+func getHostPortConflict(ds *appsv1beta1.DaemonSet) *appsv1beta1.DaemonSetHostPortConflict {
+	if ds.Spec.UpdateStrategy.RollingUpdate == nil {
+		return nil
+	}
+	return ds.Spec.UpdateStrategy.RollingUpdate.HostPortConflict
+}
+
+// hostPortConflictUnavailableCount returns the number of nodes with host port conflict that can be
+// updated by deleting the old pod first at the same time, out of numberToSchedule. Defaults to 1.
+func hostPortConflictUnavailableCount(conflict *appsv1beta1.DaemonSetHostPortConflict, numberToSchedule int) (int, error) {
+	if conflict.MaxUnavailable == nil {
+		return 1, nil
+	}
+	return intstrutil.GetScaledValueFromIntOrPercent(conflict.MaxUnavailable, numberToSchedule, true)
+}
+
+// getHostPorts returns the host ports used by the pod spec.
+// Like DefaultHostNetworkHostPortsInPodTemplates, the container ports of pods in host network
+// are regarded as host ports even if the hostPorts are not set.
+func getHostPorts(spec *corev1.PodSpec) sets.Set[hostPort] {
+	ports := sets.New[hostPort]()
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			for _, p := range containers[i].Ports {
+				port := p.HostPort
+				if port == 0 && spec.HostNetwork {
+					port = p.ContainerPort
+				}
+				if port == 0 {
+					continue
+				}
+				protocol := p.Protocol
+				if protocol == "" {
+					protocol = corev1.ProtocolTCP
+				}
+				ports.Insert(hostPort{protocol: protocol, port: port})
+			}
+		}
+	}
+	return ports
+}
+
+// hasHostPortConflict returns true if the pod and the pods created from the current template
+// of the DaemonSet can not run on the same node because of host ports.
+func hasHostPortConflict(ds *appsv1beta1.DaemonSet, pod *corev1.Pod) bool {
+	return getHostPorts(&pod.Spec).HasAny(getHostPorts(&ds.Spec.Template.Spec).UnsortedList()...)
+}
+
+// isHostPortHandoffInProgress returns true if the node is being updated by deleting the old pod
+// with host port conflict first, which means either the old pod is terminating or the new pod is
+// not available yet.
+func isHostPortHandoffInProgress(ds *appsv1beta1.DaemonSet, podsOnNode []*corev1.Pod, newPod *corev1.Pod, now time.Time) bool {
+	if newPod != nil {
+		return len(getHostPorts(&newPod.Spec)) > 0 && !isDaemonPodAvailable(newPod, ds.Spec.MinReadySeconds, metav1.Time{Time: now})
+	}
+	for _, pod := range podsOnNode {
+		if pod.DeletionTimestamp != nil && hasHostPortConflict(ds, pod) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostPortHandoffWaitingTime returns how long to wait before creating the new pod on the node,
+// until the terminating pods with host port conflict are gone or the handoffSeconds is exceeded.
+func hostPortHandoffWaitingTime(ds *appsv1beta1.DaemonSet, podsOnNode []*corev1.Pod, now time.Time) (time.Duration, bool) {
+	conflict := getHostPortConflict(ds)
+	if conflict == nil {
+		return 0, false
+	}
+	var waiting bool
+	var wait time.Duration
+	for _, pod := range podsOnNode {
+		if pod.DeletionTimestamp == nil || !hasHostPortConflict(ds, pod) {
+			continue
+		}
+		if conflict.HandoffSeconds <= 0 {
+			// wait for the pod deletion event
+			waiting = true
+			continue
+		}
+		deletedAt := pod.DeletionTimestamp.Time
+		if pod.DeletionGracePeriodSeconds != nil {
+			deletedAt = deletedAt.Add(-time.Duration(*pod.DeletionGracePeriodSeconds) * time.Second)
+		}
+		if d := deletedAt.Add(time.Duration(conflict.HandoffSeconds) * time.Second).Sub(now); d > 0 {
+			waiting = true
+			if d > wait {
+				wait = d
+			}
+		}
+	}
+	return wait, waiting
+}
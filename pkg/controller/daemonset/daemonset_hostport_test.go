@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package daemonset
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func TestHasHostPortConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		template corev1.PodSpec
+		pod      corev1.PodSpec
+		expected bool
+	}{
+		{
+			name:     "no host ports",
+			template: corev1.PodSpec{Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{ContainerPort: 80}}}}},
+			pod:      corev1.PodSpec{Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{ContainerPort: 80}}}}},
+			expected: false,
+		},
+		{
+			name:     "same host port",
+			template: corev1.PodSpec{Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{ContainerPort: 80, HostPort: 8080}}}}},
+			pod:      corev1.PodSpec{Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{ContainerPort: 81, HostPort: 8080}}}}},
+			expected: true,
+		},
+		{
+			name:     "different protocols",
+			template: corev1.PodSpec{Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{ContainerPort: 53, HostPort: 53, Protocol: corev1.ProtocolUDP}}}}},
+			pod:      corev1.PodSpec{Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{ContainerPort: 53, HostPort: 53}}}}},
+			expected: false,
+		},
+		{
+			name:     "container ports in host network",
+			template: corev1.PodSpec{HostNetwork: true, Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{ContainerPort: 80}}}}},
+			pod:      corev1.PodSpec{HostNetwork: true, InitContainers: []corev1.Container{{Ports: []corev1.ContainerPort{{ContainerPort: 80}}}}},
+			expected: true,
+		},
+		{
+			name:     "host ports changed",
+			template: corev1.PodSpec{HostNetwork: true, Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{ContainerPort: 81}}}}},
+			pod:      corev1.PodSpec{HostNetwork: true, Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{ContainerPort: 80}}}}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := newDaemonSet("foo")
+			ds.Spec.Template.Spec = tt.template
+			if got := hasHostPortConflict(ds, &corev1.Pod{Spec: tt.pod}); got != tt.expected {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHostPortHandoffWaitingTime(t *testing.T) {
+	now := time.Now()
+	ds := newDaemonSet("foo")
+	ds.Spec.Template.Spec.HostNetwork = true
+	ds.Spec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: 80}}
+	ds.Spec.UpdateStrategy = newUpdateSurge(intstr.FromInt(1))
+	newTerminatingPod := func(deletedAgo time.Duration) *corev1.Pod {
+		pod := newPod("foo-", "node-0", simpleDaemonSetLabel, ds)
+		pod.DeletionTimestamp = &metav1.Time{Time: now.Add(30*time.Second - deletedAgo)}
+		pod.DeletionGracePeriodSeconds = ptr.To(int64(30))
+		return pod
+	}
+
+	if _, waiting := hostPortHandoffWaitingTime(ds, []*corev1.Pod{newTerminatingPod(0)}, now); waiting {
+		t.Fatalf("expected no waiting without hostPortConflict")
+	}
+
+	ds.Spec.UpdateStrategy.RollingUpdate.HostPortConflict = &appsv1beta1.DaemonSetHostPortConflict{}
+	if wait, waiting := hostPortHandoffWaitingTime(ds, []*corev1.Pod{newTerminatingPod(time.Hour)}, now); !waiting || wait != 0 {
+		t.Fatalf("expected waiting for termination, got %v %v", wait, waiting)
+	}
+
+	ds.Spec.UpdateStrategy.RollingUpdate.HostPortConflict.HandoffSeconds = 10
+	if wait, waiting := hostPortHandoffWaitingTime(ds, []*corev1.Pod{newTerminatingPod(4 * time.Second)}, now); !waiting || wait != 6*time.Second {
+		t.Fatalf("expected waiting 6s, got %v %v", wait, waiting)
+	}
+	if _, waiting := hostPortHandoffWaitingTime(ds, []*corev1.Pod{newTerminatingPod(10 * time.Second)}, now); waiting {
+		t.Fatalf("expected no waiting after handoffSeconds")
+	}
+	if _, waiting := hostPortHandoffWaitingTime(ds, nil, now); waiting {
+		t.Fatalf("expected no waiting without terminating pods")
+	}
+}
@@ -1,9 +1,18 @@
 package daemonset
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
 
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
 )
 
@@ -39,3 +48,178 @@ func (dsc *ReconcileDaemonSet) pruneNodesAndOrphanedPods(ds *appsv1beta1.DaemonS
 
 	return nodeToDaemonPods
 }
+
+var defaultUnhealthyNodeConditions = []appsv1beta1.DaemonSetNodeCondition{
+	{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+	{Type: corev1.NodeReady, Status: corev1.ConditionUnknown},
+	{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue},
+	{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue},
+	{Type: corev1.NodePIDPressure, Status: corev1.ConditionTrue},
+}
+
+// nodeHealthCheckResult is the result of checking the nodes with nodeHealthCheck in rolling update.
+type nodeHealthCheckResult struct {
+	// skippedNodes are the nodes not to be updated, which are unhealthy or failed.
+	skippedNodes sets.String
+	// failedNodes are the nodes which became unhealthy after updated.
+	failedNodes []string
+	// podsToRollback are the updated pods on the failed nodes to be rolled back.
+	podsToRollback []*corev1.Pod
+	// stopped is true if the failed nodes exceed maxFailedNodes, or the rolling update has been stopped before.
+	stopped bool
+}
+
+func getNodeHealthCheck(ds *appsv1beta1.DaemonSet) *appsv1beta1.DaemonSetNodeHealthCheck {
+	if ds.Spec.UpdateStrategy.Type != appsv1beta1.RollingUpdateDaemonSetStrategyType || ds.Spec.UpdateStrategy.RollingUpdate == nil {
+		return nil
+	}
+	return ds.Spec.UpdateStrategy.RollingUpdate.NodeHealthCheck
+}
+
+// checkNodeHealth returns nil if the DaemonSet has no nodeHealthCheck.
+func checkNodeHealth(ds *appsv1beta1.DaemonSet, nodeList []*corev1.Node, nodeToDaemonPods map[string][]*corev1.Pod, hash string) (*nodeHealthCheckResult, error) {
+	check := getNodeHealthCheck(ds)
+	if check == nil {
+		return nil, nil
+	}
+
+	result := &nodeHealthCheckResult{skippedNodes: sets.NewString()}
+	var desiredNumberScheduled int
+	for _, node := range nodeList {
+		if wantToRun, _ := nodeShouldRunDaemonPod(node, ds); !wantToRun {
+			continue
+		}
+		desiredNumberScheduled++
+
+		newPod, oldPod, _ := findUpdatedPodsOnNode(ds, nodeToDaemonPods[node.Name], hash)
+		if oldPod != nil && oldPod.Annotations[appsv1beta1.DaemonSetRolledBackFromRevisionAnnotation] == hash {
+			// the pod has been rolled back from the current revision, never update it again
+			result.skippedNodes.Insert(node.Name)
+			result.failedNodes = append(result.failedNodes, node.Name)
+			continue
+		}
+		conditions := getUnhealthyNodeConditions(check, node)
+		if len(conditions) == 0 {
+			continue
+		}
+		result.skippedNodes.Insert(node.Name)
+		if newPod == nil || !isNodeUnhealthyAfterUpdated(check, conditions, newPod, hash) {
+			continue
+		}
+		klog.V(3).InfoS("DaemonSet found node unhealthy after updated", "daemonSet", klog.KObj(ds), "nodeName", node.Name, "pod", klog.KObj(newPod))
+		result.failedNodes = append(result.failedNodes, node.Name)
+		if check.Policy == appsv1beta1.RollbackUnhealthyNodePolicyType && oldPod == nil {
+			result.podsToRollback = append(result.podsToRollback, newPod)
+		}
+	}
+
+	var maxFailedNodes int
+	if check.MaxFailedNodes != nil {
+		var err error
+		if maxFailedNodes, err = intstrutil.GetScaledValueFromIntOrPercent(check.MaxFailedNodes, desiredNumberScheduled, false); err != nil {
+			return nil, fmt.Errorf("invalid value for MaxFailedNodes: %v", err)
+		}
+	}
+	result.stopped = len(result.failedNodes) > maxFailedNodes || isRollingUpdateStopped(ds, hash)
+	return result, nil
+}
+
+// isRollingUpdateStopped returns true if the rolling update of the revision has been stopped by the node health check,
+// so that it keeps stopped even if the failed nodes become healthy again.
+func isRollingUpdateStopped(ds *appsv1beta1.DaemonSet, hash string) bool {
+	if ds.Status.UpdateRevision != hash {
+		return false
+	}
+	for _, c := range ds.Status.Conditions {
+		if c.Type == appsv1beta1.DaemonSetConditionRollingUpdateStopped {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// updateRollingUpdateStoppedCondition returns the conditions with the RollingUpdateStopped condition added if the
+// rolling update is stopped, or removed if not.
+func updateRollingUpdateStoppedCondition(conditions []apps.DaemonSetCondition, stopped bool, numberUpdateFailed int) []apps.DaemonSetCondition {
+	newConditions := make([]apps.DaemonSetCondition, 0, len(conditions)+1)
+	var found bool
+	for _, c := range conditions {
+		if c.Type == appsv1beta1.DaemonSetConditionRollingUpdateStopped {
+			if !stopped || c.Status != corev1.ConditionTrue {
+				continue
+			}
+			found = true
+		}
+		newConditions = append(newConditions, c)
+	}
+	if stopped && !found {
+		newConditions = append(newConditions, apps.DaemonSetCondition{
+			Type:               appsv1beta1.DaemonSetConditionRollingUpdateStopped,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             "TooManyFailedNodes",
+			Message:            fmt.Sprintf("rolling update stopped for %d nodes unhealthy after updated", numberUpdateFailed),
+		})
+	}
+	if len(newConditions) == 0 {
+		return nil
+	}
+	return newConditions
+}
+
+// getUnhealthyNodeConditions returns the conditions of the node that match the unhealthy conditions.
+func getUnhealthyNodeConditions(check *appsv1beta1.DaemonSetNodeHealthCheck, node *corev1.Node) []corev1.NodeCondition {
+	unhealthyConditions := check.UnhealthyConditions
+	if len(unhealthyConditions) == 0 {
+		unhealthyConditions = defaultUnhealthyNodeConditions
+	}
+	var conditions []corev1.NodeCondition
+	for _, c := range node.Status.Conditions {
+		for _, u := range unhealthyConditions {
+			if c.Type == u.Type && c.Status == u.Status {
+				conditions = append(conditions, c)
+				break
+			}
+		}
+	}
+	return conditions
+}
+
+// isNodeUnhealthyAfterUpdated returns true if any of the unhealthy conditions of the node
+// turned up within soakSeconds after the updated pod started.
+func isNodeUnhealthyAfterUpdated(check *appsv1beta1.DaemonSetNodeHealthCheck, conditions []corev1.NodeCondition, pod *corev1.Pod, hash string) bool {
+	var startTime time.Time
+	if v, ok := appspub.GetInPlaceUpdateState(pod); ok {
+		state := appspub.InPlaceUpdateState{}
+		if err := json.Unmarshal([]byte(v), &state); err == nil && state.Revision == hash {
+			startTime = state.UpdateTimestamp.Time
+		}
+	}
+	if startTime.IsZero() && pod.Status.StartTime != nil {
+		startTime = pod.Status.StartTime.Time
+	}
+	if startTime.IsZero() {
+		return false
+	}
+	soakUntil := startTime.Add(time.Duration(check.SoakSeconds) * time.Second)
+	for _, c := range conditions {
+		if !c.LastTransitionTime.Time.Before(startTime) && !c.LastTransitionTime.Time.After(soakUntil) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterNodesByHealth returns the nodes which are not skipped by the node health check.
+func filterNodesByHealth(nodeList []*corev1.Node, result *nodeHealthCheckResult) []*corev1.Node {
+	if result == nil || result.skippedNodes.Len() == 0 {
+		return nodeList
+	}
+	nodes := make([]*corev1.Node, 0, len(nodeList))
+	for _, node := range nodeList {
+		if !result.skippedNodes.Has(node.Name) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
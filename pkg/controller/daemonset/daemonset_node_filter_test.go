@@ -1,9 +1,13 @@
 package daemonset
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
+	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
@@ -194,3 +198,99 @@ func TestDaemonSetIgnoreNotReadyNodesWhenUpdating(t *testing.T) {
 	clearExpectations(t, manager, ds, podControl)
 	expectSyncDaemonSets(t, manager, ds, podControl, 2, 0, 0)
 }
+
+func TestCheckNodeHealth(t *testing.T) {
+	now := time.Now()
+	ds := newDaemonSet("foo")
+	ds.Spec.UpdateStrategy = newUpdateUnavailable(intstr.FromInt(1))
+	newDaemonPod := func(hash string, startTime time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-" + hash, Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: hash}},
+			Status:     corev1.PodStatus{StartTime: &metav1.Time{Time: startTime}},
+		}
+	}
+	newUnhealthyNode := func(name string, conditionType corev1.NodeConditionType, since time.Time) *corev1.Node {
+		node := newNode(name, nil)
+		node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{
+			Type: conditionType, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(since),
+		})
+		return node
+	}
+	nodes := []*corev1.Node{
+		newNode("healthy", nil),
+		newUnhealthyNode("unhealthy-before-updated", corev1.NodeMemoryPressure, now.Add(-time.Hour)),
+		newUnhealthyNode("unhealthy-after-updated", corev1.NodeDiskPressure, now.Add(-time.Minute)),
+		newUnhealthyNode("unhealthy-after-soaked", corev1.NodeDiskPressure, now.Add(-time.Minute)),
+		newUnhealthyNode("custom-condition", "KernelDeadlock", now.Add(-time.Minute)),
+		newNode("rolled-back", nil),
+	}
+	nodeToDaemonPods := map[string][]*corev1.Pod{
+		"healthy":                  {newDaemonPod("v2", now.Add(-2*time.Minute))},
+		"unhealthy-before-updated": {newDaemonPod("v2", now.Add(-2*time.Minute))},
+		"unhealthy-after-updated":  {newDaemonPod("v2", now.Add(-2*time.Minute))},
+		"unhealthy-after-soaked":   {newDaemonPod("v2", now.Add(-time.Hour))},
+		"custom-condition":         {newDaemonPod("v2", now.Add(-2*time.Minute))},
+		"rolled-back":              {newDaemonPod("v1", now.Add(-time.Hour))},
+	}
+	nodeToDaemonPods["rolled-back"][0].Annotations = map[string]string{appsv1beta1.DaemonSetRolledBackFromRevisionAnnotation: "v2"}
+
+	if result, err := checkNodeHealth(ds, nodes, nodeToDaemonPods, "v2"); err != nil || result != nil {
+		t.Fatalf("expected no result without nodeHealthCheck, got %v %v", result, err)
+	}
+
+	ds.Spec.UpdateStrategy.RollingUpdate.NodeHealthCheck = &appsv1beta1.DaemonSetNodeHealthCheck{SoakSeconds: 600}
+	result, err := checkNodeHealth(ds, nodes, nodeToDaemonPods, "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"rolled-back", "unhealthy-after-soaked", "unhealthy-after-updated", "unhealthy-before-updated"}; !reflect.DeepEqual(result.skippedNodes.List(), expected) {
+		t.Fatalf("expected skipped nodes %v, got %v", expected, result.skippedNodes.List())
+	}
+	if expected := []string{"unhealthy-after-updated", "rolled-back"}; !reflect.DeepEqual(result.failedNodes, expected) {
+		t.Fatalf("expected failed nodes %v, got %v", expected, result.failedNodes)
+	}
+	if !result.stopped || len(result.podsToRollback) != 0 {
+		t.Fatalf("expected stopped without pods to roll back, got %v %v", result.stopped, result.podsToRollback)
+	}
+	if nodes := filterNodesByHealth(nodes, result); len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes to update, got %v", len(nodes))
+	}
+
+	// custom conditions and rollback
+	maxFailedNodes := intstr.FromString("50%")
+	ds.Spec.UpdateStrategy.RollingUpdate.NodeHealthCheck = &appsv1beta1.DaemonSetNodeHealthCheck{
+		UnhealthyConditions: []appsv1beta1.DaemonSetNodeCondition{{Type: "KernelDeadlock", Status: corev1.ConditionTrue}},
+		SoakSeconds:         600,
+		Policy:              appsv1beta1.RollbackUnhealthyNodePolicyType,
+		MaxFailedNodes:      &maxFailedNodes,
+	}
+	result, err = checkNodeHealth(ds, nodes, nodeToDaemonPods, "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"custom-condition", "rolled-back"}; !reflect.DeepEqual(result.failedNodes, expected) {
+		t.Fatalf("expected failed nodes %v, got %v", expected, result.failedNodes)
+	}
+	if result.stopped || len(result.podsToRollback) != 1 || result.podsToRollback[0] != nodeToDaemonPods["custom-condition"][0] {
+		t.Fatalf("expected not stopped with pod to roll back, got %v %v", result.stopped, result.podsToRollback)
+	}
+
+	// the stop persists by the condition until the revision changes
+	ds.Status.UpdateRevision = "v2"
+	ds.Status.Conditions = updateRollingUpdateStoppedCondition(nil, true, 3)
+	if len(ds.Status.Conditions) != 1 || ds.Status.Conditions[0].Type != appsv1beta1.DaemonSetConditionRollingUpdateStopped {
+		t.Fatalf("expected RollingUpdateStopped condition, got %v", ds.Status.Conditions)
+	}
+	if conditions := updateRollingUpdateStoppedCondition(ds.Status.Conditions, true, 2); !reflect.DeepEqual(conditions, ds.Status.Conditions) {
+		t.Fatalf("expected condition unchanged, got %v", conditions)
+	}
+	if result, err = checkNodeHealth(ds, nodes, nodeToDaemonPods, "v2"); err != nil || !result.stopped {
+		t.Fatalf("expected stopped by condition, got %v %v", result, err)
+	}
+	if result, err = checkNodeHealth(ds, nodes, nodeToDaemonPods, "v3"); err != nil || result.stopped {
+		t.Fatalf("expected not stopped for new revision, got %v %v", result, err)
+	}
+	if conditions := updateRollingUpdateStoppedCondition(ds.Status.Conditions, false, 0); conditions != nil {
+		t.Fatalf("expected condition removed, got %v", conditions)
+	}
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/openkruise/kruise/pkg/util"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	"github.com/openkruise/kruise/pkg/util/inplaceupdate"
+	"github.com/openkruise/kruise/pkg/util/updatesort"
 )
 
 // rollingUpdate identifies the set of old pods to in-place update, delete, or additional pods to create on nodes,
@@ -72,12 +73,61 @@ func (dsc *ReconcileDaemonSet) rollingUpdate(ctx context.Context, ds *appsv1beta
 		return fmt.Errorf("couldn't get unavailable numbers: %v", err)
 	}
 
-	nodeToDaemonPods, err = dsc.filterDaemonPodsToUpdate(ds, nodeList, hash, nodeToDaemonPods)
+	// Advanced: skip the unhealthy nodes, and stop the rollout if too many nodes failed after updated
+	health, err := checkNodeHealth(ds, nodeList, nodeToDaemonPods, hash)
+	if err != nil {
+		return fmt.Errorf("couldn't check node health: %v", err)
+	}
+	if health != nil {
+		if err := dsc.rollbackPodsInPlace(ds, health.podsToRollback, curRevision, oldRevisions); err != nil {
+			return err
+		}
+		if health.stopped {
+			klog.V(3).InfoS("DaemonSet stopped rolling update for too many failed nodes", "daemonSet", klog.KObj(ds), "failedNodes", health.failedNodes)
+			if !isRollingUpdateStopped(ds, hash) {
+				dsc.eventRecorder.Eventf(ds, corev1.EventTypeWarning, "RollingUpdateStopped",
+					"rolling update stopped for %d nodes unhealthy after updated", len(health.failedNodes))
+			}
+			return nil
+		}
+		nodeList = filterNodesByHealth(nodeList, health)
+	}
+
+	nodeToDaemonPodsToUpdate, err := dsc.filterDaemonPodsToUpdate(ds, nodeList, hash, nodeToDaemonPods)
 	if err != nil {
 		return fmt.Errorf("failed to filterDaemonPodsToUpdate: %v", err)
 	}
 
 	now := dsc.failedPodsBackoff.Clock.Now()
+	nodeNamesToUpdate := sets.StringKeySet(nodeToDaemonPodsToUpdate)
+	wave, err := getCurrentUpdateWave(ds, nodeList, nodeToDaemonPods, nodeNamesToUpdate, hash, now)
+	if err != nil {
+		return fmt.Errorf("couldn't get current update wave: %v", err)
+	}
+	nodeToDaemonPods = nodeToDaemonPodsToUpdate
+
+	// Advanced: only update the pods on the nodes of the current wave
+	if wave != nil {
+		if wave.soakingUntil != nil {
+			klog.V(3).InfoS("DaemonSet was soaking the updated wave", "daemonSet", klog.KObj(ds), "wave", wave.index, "soakingUntil", wave.soakingUntil)
+			durationStore.Push(keyFunc(ds), wave.soakingUntil.Sub(now))
+			return nil
+		}
+		for nodeName := range nodeToDaemonPods {
+			if !wave.nodeNames.Has(nodeName) {
+				delete(nodeToDaemonPods, nodeName)
+			}
+		}
+		if waveMaxUnavailable, ok, err := wave.maxUnavailable(); err != nil {
+			return fmt.Errorf("invalid value for MaxUnavailable of wave %d: %v", wave.index, err)
+		} else if ok {
+			maxUnavailable = waveMaxUnavailable
+			if maxUnavailable == 0 && maxSurge == 0 {
+				maxUnavailable = 1
+			}
+		}
+		klog.V(5).InfoS("DaemonSet updating wave", "daemonSet", klog.KObj(ds), "wave", wave.index, "nodeCount", wave.nodeNames.Len(), "maxUnavailable", maxUnavailable)
+	}
 
 	// When not surging, we delete just enough pods to stay under the maxUnavailable limit, if any
 	// are necessary, and let the core loop create new instances on those nodes.
@@ -95,7 +145,8 @@ func (dsc *ReconcileDaemonSet) rollingUpdate(ctx context.Context, ds *appsv1beta
 		var numUnavailable int
 		var allowedReplacementPods []string
 		var candidatePodsToDelete []string
-		for nodeName, pods := range nodeToDaemonPods {
+		for _, nodeName := range dsc.getNodeNamesInUpdateOrder(ds, nodeToDaemonPods) {
+			pods := nodeToDaemonPods[nodeName]
 			newPod, oldPod, ok := findUpdatedPodsOnNode(ds, pods, hash)
 			if !ok {
 				// let the manage loop clean up this node, and treat it as an unavailable node
@@ -110,7 +161,7 @@ func (dsc *ReconcileDaemonSet) rollingUpdate(ctx context.Context, ds *appsv1beta
 				klog.V(5).InfoS("DaemonSet found no pods (or pre-deleting) on node", "daemonSet", klog.KObj(ds), "nodeName", nodeName)
 			case newPod != nil:
 				// this pod is up to date, check its availability
-				if !podutil.IsPodAvailable(newPod, ds.Spec.MinReadySeconds, metav1.Time{Time: now}) {
+				if !isDaemonPodAvailable(newPod, ds.Spec.MinReadySeconds, metav1.Time{Time: now}) {
 					// an unavailable new pod is counted against maxUnavailable
 					numUnavailable++
 					klog.V(5).InfoS("DaemonSet pod on node was new and unavailable", "daemonSet", klog.KObj(ds), "pod", klog.KObj(newPod), "nodeName", nodeName)
@@ -184,9 +235,19 @@ func (dsc *ReconcileDaemonSet) rollingUpdate(ctx context.Context, ds *appsv1beta
 	var oldPodsToDelete []string
 	var candidateNewNodes []string
 	var allowedNewNodes []string
+	var candidateConflictPods []string
 	var numSurge int
+	// Advanced: the nodes with host port conflict are updated by deleting the old pod first within their own budget
+	var numConflictUnavailable, maxConflictUnavailable int
+	hostPortConflict := getHostPortConflict(ds)
+	if hostPortConflict != nil {
+		if maxConflictUnavailable, err = hostPortConflictUnavailableCount(hostPortConflict, int(ds.Status.DesiredNumberScheduled)); err != nil {
+			return fmt.Errorf("invalid value for hostPortConflict.maxUnavailable: %v", err)
+		}
+	}
 
-	for nodeName, pods := range nodeToDaemonPods {
+	for _, nodeName := range dsc.getNodeNamesInUpdateOrder(ds, nodeToDaemonPods) {
+		pods := nodeToDaemonPods[nodeName]
 		newPod, oldPod, ok := findUpdatedPodsOnNode(ds, pods, hash)
 		if !ok {
 			// let the manage loop clean up this node, and treat it as a surge node
@@ -197,6 +258,22 @@ func (dsc *ReconcileDaemonSet) rollingUpdate(ctx context.Context, ds *appsv1beta
 		switch {
 		case isPodNilOrPreDeleting(oldPod):
 			// we don't need to do anything to this node, the manage loop will handle it
+			if hostPortConflict != nil && isHostPortHandoffInProgress(ds, pods, newPod, now) {
+				// Advanced: the node updated by deleting the old pod first is unavailable until the new pod is available
+				numConflictUnavailable++
+			}
+		case newPod == nil && hostPortConflict != nil && hasHostPortConflict(ds, oldPod):
+			// Advanced: the new pod can not run with the old pod on this node, so delete the old pod first
+			switch {
+			case !podutil.IsPodAvailable(oldPod, ds.Spec.MinReadySeconds, metav1.Time{Time: now}):
+				klog.V(5).InfoS("DaemonSet pod on node was out of date and not available, with host port conflict, removed old pod", "daemonSet", klog.KObj(ds), "pod", klog.KObj(oldPod), "nodeName", nodeName)
+				oldPodsToDelete = append(oldPodsToDelete, oldPod.Name)
+			case numConflictUnavailable >= maxConflictUnavailable:
+				continue
+			default:
+				klog.V(5).InfoS("DaemonSet pod on node was out of date, with host port conflict, it was a candidate to delete", "daemonSet", klog.KObj(ds), "pod", klog.KObj(oldPod), "nodeName", nodeName)
+				candidateConflictPods = append(candidateConflictPods, oldPod.Name)
+			}
 		case newPod == nil:
 			// this is a surge candidate
 			switch {
@@ -248,6 +325,16 @@ func (dsc *ReconcileDaemonSet) rollingUpdate(ctx context.Context, ds *appsv1beta
 	}
 	newNodesToCreate := append(allowedNewNodes, candidateNewNodes[:remainingSurge]...)
 
+	// Advanced: delete the old pods with host port conflict within the rest of hostPortConflict.maxUnavailable
+	remainingConflict := maxConflictUnavailable - numConflictUnavailable
+	if remainingConflict < 0 {
+		remainingConflict = 0
+	}
+	if max := len(candidateConflictPods); remainingConflict > max {
+		remainingConflict = max
+	}
+	oldPodsToDelete = append(oldPodsToDelete, candidateConflictPods[:remainingConflict]...)
+
 	return dsc.syncNodes(ctx, ds, oldPodsToDelete, newNodesToCreate, hash)
 }
 
@@ -377,6 +464,10 @@ func (dsc *ReconcileDaemonSet) filterDaemonPodsNodeToUpdate(ds *appsv1beta1.Daem
 		rest = append(rest, nodeName)
 	}
 
+	// Advanced: the nodes with higher priority are updated first and the lower ones are left by partition
+	selected = dsc.sortNodeNamesByPriority(ds, selected)
+	rest = dsc.sortNodeNamesByPriority(ds, rest)
+
 	sorted := append(updated, updating...)
 	if selector != nil {
 		sorted = append(sorted, selected...)
@@ -391,6 +482,44 @@ func (dsc *ReconcileDaemonSet) filterDaemonPodsNodeToUpdate(ds *appsv1beta1.Daem
 	return sorted, nil
 }
 
+// sortNodeNamesByPriority sorts the node names by the priorityStrategy of rolling update over the node labels.
+func (dsc *ReconcileDaemonSet) sortNodeNamesByPriority(ds *appsv1beta1.DaemonSet, nodeNames []string) []string {
+	if ds.Spec.UpdateStrategy.RollingUpdate == nil || ds.Spec.UpdateStrategy.RollingUpdate.PriorityStrategy == nil {
+		return nodeNames
+	}
+	nodeLabels := make([]map[string]string, len(nodeNames))
+	indexes := make([]int, len(nodeNames))
+	for i, nodeName := range nodeNames {
+		indexes[i] = i
+		// the labels of node not found are regarded as empty
+		if node, err := dsc.nodeLister.Get(nodeName); err == nil {
+			nodeLabels[i] = node.Labels
+		}
+	}
+	indexes = updatesort.SortByLabels(ds.Spec.UpdateStrategy.RollingUpdate.PriorityStrategy, nodeLabels, indexes)
+
+	sorted := make([]string, 0, len(nodeNames))
+	for _, i := range indexes {
+		sorted = append(sorted, nodeNames[i])
+	}
+	return sorted
+}
+
+// getNodeNamesInUpdateOrder returns the node names in the order of priority to pick candidates for rolling update.
+// Without priorityStrategy, the order is random like iterating the map.
+func (dsc *ReconcileDaemonSet) getNodeNamesInUpdateOrder(ds *appsv1beta1.DaemonSet, nodeToDaemonPods map[string][]*corev1.Pod) []string {
+	nodeNames := make([]string, 0, len(nodeToDaemonPods))
+	for nodeName := range nodeToDaemonPods {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	if ds.Spec.UpdateStrategy.RollingUpdate == nil || ds.Spec.UpdateStrategy.RollingUpdate.PriorityStrategy == nil {
+		return nodeNames
+	}
+	// keep the same order as filterDaemonPodsNodeToUpdate for the nodes with the same priority
+	sort.Sort(sort.Reverse(sort.StringSlice(nodeNames)))
+	return dsc.sortNodeNamesByPriority(ds, nodeNames)
+}
+
 func getInPlaceUpdateOptions() *inplaceupdate.UpdateOptions {
 	return &inplaceupdate.UpdateOptions{GetRevision: func(rev *apps.ControllerRevision) string {
 		return rev.Labels[apps.DefaultDaemonSetUniqueLabelKey]
@@ -447,7 +576,13 @@ func (dsc *ReconcileDaemonSet) inPlaceUpdatePods(ds *appsv1beta1.DaemonSet, podN
 					break
 				}
 			}
-			res := dsc.inplaceControl.Update(pod, oldRevision, curRevision, getInPlaceUpdateOptions())
+			opts := getInPlaceUpdateOptions()
+			if oldRevision != nil {
+				opts.AdditionalFuncs = append(opts.AdditionalFuncs, func(pod *corev1.Pod) {
+					pod.Annotations[appsv1beta1.DaemonSetInPlaceUpdatedFromRevisionAnnotation] = oldRevision.Labels[apps.DefaultDaemonSetUniqueLabelKey]
+				})
+			}
+			res := dsc.inplaceControl.Update(pod, oldRevision, curRevision, opts)
 			if res.InPlaceUpdate {
 				if res.UpdateErr == nil {
 					dsc.eventRecorder.Eventf(ds, corev1.EventTypeNormal, "SuccessfulUpdatePodInPlace", "successfully update pod %s in-place", pod.Name)
@@ -479,3 +614,46 @@ func (dsc *ReconcileDaemonSet) inPlaceUpdatePods(ds *appsv1beta1.DaemonSet, podN
 
 	return podsNeedDelete, utilerrors.NewAggregate(errors)
 }
+
+// rollbackPodsInPlace updates the pods back to the revisions they were in-place updated from, and marks them
+// with the revision rolled back from so that they will not be updated again.
+func (dsc *ReconcileDaemonSet) rollbackPodsInPlace(ds *appsv1beta1.DaemonSet, pods []*corev1.Pod, curRevision *apps.ControllerRevision, oldRevisions []*apps.ControllerRevision) error {
+	opts := getInPlaceUpdateOptions()
+	opts.AdditionalFuncs = append(opts.AdditionalFuncs, func(pod *corev1.Pod) {
+		pod.Annotations[appsv1beta1.DaemonSetRolledBackFromRevisionAnnotation] = curRevision.Labels[apps.DefaultDaemonSetUniqueLabelKey]
+		delete(pod.Annotations, appsv1beta1.DaemonSetInPlaceUpdatedFromRevisionAnnotation)
+	})
+
+	var errs []error
+	for _, pod := range pods {
+		prevHash := pod.Annotations[appsv1beta1.DaemonSetInPlaceUpdatedFromRevisionAnnotation]
+		if prevHash == "" {
+			dsc.eventRecorder.Eventf(ds, corev1.EventTypeWarning, "FailedRollbackPodInPlace", "skip rolling back pod %s in-place: it was not updated in-place", pod.Name)
+			continue
+		}
+		var prevRevision *apps.ControllerRevision
+		for _, r := range oldRevisions {
+			if r.Labels[apps.DefaultDaemonSetUniqueLabelKey] == prevHash {
+				prevRevision = r
+				break
+			}
+		}
+		if prevRevision == nil {
+			dsc.eventRecorder.Eventf(ds, corev1.EventTypeWarning, "FailedRollbackPodInPlace", "skip rolling back pod %s in-place: previous revision %s no longer exists", pod.Name, prevHash)
+			continue
+		}
+		if !dsc.inplaceControl.CanUpdateInPlace(curRevision, prevRevision, opts) {
+			dsc.eventRecorder.Eventf(ds, corev1.EventTypeWarning, "FailedRollbackPodInPlace", "skip rolling back pod %s in-place: can not update in-place to revision %s", pod.Name, prevRevision.Name)
+			continue
+		}
+		res := dsc.inplaceControl.Update(pod, curRevision, prevRevision, opts)
+		if res.UpdateErr != nil {
+			dsc.eventRecorder.Eventf(ds, corev1.EventTypeWarning, "FailedRollbackPodInPlace", "failed to roll back pod %s in-place(revision %v): %v", pod.Name, prevRevision.Name, res.UpdateErr)
+			errs = append(errs, res.UpdateErr)
+			continue
+		}
+		dsc.eventRecorder.Eventf(ds, corev1.EventTypeNormal, "SuccessfulRollbackPodInPlace", "successfully roll back pod %s in-place(revision %v) for unhealthy node %s", pod.Name, prevRevision.Name, pod.Spec.NodeName)
+		dsc.resourceVersionExpectations.Expect(&metav1.ObjectMeta{UID: pod.UID, ResourceVersion: res.NewResourceVersion})
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -27,14 +27,18 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	"k8s.io/kubernetes/pkg/controller/daemon/util"
 	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util/inplaceupdate"
 )
 
 func TestDaemonSetUpdatesPods(t *testing.T) {
@@ -78,6 +82,99 @@ func TestDaemonSetUpdatesPods(t *testing.T) {
 	clearExpectations(t, manager, ds, podControl)
 }
 
+func TestDaemonSetUpdatesPodsWithWaves(t *testing.T) {
+	ds := newDaemonSet("foo")
+	manager, podControl, _, err := newTestController(ds)
+	if err != nil {
+		t.Fatalf("error creating DaemonSets controller: %v", err)
+	}
+	addNodes(manager.nodeStore, 0, 2, map[string]string{"pool": "canary"})
+	addNodes(manager.nodeStore, 2, 3, nil)
+	manager.dsStore.Add(ds)
+	expectSyncDaemonSets(t, manager, ds, podControl, 5, 0, 0)
+	markPodsReady(podControl.podStore)
+
+	ds.Spec.Template.Spec.Containers[0].Image = "foo2/bar2"
+	ds.Spec.UpdateStrategy = newUpdateUnavailable(intstr.FromInt(3))
+	ds.Spec.UpdateStrategy.RollingUpdate.Waves = []appsv1beta1.DaemonSetUpdateWave{
+		{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}},
+	}
+	manager.dsStore.Update(ds)
+
+	// only the canary wave is updated at first
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 0, 2, 0)
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 2, 0, 0)
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 0, 0, 0)
+	markPodsReady(podControl.podStore)
+
+	// then the nodes matching no wave
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 0, 3, 0)
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 3, 0, 0)
+	markPodsReady(podControl.podStore)
+
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 0, 0, 0)
+	clearExpectations(t, manager, ds, podControl)
+}
+
+func TestDaemonSetUpdatesPodsWithPriority(t *testing.T) {
+	ds := newDaemonSet("foo")
+	manager, podControl, _, err := newTestController(ds)
+	if err != nil {
+		t.Fatalf("error creating DaemonSets controller: %v", err)
+	}
+	addNodes(manager.nodeStore, 0, 2, map[string]string{"pool": "canary"})
+	addNodes(manager.nodeStore, 2, 3, nil)
+	manager.dsStore.Add(ds)
+	expectSyncDaemonSets(t, manager, ds, podControl, 5, 0, 0)
+	markPodsReady(podControl.podStore)
+
+	ds.Spec.Template.Spec.Containers[0].Image = "foo2/bar2"
+	ds.Spec.UpdateStrategy = newUpdateUnavailable(intstr.FromInt(1))
+	ds.Spec.UpdateStrategy.RollingUpdate.Partition = &intstr.IntOrString{Type: intstr.Int, IntVal: 3}
+	ds.Spec.UpdateStrategy.RollingUpdate.PriorityStrategy = &appspub.UpdatePriorityStrategy{
+		WeightPriority: []appspub.UpdatePriorityWeightTerm{
+			{Weight: 100, MatchSelector: metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}},
+		},
+	}
+	manager.dsStore.Update(ds)
+
+	expectNodesWithoutPod := func(expected ...string) {
+		nodeToDaemonPods, err := manager.getNodesToDaemonPods(context.TODO(), ds)
+		if err != nil {
+			t.Fatalf("failed to get node to daemon pods: %v", err)
+		}
+		for _, nodeName := range expected {
+			if len(nodeToDaemonPods[nodeName]) != 0 {
+				t.Fatalf("expected pod on %s deleted, got %v", nodeName, nodeToDaemonPods)
+			}
+		}
+	}
+
+	// the canary nodes with higher priority are updated one by one, and the others are left by partition
+	for i := 0; i < 2; i++ {
+		clearExpectations(t, manager, ds, podControl)
+		expectSyncDaemonSets(t, manager, ds, podControl, 0, 1, 0)
+		if i == 0 {
+			expectNodesWithoutPod("node-1")
+		} else {
+			expectNodesWithoutPod("node-0")
+		}
+		clearExpectations(t, manager, ds, podControl)
+		expectSyncDaemonSets(t, manager, ds, podControl, 1, 0, 0)
+		markPodsReady(podControl.podStore)
+	}
+
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 0, 0, 0)
+	clearExpectations(t, manager, ds, podControl)
+}
+
 func TestDaemonSetUpdatesPodsWithMaxSurge(t *testing.T) {
 	ds := newDaemonSet("foo")
 	manager, podControl, _, err := newTestController(ds)
@@ -119,6 +216,58 @@ func TestDaemonSetUpdatesPodsWithMaxSurge(t *testing.T) {
 	expectSyncDaemonSets(t, manager, ds, podControl, 0, 0, 0)
 }
 
+func TestDaemonSetUpdatesPodsWithMaxSurgeAndHostPortConflict(t *testing.T) {
+	ds := newDaemonSet("foo")
+	ds.Spec.Template.Spec.HostNetwork = true
+	ds.Spec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: 80}}
+	manager, podControl, _, err := newTestController(ds)
+	if err != nil {
+		t.Fatalf("error creating DaemonSets controller: %v", err)
+	}
+	addNodes(manager.nodeStore, 0, 5, nil)
+	manager.dsStore.Add(ds)
+	expectSyncDaemonSets(t, manager, ds, podControl, 5, 0, 0)
+	markPodsReady(podControl.podStore)
+
+	// the old pods are deleted before the new pods are created, within hostPortConflict.maxUnavailable instead of maxSurge
+	maxConflictUnavailable := 3
+	ds.Spec.Template.Spec.Containers[0].Image = "foo2/bar2"
+	ds.Spec.UpdateStrategy = newUpdateSurge(intstr.FromInt(1))
+	ds.Spec.UpdateStrategy.RollingUpdate.HostPortConflict = &appsv1beta1.DaemonSetHostPortConflict{
+		MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: int32(maxConflictUnavailable)},
+	}
+	manager.dsStore.Update(ds)
+
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 0, maxConflictUnavailable, 0)
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, maxConflictUnavailable, 0, 0)
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 0, 0, 0)
+	markPodsReady(podControl.podStore)
+
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 0, 5%maxConflictUnavailable, 0)
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 5%maxConflictUnavailable, 0, 0)
+	markPodsReady(podControl.podStore)
+
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 0, 0, 0)
+
+	// defaults to update one node with host port conflict at a time
+	ds.Spec.Template.Spec.Containers[0].Image = "foo3/bar3"
+	ds.Spec.UpdateStrategy.RollingUpdate.HostPortConflict = &appsv1beta1.DaemonSetHostPortConflict{}
+	manager.dsStore.Update(ds)
+
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 0, 1, 0)
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 1, 0, 0)
+	clearExpectations(t, manager, ds, podControl)
+	expectSyncDaemonSets(t, manager, ds, podControl, 0, 0, 0)
+}
+
 func TestDaemonSetUpdatesWhenNewPosIsNotReady(t *testing.T) {
 	ds := newDaemonSet("foo")
 	manager, podControl, _, err := newTestController(ds)
@@ -887,3 +1036,197 @@ func TestFilterDaemonPodsNodeToUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCurrentUpdateWave(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ds := newDaemonSet("foo")
+	ds.Spec.UpdateStrategy = newUpdateUnavailable(intstr.FromInt(1))
+	ds.Spec.UpdateStrategy.RollingUpdate.Waves = []appsv1beta1.DaemonSetUpdateWave{
+		{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}, SoakSeconds: 60},
+		{Name: "empty", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "empty"}}},
+		{Name: "prod", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "prod"}}},
+	}
+	nodes := []*corev1.Node{
+		newNode("n1", map[string]string{"pool": "canary"}),
+		newNode("n2", map[string]string{"pool": "prod"}),
+		newNode("n3", map[string]string{"pool": "prod"}),
+		newNode("n4", nil),
+	}
+	newDaemonPod := func(hash string, readySince time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: hash}},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(readySince)},
+			}},
+		}
+	}
+	nodeToDaemonPods := map[string][]*corev1.Pod{}
+	for _, node := range nodes {
+		nodeToDaemonPods[node.Name] = []*corev1.Pod{newDaemonPod("v1", now.Add(-time.Hour))}
+	}
+
+	expectWave := func(expected *appsv1beta1.DaemonSetUpdateWaveStatus, expectedNodes ...string) {
+		t.Helper()
+		wave, err := getCurrentUpdateWave(ds, nodes, nodeToDaemonPods, sets.StringKeySet(nodeToDaemonPods), "v2", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(wave.status(), expected) {
+			t.Fatalf("expected wave %+v, got %+v", expected, wave.status())
+		}
+		if wave != nil && !reflect.DeepEqual(wave.nodeNames.List(), expectedNodes) {
+			t.Fatalf("expected nodes %v, got %v", expectedNodes, wave.nodeNames.List())
+		}
+	}
+
+	// the canary wave is updating
+	expectWave(&appsv1beta1.DaemonSetUpdateWaveStatus{Index: 0, Name: "canary"}, "n1")
+
+	// the canary wave is soaking after updated
+	nodeToDaemonPods["n1"] = []*corev1.Pod{newDaemonPod("v2", now.Add(-10*time.Second))}
+	soakingUntil := metav1.NewTime(now.Add(50 * time.Second))
+	expectWave(&appsv1beta1.DaemonSetUpdateWaveStatus{Index: 0, Name: "canary", SoakingUntil: &soakingUntil}, "n1")
+
+	// the empty wave is skipped after soaking
+	nodeToDaemonPods["n1"] = []*corev1.Pod{newDaemonPod("v2", now.Add(-time.Minute))}
+	expectWave(&appsv1beta1.DaemonSetUpdateWaveStatus{Index: 2, Name: "prod"}, "n2", "n3")
+
+	// the prod wave is not updated until all pods are available
+	nodeToDaemonPods["n2"] = []*corev1.Pod{newDaemonPod("v2", now)}
+	nodeToDaemonPods["n3"] = []*corev1.Pod{newDaemonPod("v2", now)}
+	nodeToDaemonPods["n3"][0].Status.Conditions[0].Status = corev1.ConditionFalse
+	expectWave(&appsv1beta1.DaemonSetUpdateWaveStatus{Index: 2, Name: "prod"}, "n2", "n3")
+
+	// the nodes matching no wave are updated at last
+	nodeToDaemonPods["n3"][0].Status.Conditions[0].Status = corev1.ConditionTrue
+	expectWave(&appsv1beta1.DaemonSetUpdateWaveStatus{Index: 3}, "n4")
+
+	// all the waves are updated
+	nodeToDaemonPods["n4"] = []*corev1.Pod{newDaemonPod("v2", now)}
+	expectWave(nil)
+}
+
+func TestGetCurrentUpdateWaveWithPartition(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ds := newDaemonSet("foo")
+	ds.Spec.UpdateStrategy = newUpdateUnavailable(intstr.FromInt(1))
+	ds.Spec.UpdateStrategy.RollingUpdate.Waves = []appsv1beta1.DaemonSetUpdateWave{
+		{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}},
+		{Name: "prod", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "prod"}}},
+	}
+	nodes := []*corev1.Node{
+		newNode("n1", map[string]string{"pool": "canary"}),
+		newNode("n2", map[string]string{"pool": "prod"}),
+		newNode("n3", map[string]string{"pool": "prod"}),
+		newNode("n4", nil),
+	}
+	newDaemonPod := func(hash string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: hash}},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-time.Hour))},
+			}},
+		}
+	}
+	// n3 is the only node not updated
+	nodeToDaemonPods := map[string][]*corev1.Pod{
+		"n1": {newDaemonPod("v2")},
+		"n2": {newDaemonPod("v2")},
+		"n3": {newDaemonPod("v1")},
+		"n4": {newDaemonPod("v2")},
+	}
+	dsc := &ReconcileDaemonSet{}
+
+	cases := []struct {
+		name      string
+		partition int32
+		expected  *appsv1beta1.DaemonSetUpdateWaveStatus
+	}{
+		{
+			name:     "prod wave is updating without partition",
+			expected: &appsv1beta1.DaemonSetUpdateWaveStatus{Index: 1, Name: "prod"},
+		},
+		{
+			name:      "all the waves are updated except the node left by partition",
+			partition: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ds.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To(intstr.FromInt32(tc.partition))
+			nodeNamesToUpdate, err := dsc.getNodeNamesToUpdate(ds, nodes, "v2", nodeToDaemonPods)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wave, err := getCurrentUpdateWave(ds, nodes, nodeToDaemonPods, nodeNamesToUpdate, "v2", now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(wave.status(), tc.expected) {
+				t.Fatalf("expected wave %+v, got %+v", tc.expected, wave.status())
+			}
+		})
+	}
+}
+
+type fakeInPlaceControl struct {
+	rolledBack map[string]string
+}
+
+func (c *fakeInPlaceControl) CanUpdateInPlace(oldRevision, newRevision *apps.ControllerRevision, opts *inplaceupdate.UpdateOptions) bool {
+	return true
+}
+
+func (c *fakeInPlaceControl) Update(pod *corev1.Pod, oldRevision, newRevision *apps.ControllerRevision, opts *inplaceupdate.UpdateOptions) inplaceupdate.UpdateResult {
+	c.rolledBack[pod.Name] = newRevision.Name
+	return inplaceupdate.UpdateResult{InPlaceUpdate: true}
+}
+
+func (c *fakeInPlaceControl) Refresh(pod *corev1.Pod, opts *inplaceupdate.UpdateOptions) inplaceupdate.RefreshResult {
+	return inplaceupdate.RefreshResult{}
+}
+
+func TestRollbackPodsInPlace(t *testing.T) {
+	ds := newDaemonSet("foo")
+	manager, _, _, err := newTestController(ds)
+	if err != nil {
+		t.Fatalf("error creating DaemonSets controller: %v", err)
+	}
+	inplaceControl := &fakeInPlaceControl{rolledBack: map[string]string{}}
+	manager.inplaceControl = inplaceControl
+
+	newRevision := func(name string, revision int64) *apps.ControllerRevision {
+		return &apps.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-" + name, Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: name}},
+			Revision:   revision,
+		}
+	}
+	curRevision := newRevision("v3", 3)
+	oldRevisions := []*apps.ControllerRevision{newRevision("v1", 1), newRevision("v2", 2)}
+
+	newUpdatedPod := func(name, prevHash string) *corev1.Pod {
+		pod := newPod(name, "node-"+name, simpleDaemonSetLabel, ds)
+		pod.Name = name
+		if prevHash != "" {
+			pod.Annotations = map[string]string{appsv1beta1.DaemonSetInPlaceUpdatedFromRevisionAnnotation: prevHash}
+		}
+		return pod
+	}
+	pods := []*corev1.Pod{
+		newUpdatedPod("from-v1", "v1"),
+		newUpdatedPod("from-v2", "v2"),
+		newUpdatedPod("from-v0", "v0"),
+		newUpdatedPod("recreated", ""),
+	}
+
+	if err := manager.rollbackPodsInPlace(ds, pods, curRevision, oldRevisions); err != nil {
+		t.Fatalf("failed to roll back pods: %v", err)
+	}
+	expected := map[string]string{"from-v1": "foo-v1", "from-v2": "foo-v2"}
+	if !reflect.DeepEqual(inplaceControl.rolledBack, expected) {
+		t.Fatalf("expected rolled back pods %v, got %v", expected, inplaceControl.rolledBack)
+	}
+	if len(manager.fakeRecorder.Events) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(manager.fakeRecorder.Events))
+	}
+}
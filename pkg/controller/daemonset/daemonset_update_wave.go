@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package daemonset
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+// updateWave is the wave of nodes to be updated in rolling update.
+type updateWave struct {
+	index int
+	// wave is nil for the nodes matching no wave, which are updated after all the waves.
+	wave      *appsv1beta1.DaemonSetUpdateWave
+	nodeNames sets.String
+	// soakingUntil is set if all pods in the wave have been updated and available.
+	soakingUntil *time.Time
+}
+
+func getUpdateWaves(ds *appsv1beta1.DaemonSet) []appsv1beta1.DaemonSetUpdateWave {
+	if ds.Spec.UpdateStrategy.Type != appsv1beta1.RollingUpdateDaemonSetStrategyType || ds.Spec.UpdateStrategy.RollingUpdate == nil {
+		return nil
+	}
+	return ds.Spec.UpdateStrategy.RollingUpdate.Waves
+}
+
+// getCurrentUpdateWave returns the first wave which has not been updated or is still soaking,
+// or nil if there is no waves or all the waves have been updated.
+// Only the nodes in nodeNamesToUpdate, which are selected by the partition and selector of rolling update,
+// are required to be updated for a wave to complete. Waves without any of these nodes are skipped.
+func getCurrentUpdateWave(ds *appsv1beta1.DaemonSet, nodeList []*corev1.Node, nodeToDaemonPods map[string][]*corev1.Pod,
+	nodeNamesToUpdate sets.String, hash string, now time.Time) (*updateWave, error) {
+	waves := getUpdateWaves(ds)
+	if len(waves) == 0 {
+		return nil, nil
+	}
+
+	var nodes []*corev1.Node
+	for _, node := range nodeList {
+		if wantToRun, _ := nodeShouldRunDaemonPod(node, ds); wantToRun {
+			nodes = append(nodes, node)
+		}
+	}
+
+	claimed := sets.NewString()
+	for i := 0; i <= len(waves); i++ {
+		w := &updateWave{index: i, nodeNames: sets.NewString()}
+		var selector labels.Selector
+		if i < len(waves) {
+			w.wave = &waves[i]
+			var err error
+			if selector, err = util.ValidatedLabelSelectorAsSelector(w.wave.Selector); err != nil {
+				return nil, fmt.Errorf("invalid selector of wave %s: %v", w.wave.Name, err)
+			}
+		}
+		for _, node := range nodes {
+			if claimed.Has(node.Name) || (selector != nil && !selector.Matches(labels.Set(node.Labels))) {
+				continue
+			}
+			w.nodeNames.Insert(node.Name)
+			claimed.Insert(node.Name)
+		}
+		nodeNamesInWave := w.nodeNames.Intersection(nodeNamesToUpdate)
+		if nodeNamesInWave.Len() == 0 {
+			continue
+		}
+
+		availableAt, updated := getWaveAvailableTime(ds, nodeNamesInWave, nodeToDaemonPods, hash, now)
+		if !updated {
+			return w, nil
+		}
+		if w.wave != nil && w.wave.SoakSeconds > 0 {
+			if soakingUntil := availableAt.Add(time.Duration(w.wave.SoakSeconds) * time.Second); soakingUntil.After(now) {
+				w.soakingUntil = &soakingUntil
+				return w, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// getWaveAvailableTime returns whether all the nodes in the wave have only an available updated pod,
+// and the time when the last of them became available.
+func getWaveAvailableTime(ds *appsv1beta1.DaemonSet, nodeNames sets.String, nodeToDaemonPods map[string][]*corev1.Pod, hash string, now time.Time) (time.Time, bool) {
+	var availableAt time.Time
+	for nodeName := range nodeNames {
+		newPod, oldPod, ok := findUpdatedPodsOnNode(ds, nodeToDaemonPods[nodeName], hash)
+		if !ok || oldPod != nil || isPodNilOrPreDeleting(newPod) ||
+			!isDaemonPodAvailable(newPod, ds.Spec.MinReadySeconds, metav1.Time{Time: now}) {
+			return time.Time{}, false
+		}
+		if c := podutil.GetPodReadyCondition(newPod.Status); c != nil {
+			if t := c.LastTransitionTime.Add(time.Duration(ds.Spec.MinReadySeconds) * time.Second); t.After(availableAt) {
+				availableAt = t
+			}
+		}
+	}
+	return availableAt, true
+}
+
+// getNodeNamesToUpdate returns the nodes selected to update by the partition and selector of rolling update.
+func (dsc *ReconcileDaemonSet) getNodeNamesToUpdate(ds *appsv1beta1.DaemonSet, nodeList []*corev1.Node, hash string, nodeToDaemonPods map[string][]*corev1.Pod) (sets.String, error) {
+	existingNodeToDaemonPods := make(map[string][]*corev1.Pod, len(nodeToDaemonPods))
+	for _, node := range nodeList {
+		if pods, ok := nodeToDaemonPods[node.Name]; ok {
+			existingNodeToDaemonPods[node.Name] = pods
+		}
+	}
+	nodeNames, err := dsc.filterDaemonPodsNodeToUpdate(ds, hash, existingNodeToDaemonPods)
+	if err != nil {
+		return nil, err
+	}
+	return sets.NewString(nodeNames...), nil
+}
+
+// maxUnavailable returns the maxUnavailable of the wave scaled on its nodes, or false if the wave does not set it.
+func (w *updateWave) maxUnavailable() (int, bool, error) {
+	if w.wave == nil || w.wave.MaxUnavailable == nil {
+		return 0, false, nil
+	}
+	maxUnavailable, err := intstrutil.GetScaledValueFromIntOrPercent(w.wave.MaxUnavailable, w.nodeNames.Len(), true)
+	return maxUnavailable, true, err
+}
+
+func (w *updateWave) status() *appsv1beta1.DaemonSetUpdateWaveStatus {
+	if w == nil {
+		return nil
+	}
+	status := &appsv1beta1.DaemonSetUpdateWaveStatus{Index: int32(w.index)}
+	if w.wave != nil {
+		status.Name = w.wave.Name
+	}
+	if w.soakingUntil != nil {
+		soakingUntil := metav1.NewTime(*w.soakingUntil)
+		status.SoakingUntil = &soakingUntil
+	}
+	return status
+}
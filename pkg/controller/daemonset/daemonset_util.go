@@ -193,6 +193,11 @@ func isDaemonPodAvailable(pod *corev1.Pod, minReadySeconds int32, now metav1.Tim
 		if condition != nil && condition.Status != corev1.ConditionTrue {
 			return false
 		}
+		// the pod keeps ready during in-place resource resizing which needs no restart,
+		// so it should not be available until the resources in status have been updated.
+		if err := inplaceupdate.DefaultCheckInPlaceUpdateCompleted(pod); err != nil {
+			return false
+		}
 	}
 
 	return true
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -32,7 +33,10 @@ import (
 	"k8s.io/kubernetes/pkg/securitycontext"
 	labelsutil "k8s.io/kubernetes/pkg/util/labels"
 
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 )
 
 func Test_nodeInSameCondition(t *testing.T) {
@@ -724,3 +728,38 @@ func newStandardRollingUpdateStrategy(matchLabels map[string]string) appsv1beta1
 	}
 	return strategy
 }
+
+func TestIsDaemonPodAvailableWithInPlaceResize(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, features.InPlaceWorkloadVerticalScaling, true)()
+
+	ds := newDaemonSet("foo")
+	pod := newPod("foo-", "node-0", simpleDaemonSetLabel, ds)
+	pod.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: appspub.InPlaceUpdateReady}}
+	pod.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+	}
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+		{Type: appspub.InPlaceUpdateReady, Status: corev1.ConditionTrue},
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:      pod.Spec.Containers[0].Name,
+		Ready:     true,
+		Resources: &corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}},
+	}}
+	pod.Annotations = map[string]string{appspub.InPlaceUpdateStateKey: `{"revision":"new","updateResources":true}`}
+
+	if isDaemonPodAvailable(pod, 0, metav1.Now()) {
+		t.Fatalf("expected pod unavailable before resources resized")
+	}
+
+	pod.Status.ContainerStatuses[0].Resources.Requests[corev1.ResourceCPU] = resource.MustParse("200m")
+	if !isDaemonPodAvailable(pod, 0, metav1.Now()) {
+		t.Fatalf("expected pod available after resources resized")
+	}
+
+	pod.Annotations[appspub.InPlaceUpdateStateKey] = `{"revision":"new","updateResources":true,"nextContainerResources":{"sidecar":{}}}`
+	if isDaemonPodAvailable(pod, 0, metav1.Now()) {
+		t.Fatalf("expected pod unavailable with containers to resize in next batches")
+	}
+}
@@ -18,13 +18,17 @@ package sidecarset
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
 	"github.com/openkruise/kruise/pkg/control/sidecarcontrol"
@@ -79,18 +83,20 @@ func flipPodSidecarContainerDo(control sidecarcontrol.SidecarControl, pod *corev
 	for _, sidecarContainer := range sidecarSet.Spec.Containers {
 		if sidecarcontrol.IsHotUpgradeContainer(&sidecarContainer) {
 			workContainer, emptyContainer := sidecarcontrol.GetPodHotUpgradeContainers(sidecarContainer.Name, pod)
-			if containersInPod[emptyContainer].Image == sidecarContainer.UpgradeStrategy.HotUpgradeEmptyImage {
+			emptyImage := sidecarcontrol.GetHotUpgradeEmptyImage(&sidecarContainer, pod)
+			if containersInPod[emptyContainer].Image == emptyImage {
 				continue
 			}
 			// flip the empty sidecar container image
 			containerNeedFlip := containersInPod[emptyContainer]
 			klog.V(3).InfoS("Tried to reset container's image to empty", "pod", klog.KObj(pod), "containerName", containerNeedFlip.Name,
-				"imageName", containerNeedFlip.Image, "hotUpgradeEmptyImageName", sidecarContainer.UpgradeStrategy.HotUpgradeEmptyImage)
-			containerNeedFlip.Image = sidecarContainer.UpgradeStrategy.HotUpgradeEmptyImage
+				"imageName", containerNeedFlip.Image, "hotUpgradeEmptyImageName", emptyImage)
+			containerNeedFlip.Image = emptyImage
 			changedContainer = append(changedContainer, containerNeedFlip.Name)
 			// update pod sidecarSet version annotations
 			pod.Annotations[sidecarcontrol.GetPodSidecarSetVersionAnnotation(containerNeedFlip.Name)] = "0"
 			pod.Annotations[sidecarcontrol.GetPodSidecarSetVersionAltAnnotation(workContainer)] = "0"
+			delete(pod.Annotations, sidecarcontrol.GetPodHandoffProbeTimeAnnotation(sidecarContainer.Name))
 		}
 	}
 	// record the updated container status, to determine if the update is complete
@@ -144,10 +150,98 @@ func isPodSidecarInHotUpgrading(sidecarSet *appsv1beta1.SidecarSet, pod *corev1.
 	for _, sidecar := range sidecarSet.Spec.Containers {
 		if sidecarcontrol.IsHotUpgradeContainer(&sidecar) {
 			_, emptyContainer := sidecarcontrol.GetPodHotUpgradeContainers(sidecar.Name, pod)
-			if containerImage[emptyContainer] != sidecar.UpgradeStrategy.HotUpgradeEmptyImage {
+			if containerImage[emptyContainer] != sidecarcontrol.GetHotUpgradeEmptyImage(&sidecar, pod) {
 				return true
 			}
 		}
 	}
 	return false
 }
+
+// maxConcurrentHandoffProbes is the maximum number of pods to run the handoff probes on at the same time in a reconcile.
+const maxConcurrentHandoffProbes = 16
+
+// defaultHandoffProbePeriod is the default interval between the handoff probes on each pod.
+const defaultHandoffProbePeriod = 5 * time.Second
+
+// filterHotUpgradeHandoffSucceededPods runs the handoff probes on the pods concurrently, and returns the pods on which
+// the handoff probes succeed, and the duration after which to probe the others again.
+// The time of probes failed is recorded in the pod annotations, so that each pod is probed at most once in periodSeconds.
+func (p *Processor) filterHotUpgradeHandoffSucceededPods(sidecarSet *appsv1beta1.SidecarSet, pods []*corev1.Pod) ([]*corev1.Pod, time.Duration) {
+	now := time.Now()
+	succeeded := make([]bool, len(pods))
+	requeueAfters := make([]time.Duration, len(pods))
+	workqueue.ParallelizeUntil(context.TODO(), maxConcurrentHandoffProbes, len(pods), func(i int) {
+		var probed []string
+		succeeded[i], requeueAfters[i], probed = isHotUpgradeHandoffSucceeded(sidecarSet, pods[i], now)
+		if len(probed) > 0 {
+			if err := p.recordHandoffProbeTime(pods[i], probed, now); err != nil {
+				klog.ErrorS(err, "Failed to record handoff probe time in pod", "sidecarSet", klog.KObj(sidecarSet), "pod", klog.KObj(pods[i]))
+			}
+		}
+	})
+
+	var succeededPods []*corev1.Pod
+	var requeueAfter time.Duration
+	for i, pod := range pods {
+		if succeeded[i] {
+			succeededPods = append(succeededPods, pod)
+		} else if requeueAfter == 0 || requeueAfters[i] < requeueAfter {
+			requeueAfter = requeueAfters[i]
+		}
+	}
+	return succeededPods, requeueAfter
+}
+
+// isHotUpgradeHandoffSucceeded returns whether the handoff probes succeed on the pod for all the hot upgrading containers,
+// otherwise the duration after which to probe again, and the sidecar containers probed failed this time.
+// The older sidecar container is kept working until then.
+func isHotUpgradeHandoffSucceeded(sidecarSet *appsv1beta1.SidecarSet, pod *corev1.Pod, now time.Time) (bool, time.Duration, []string) {
+	var requeueAfter time.Duration
+	var probed []string
+	for i := range sidecarSet.Spec.Containers {
+		sidecar := &sidecarSet.Spec.Containers[i]
+		if !sidecarcontrol.IsHotUpgradeWithHandoff(sidecar) {
+			continue
+		}
+		workContainer, emptyContainer := sidecarcontrol.GetPodHotUpgradeContainers(sidecar.Name, pod)
+		if c := util.GetContainer(emptyContainer, pod); c == nil || c.Image == sidecarcontrol.GetHotUpgradeEmptyImage(sidecar, pod) {
+			continue
+		}
+		probe := sidecar.UpgradeStrategy.HandoffProbe
+		period := time.Duration(probe.PeriodSeconds) * time.Second
+		if period <= 0 {
+			period = defaultHandoffProbePeriod
+		}
+		wait := period
+		if lastProbeTime, err := time.Parse(time.RFC3339, pod.Annotations[sidecarcontrol.GetPodHandoffProbeTimeAnnotation(sidecar.Name)]); err == nil && now.Before(lastProbeTime.Add(period)) {
+			// the last probe failed within periodSeconds
+			wait = lastProbeTime.Add(period).Sub(now)
+		} else if succeeded, msg := util.CallPodHTTPGetAction(&probe.HTTPGet, pod, time.Duration(probe.TimeoutSeconds)*time.Second); succeeded {
+			continue
+		} else {
+			klog.V(3).InfoS("Sidecar container hot upgrade handoff was not finished", "sidecarSet", klog.KObj(sidecarSet), "pod", klog.KObj(pod),
+				"containerName", workContainer, "message", msg)
+			probed = append(probed, sidecar.Name)
+		}
+		if requeueAfter == 0 || wait < requeueAfter {
+			requeueAfter = wait
+		}
+	}
+	return requeueAfter == 0, requeueAfter, probed
+}
+
+// recordHandoffProbeTime records the time of the handoff probes on the sidecar containers in pod annotations.
+func (p *Processor) recordHandoffProbeTime(pod *corev1.Pod, sidecarNames []string, now time.Time) error {
+	annotations := make(map[string]string, len(sidecarNames))
+	for _, name := range sidecarNames {
+		annotations[sidecarcontrol.GetPodHandoffProbeTimeAnnotation(name)] = now.Format(time.RFC3339)
+	}
+	body, _ := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"annotations": annotations}})
+	podClone := pod.DeepCopy()
+	if err := p.Client.Patch(context.TODO(), podClone, client.RawPatch(types.MergePatchType, body)); err != nil {
+		return err
+	}
+	sidecarcontrol.ResourceVersionExpectations.Expect(podClone)
+	return nil
+}
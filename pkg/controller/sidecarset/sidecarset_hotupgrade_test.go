@@ -18,10 +18,17 @@ package sidecarset
 
 import (
 	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -295,3 +302,95 @@ func testUpdateHotUpgradeSidecar(t *testing.T, hotUpgradeEmptyImage string, side
 		})
 	}
 }
+
+func TestUpdateHotUpgradeSidecarWithHandoff(t *testing.T) {
+	var handoff atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/handoff" && handoff.Load() {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	host, portStr, _ := net.SplitHostPort(server.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	sidecarSet := sidecarSetHotUpgrade.DeepCopy()
+	sidecarSet.Spec.Containers[0].UpgradeStrategy = appsv1beta1.SidecarContainerUpgradeStrategy{
+		UpgradeType: appsv1beta1.SidecarContainerHotUpgrade,
+		HandoffProbe: &appsv1beta1.SidecarContainerHandoffProbe{
+			HTTPGet:       corev1.HTTPGetAction{Path: "/handoff", Port: intstr.FromInt32(int32(port))},
+			PeriodSeconds: 3,
+		},
+	}
+	// the standby container has the same image as the working one
+	pod := podHotUpgrade.DeepCopy()
+	pod.Name = "handoff-test"
+	pod.Annotations[sidecarcontrol.GetPodSidecarSetVersionAnnotation("test-sidecar-2")] = "0"
+	pod.Spec.Containers[2].Image = "test-image:v1"
+	pod.Status.ContainerStatuses[2].Image = "test-image:v1"
+	pod.Status.ContainerStatuses[2].ImageID = testImageV1ImageID
+	pod.Status.PodIP = host
+	defer sidecarcontrol.UpdateExpectations.DeleteExpectations(sidecarSet.Name)
+	defer sidecarcontrol.ResourceVersionExpectations.Delete(pod)
+
+	update := func(expectedImages map[string]string) time.Duration {
+		// the fake client is rebuilt with the latest pod, as the informer cache has observed the updates
+		sidecarcontrol.ResourceVersionExpectations.Delete(pod)
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sidecarSet, pod).
+			WithStatusSubresource(&appsv1beta1.SidecarSet{}).Build()
+		processor := NewSidecarSetProcessor(fakeClient, record.NewFakeRecorder(10))
+		result, err := processor.UpdateSidecarSet(sidecarSet)
+		if err != nil {
+			t.Fatalf("processor update sidecarset failed: %s", err.Error())
+		}
+		if pod, err = getLatestPod(fakeClient, pod); err != nil {
+			t.Fatalf("get latest pod failed: %s", err.Error())
+		}
+		if sidecarSet, err = getLatestSidecarSet(fakeClient, sidecarSet); err != nil {
+			t.Fatalf("get latest sidecarset failed: %s", err.Error())
+		}
+		pod.ResourceVersion = ""
+		sidecarSet.ResourceVersion = ""
+		for cName, image := range expectedImages {
+			if c := util.GetPodContainerByName(cName, pod); c.Image != image {
+				t.Fatalf("expect container(%s) image(%s), but get image(%s)", cName, image, c.Image)
+			}
+		}
+		return result.RequeueAfter
+	}
+
+	// upgrade the standby container
+	update(map[string]string{"test-sidecar-1": "test-image:v1", "test-sidecar-2": "test-image:v2"})
+	if working, _ := sidecarcontrol.GetPodHotUpgradeContainers("test-sidecar", pod); working != "test-sidecar-2" {
+		t.Fatalf("expect working container test-sidecar-2, but get %s", working)
+	}
+	pod.Status.ContainerStatuses[2].Image = "test-image:v2"
+	pod.Status.ContainerStatuses[2].ImageID = testImageV2ImageID
+
+	// the older container keeps working until the handoff probe succeeds
+	if requeueAfter := update(map[string]string{"test-sidecar-1": "test-image:v1"}); requeueAfter != 3*time.Second {
+		t.Fatalf("expect requeue after the handoff probe period, but get %v", requeueAfter)
+	}
+	probeTimeKey := sidecarcontrol.GetPodHandoffProbeTimeAnnotation("test-sidecar")
+	if _, err := time.Parse(time.RFC3339, pod.Annotations[probeTimeKey]); err != nil {
+		t.Fatalf("expect handoff probe time recorded, but get %q", pod.Annotations[probeTimeKey])
+	}
+
+	// the pod is not probed again within the handoff probe period
+	handoff.Store(true)
+	if requeueAfter := update(map[string]string{"test-sidecar-1": "test-image:v1"}); requeueAfter <= 0 || requeueAfter > 3*time.Second {
+		t.Fatalf("expect requeue within the handoff probe period, but get %v", requeueAfter)
+	}
+
+	// reset the older container to the standby with the latest image
+	pod.Annotations[probeTimeKey] = time.Now().Add(-time.Minute).Format(time.RFC3339)
+	update(map[string]string{"test-sidecar-1": "test-image:v2", "test-sidecar-2": "test-image:v2"})
+	if version := pod.Annotations[sidecarcontrol.GetPodSidecarSetVersionAnnotation("test-sidecar-1")]; version != "0" {
+		t.Fatalf("expect standby container version 0, but get %s", version)
+	}
+	if _, ok := pod.Annotations[probeTimeKey]; ok {
+		t.Fatalf("expect handoff probe time removed after handoff")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -121,6 +122,8 @@ func (p *Processor) UpdateSidecarSet(sidecarSet *appsv1beta1.SidecarSet) (reconc
 	}
 
 	// 5. If sidecar container hot upgrade complete, then set the other one(empty sidecar container) image to HotUpgradeEmptyImage
+	// handoffRequeueAfter is set if some pods are waiting for the handoff probes
+	var handoffRequeueAfter time.Duration
 	if isSidecarSetHasHotUpgradeContainer(sidecarSet) {
 		var podsInHotUpgrading []*corev1.Pod
 		for _, pod := range pods {
@@ -128,6 +131,7 @@ func (p *Processor) UpdateSidecarSet(sidecarSet *appsv1beta1.SidecarSet) (reconc
 			// 1. the empty sidecar container image isn't equal HotUpgradeEmptyImage
 			// 2. all containers with exception of empty sidecar containers is updated and consistent
 			// 3. all containers with exception of empty sidecar containers is ready
+			// 4. the new sidecar containers have taken over the work, if the handoff probes are set
 
 			// don't contain sidecar empty containers
 			sidecarContainers := sidecarcontrol.GetSidecarContainersInPod(sidecarSet)
@@ -142,25 +146,26 @@ func (p *Processor) UpdateSidecarSet(sidecarSet *appsv1beta1.SidecarSet) (reconc
 				podsInHotUpgrading = append(podsInHotUpgrading, pod)
 			}
 		}
+		podsInHotUpgrading, handoffRequeueAfter = p.filterHotUpgradeHandoffSucceededPods(sidecarSet, podsInHotUpgrading)
 		if len(podsInHotUpgrading) > 0 {
 			if err := p.flipHotUpgradingContainers(control, podsInHotUpgrading); err != nil {
 				return reconcile.Result{}, err
 			}
-			return reconcile.Result{}, nil
+			return reconcile.Result{RequeueAfter: handoffRequeueAfter}, nil
 		}
 	}
 
 	// 6. sidecarset already updates all matched pods, then return
 	if isSidecarSetUpdateFinish(status) {
 		klog.V(3).InfoS("SidecarSet matched pods were latest, and don't need update", "sidecarSet", klog.KObj(sidecarSet), "matchedPodCount", len(pods))
-		return reconcile.Result{}, nil
+		return reconcile.Result{RequeueAfter: handoffRequeueAfter}, nil
 	}
 
 	// 7. upgrade pod sidecar
 	if err := p.updatePods(control, pods); err != nil {
 		return reconcile.Result{}, err
 	}
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: handoffRequeueAfter}, nil
 }
 
 func (p *Processor) updatePods(control sidecarcontrol.SidecarControl, pods []*corev1.Pod) error {
@@ -523,32 +528,68 @@ func replaceRevision(revisions []*apps.ControllerRevision, oldOne, newOne *apps.
 // ReadyPods: ready pods number
 // UpdatedReadyPods: updated and ready pods number
 // UnavailablePods: MatchedPods - UpdatedReadyPods
+// NamespaceStatuses: the numbers above in each namespace of the namespace waves and in the other namespaces, only if the namespace waves are set
 func calculateStatus(control sidecarcontrol.SidecarControl, pods []*corev1.Pod, latestRevision *apps.ControllerRevision, collisionCount int32,
 ) *appsv1beta1.SidecarSetStatus {
 	sidecarset := control.GetSidecarset()
-	var matchedPods, updatedPods, readyPods, updatedAndReady int32
-	matchedPods = int32(len(pods))
+	namespaceStatuses := make(map[string]*appsv1beta1.SidecarSetNamespaceStatus)
 	for _, pod := range pods {
+		nsStatus, ok := namespaceStatuses[pod.Namespace]
+		if !ok {
+			nsStatus = &appsv1beta1.SidecarSetNamespaceStatus{Namespace: pod.Namespace}
+			namespaceStatuses[pod.Namespace] = nsStatus
+		}
+		nsStatus.MatchedPods++
 		updated := sidecarcontrol.IsPodSidecarUpdated(sidecarset, pod)
 		if updated {
-			updatedPods++
+			nsStatus.UpdatedPods++
 		}
 		if control.IsPodStateConsistent(pod, nil) && control.IsPodReady(pod) {
-			readyPods++
+			nsStatus.ReadyPods++
 			if updated {
-				updatedAndReady++
+				nsStatus.UpdatedReadyPods++
 			}
 		}
 	}
-	return &appsv1beta1.SidecarSetStatus{
+	status := &appsv1beta1.SidecarSetStatus{
 		ObservedGeneration: sidecarset.Generation,
-		MatchedPods:        matchedPods,
-		UpdatedPods:        updatedPods,
-		ReadyPods:          readyPods,
-		UpdatedReadyPods:   updatedAndReady,
 		LatestRevision:     latestRevision.Name,
 		CollisionCount:     pointer.Int32Ptr(collisionCount),
 	}
+	for _, nsStatus := range namespaceStatuses {
+		status.MatchedPods += nsStatus.MatchedPods
+		status.UpdatedPods += nsStatus.UpdatedPods
+		status.ReadyPods += nsStatus.ReadyPods
+		status.UpdatedReadyPods += nsStatus.UpdatedReadyPods
+	}
+	if len(sidecarset.Spec.UpdateStrategy.NamespaceWaves) == 0 {
+		return status
+	}
+
+	// only the namespaces in waves are recorded one by one, so that the size of status is bounded
+	waveNamespaces := sets.NewString()
+	for _, wave := range sidecarset.Spec.UpdateStrategy.NamespaceWaves {
+		waveNamespaces.Insert(wave.Namespaces...)
+	}
+	for _, ns := range waveNamespaces.List() {
+		nsStatus := appsv1beta1.SidecarSetNamespaceStatus{Namespace: ns}
+		if s, ok := namespaceStatuses[ns]; ok {
+			nsStatus = *s
+		}
+		status.NamespaceStatuses = append(status.NamespaceStatuses, nsStatus)
+	}
+	others := appsv1beta1.SidecarSetNamespaceStatus{Namespace: appsv1beta1.SidecarSetOtherNamespaces}
+	for ns, nsStatus := range namespaceStatuses {
+		if waveNamespaces.Has(ns) {
+			continue
+		}
+		others.MatchedPods += nsStatus.MatchedPods
+		others.UpdatedPods += nsStatus.UpdatedPods
+		others.ReadyPods += nsStatus.ReadyPods
+		others.UpdatedReadyPods += nsStatus.UpdatedReadyPods
+	}
+	status.NamespaceStatuses = append(status.NamespaceStatuses, others)
+	return status
 }
 
 func isSidecarSetNotUpdate(s *appsv1beta1.SidecarSet) bool {
@@ -566,6 +607,13 @@ func updateContainerInPod(container corev1.Container, pod *corev1.Pod) {
 			return
 		}
 	}
+	// the sidecar container may be injected as native sidecar
+	for i := range pod.Spec.InitContainers {
+		if pod.Spec.InitContainers[i].Name == container.Name {
+			pod.Spec.InitContainers[i] = container
+			return
+		}
+	}
 }
 
 func updatePodSidecarContainer(control sidecarcontrol.SidecarControl, pod *corev1.Pod) {
@@ -573,7 +621,7 @@ func updatePodSidecarContainer(control sidecarcontrol.SidecarControl, pod *corev
 
 	// upgrade sidecar containers
 	var changedContainers []string
-	for _, sidecarContainer := range sidecarSet.Spec.Containers {
+	for _, sidecarContainer := range sidecarcontrol.GetUpgradableSidecarContainers(sidecarSet) {
 		// sidecarContainer := &sidecarset.Spec.Containers[i]
 		// volumeMounts that injected into sidecar container
 		// when volumeMounts SubPathExpr contains expansions, then need copy container EnvVars(injectEnvs)
@@ -638,7 +686,8 @@ func inconsistentStatus(sidecarSet *appsv1beta1.SidecarSet, status *appsv1beta1.
 		status.ReadyPods != sidecarSet.Status.ReadyPods ||
 		status.UpdatedReadyPods != sidecarSet.Status.UpdatedReadyPods ||
 		status.LatestRevision != sidecarSet.Status.LatestRevision ||
-		!pointer.Int32Equal(sidecarSet.Status.CollisionCount, status.CollisionCount)
+		!pointer.Int32Equal(sidecarSet.Status.CollisionCount, status.CollisionCount) ||
+		!reflect.DeepEqual(sidecarSet.Status.NamespaceStatuses, status.NamespaceStatuses)
 }
 
 func isSidecarSetUpdateFinish(status *appsv1beta1.SidecarSetStatus) bool {
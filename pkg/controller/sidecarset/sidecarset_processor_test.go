@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strconv"
 	"testing"
 
@@ -27,8 +28,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/kubernetes/pkg/controller/history"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
@@ -83,6 +86,49 @@ func TestUpdatePodSidecarAndHashPodNotFound(t *testing.T) {
 	}
 }
 
+func TestUpdateNativeSidecar(t *testing.T) {
+	sidecarSet := sidecarSetDemo.DeepCopy()
+	sidecarSet.Spec.InjectionStrategy.NativeSidecar = true
+	pod := podDemo.DeepCopy()
+	// the sidecar container is injected as native sidecar
+	sidecar := pod.Spec.Containers[1]
+	sidecar.RestartPolicy = ptr.To(corev1.ContainerRestartPolicyAlways)
+	pod.Spec.InitContainers = []corev1.Container{sidecar}
+	pod.Spec.Containers = pod.Spec.Containers[:1]
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{pod.Status.ContainerStatuses[1]}
+	pod.Status.ContainerStatuses = pod.Status.ContainerStatuses[:1]
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sidecarSet, pod).
+		WithStatusSubresource(&appsv1beta1.SidecarSet{}).Build()
+	processor := NewSidecarSetProcessor(fakeClient, record.NewFakeRecorder(10))
+	defer sidecarcontrol.UpdateExpectations.DeleteExpectations(sidecarSet.Name)
+	if _, err := processor.UpdateSidecarSet(sidecarSet); err != nil {
+		t.Fatalf("processor update sidecarset failed: %s", err.Error())
+	}
+	podOutput, err := getLatestPod(fakeClient, pod)
+	if err != nil {
+		t.Fatalf("get latest pod(%s) failed: %s", pod.Name, err.Error())
+	}
+	if len(podOutput.Spec.Containers) != 1 || len(podOutput.Spec.InitContainers) != 1 {
+		t.Fatalf("expect native sidecar upgraded in initContainers, but get %v", podOutput.Spec)
+	}
+	initContainer := podOutput.Spec.InitContainers[0]
+	if initContainer.Image != "test-image:v2" || initContainer.RestartPolicy == nil || *initContainer.RestartPolicy != corev1.ContainerRestartPolicyAlways {
+		t.Fatalf("expect native sidecar image(test-image:v2), but get %v", initContainer)
+	}
+
+	// the upgrade is not completed until the initContainer status is updated
+	control := sidecarcontrol.New(sidecarSet)
+	if control.IsPodStateConsistent(podOutput, sets.NewString("test-sidecar")) {
+		t.Fatalf("expect pod state inconsistent before the native sidecar is restarted")
+	}
+	podOutput.Status.InitContainerStatuses[0].Image = "test-image:v2"
+	podOutput.Status.InitContainerStatuses[0].ImageID = testImageV2ImageID
+	if !control.IsPodStateConsistent(podOutput, sets.NewString("test-sidecar")) {
+		t.Fatalf("expect pod state consistent after the native sidecar is restarted")
+	}
+}
+
 func testUpdateColdUpgradeSidecar(t *testing.T, podDemo *corev1.Pod, sidecarSetInput *appsv1beta1.SidecarSet, handlers map[string]HandlePod) {
 	podInput1 := podDemo.DeepCopy()
 	podInput2 := podDemo.DeepCopy()
@@ -297,6 +343,39 @@ func TestScopeNamespacePods(t *testing.T) {
 	}
 }
 
+func TestCalculateNamespaceStatuses(t *testing.T) {
+	sidecarSet := factorySidecarSet()
+	// pod-0~2 are updated, pod-0~1 are updated and ready
+	pods := factoryPods(6, 3, 2)
+	for i, pod := range pods {
+		pod.Namespace = "ns-b"
+		if i%2 == 0 {
+			pod.Namespace = "ns-a"
+		}
+	}
+	latestRevision := &apps.ControllerRevision{ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset-revision"}}
+
+	status := calculateStatus(sidecarcontrol.New(sidecarSet), pods, latestRevision, 0)
+	if status.NamespaceStatuses != nil {
+		t.Fatalf("expect no namespace statuses without namespace waves, but got %v", status.NamespaceStatuses)
+	}
+
+	// the namespaces not in waves are aggregated
+	sidecarSet.Spec.UpdateStrategy.NamespaceWaves = []appsv1beta1.SidecarSetNamespaceWave{{Namespaces: []string{"ns-c"}}, {Namespaces: []string{"ns-a"}}}
+	status = calculateStatus(sidecarcontrol.New(sidecarSet), pods, latestRevision, 0)
+	expect := []appsv1beta1.SidecarSetNamespaceStatus{
+		{Namespace: "ns-a", MatchedPods: 3, UpdatedPods: 2, ReadyPods: 2, UpdatedReadyPods: 1},
+		{Namespace: "ns-c"},
+		{Namespace: appsv1beta1.SidecarSetOtherNamespaces, MatchedPods: 3, UpdatedPods: 1, ReadyPods: 3, UpdatedReadyPods: 1},
+	}
+	if !reflect.DeepEqual(expect, status.NamespaceStatuses) {
+		t.Fatalf("expect namespace statuses %v, but got %v", expect, status.NamespaceStatuses)
+	}
+	if status.MatchedPods != 6 || status.UpdatedPods != 3 || status.ReadyPods != 5 || status.UpdatedReadyPods != 2 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+}
+
 func TestCanUpgradePods(t *testing.T) {
 	sidecarSet := factorySidecarSet()
 	sidecarSet.Annotations[sidecarcontrol.SidecarSetHashWithoutImageAnnotation] = "without-bbb"
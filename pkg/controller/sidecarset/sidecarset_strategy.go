@@ -37,11 +37,70 @@ func NewStrategy() Strategy {
 }
 
 func (p *spreadingStrategy) GetNextUpgradePods(control sidecarcontrol.SidecarControl, pods []*corev1.Pod) (upgradePods []*corev1.Pod, notUpgradablePods []*corev1.Pod) {
+	sidecarset := control.GetSidecarset()
+	strategy := sidecarset.Spec.UpdateStrategy
+	if len(strategy.NamespaceWaves) == 0 {
+		upgradePods, notUpgradablePods, _ = getNextUpgradePods(control, pods, strategy.Partition, strategy.MaxUnavailable)
+		return
+	}
+
+	// update the waves in order, the pods in namespaces not listed in any wave are updated in the last wave
+	waves := make([]appsv1beta1.SidecarSetNamespaceWave, 0, len(strategy.NamespaceWaves)+1)
+	waves = append(waves, strategy.NamespaceWaves...)
+	waves = append(waves, appsv1beta1.SidecarSetNamespaceWave{Partition: strategy.Partition, MaxUnavailable: strategy.MaxUnavailable})
+	wavePods := groupPodsByNamespaceWave(strategy.NamespaceWaves, pods)
+	for i, wave := range waves {
+		if wave.Paused {
+			klog.V(3).InfoS("SidecarSet namespace wave was paused", "sidecarSet", klog.KObj(sidecarset), "wave", i)
+			return
+		}
+		maxUnavailable := wave.MaxUnavailable
+		if maxUnavailable == nil {
+			maxUnavailable = strategy.MaxUnavailable
+		}
+		waveUpgradePods, waveNotUpgradablePods, completed := getNextUpgradePods(control, wavePods[i], wave.Partition, maxUnavailable)
+		notUpgradablePods = append(notUpgradablePods, waveNotUpgradablePods...)
+		if !completed {
+			klog.V(3).InfoS("SidecarSet is updating namespace wave", "sidecarSet", klog.KObj(sidecarset), "wave", i, "pods", len(wavePods[i]))
+			return waveUpgradePods, notUpgradablePods
+		}
+	}
+	return
+}
+
+// groupPodsByNamespaceWave groups the pods by the waves of their namespaces,
+// the pods in namespaces not listed in any wave are grouped into the last one.
+func groupPodsByNamespaceWave(waves []appsv1beta1.SidecarSetNamespaceWave, pods []*corev1.Pod) [][]*corev1.Pod {
+	waveIndexes := make(map[string]int)
+	for i, wave := range waves {
+		for _, ns := range wave.Namespaces {
+			if _, ok := waveIndexes[ns]; !ok {
+				waveIndexes[ns] = i
+			}
+		}
+	}
+	wavePods := make([][]*corev1.Pod, len(waves)+1)
+	for _, pod := range pods {
+		i, ok := waveIndexes[pod.Namespace]
+		if !ok {
+			i = len(waves)
+		}
+		wavePods[i] = append(wavePods[i], pod)
+	}
+	return wavePods
+}
+
+// getNextUpgradePods selects the pods to be upgraded with the partition and maxUnavailable,
+// and returns whether the pods have been updated and ready, except for the ones kept in old revisions by the partition.
+func getNextUpgradePods(control sidecarcontrol.SidecarControl, pods []*corev1.Pod, partition, maxUnavailable *intstrutil.IntOrString) (
+	upgradePods []*corev1.Pod, notUpgradablePods []*corev1.Pod, completed bool) {
 	sidecarset := control.GetSidecarset()
 	// wait to upgrade pod index
 	var waitUpgradedIndexes []int
 	// because SidecarSet in-place update only support upgrading Image, if other fields are changed they will not be upgraded.
 	var notUpgradableIndexes []int
+	// the pods that can be upgraded after their spec and status are consistent
+	var inconsistentCount int
 	strategy := sidecarset.Spec.UpdateStrategy
 
 	// If selector is not nil, check whether the pods is selected to upgrade
@@ -78,6 +137,8 @@ func (p *spreadingStrategy) GetNextUpgradePods(control sidecarcontrol.SidecarCon
 			} else if !canUpgrade {
 				// only image field can be in-place updated, if other fields changed, mark pod as not upgradable
 				notUpgradableIndexes = append(notUpgradableIndexes, index)
+			} else {
+				inconsistentCount++
 			}
 		}
 	}
@@ -88,7 +149,8 @@ func (p *spreadingStrategy) GetNextUpgradePods(control sidecarcontrol.SidecarCon
 	waitUpgradedIndexes = SortUpdateIndexes(strategy, pods, waitUpgradedIndexes)
 
 	//3. calculate to be upgraded pods number for the time
-	needToUpgradeCount := calculateUpgradeCount(control, waitUpgradedIndexes, pods)
+	needToUpgradeCount, completed := calculateUpgradeCount(control, waitUpgradedIndexes, pods, partition, maxUnavailable)
+	completed = completed && inconsistentCount == 0
 	if needToUpgradeCount < len(waitUpgradedIndexes) {
 		waitUpgradedIndexes = waitUpgradedIndexes[:needToUpgradeCount]
 	}
@@ -131,38 +193,41 @@ func SortUpdateIndexes(strategy appsv1beta1.SidecarSetUpdateStrategy, pods []*co
 	return waitUpdateIndexes
 }
 
-func calculateUpgradeCount(coreControl sidecarcontrol.SidecarControl, waitUpdateIndexes []int, pods []*corev1.Pod) int {
+// calculateUpgradeCount returns the number of pods to be upgraded for the time, and whether all the pods have been
+// updated and ready, except for the ones kept in old revisions by the partition.
+func calculateUpgradeCount(coreControl sidecarcontrol.SidecarControl, waitUpdateIndexes []int, pods []*corev1.Pod,
+	partitionStr, maxUnavailableStr *intstrutil.IntOrString) (int, bool) {
 	totalReplicas := len(pods)
 	sidecarSet := coreControl.GetSidecarset()
-	strategy := sidecarSet.Spec.UpdateStrategy
+
+	var upgradeAndNotReadyCount int
+	for _, pod := range pods {
+		// 1. sidecar containers have been updated to the latest sidecarSet version, for pod.spec.containers
+		// 2. whether pod.spec and pod.status is inconsistent after updating the sidecar containers
+		// 3. whether pod is not ready
+		if sidecarcontrol.IsPodSidecarUpdated(sidecarSet, pod) && (!coreControl.IsPodStateConsistent(pod, nil) || !coreControl.IsPodReady(pod)) {
+			upgradeAndNotReadyCount++
+		}
+	}
 
 	// default partition = 0, indicates all pods will been upgraded
 	var partition int
-	if strategy.Partition != nil {
+	if partitionStr != nil {
 		totalInt32 := int32(totalReplicas)
-		partition, _ = util.CalculatePartitionReplicas(strategy.Partition, &totalInt32)
+		partition, _ = util.CalculatePartitionReplicas(partitionStr, &totalInt32)
 	}
 	// indicates the partition pods will not be upgraded for the time
 	if len(waitUpdateIndexes)-partition <= 0 {
-		return 0
+		return 0, upgradeAndNotReadyCount == 0
 	}
 	waitUpdateIndexes = waitUpdateIndexes[:(len(waitUpdateIndexes) - partition)]
 
 	// max unavailable pods number, default is 1
 	maxUnavailable := 1
-	if strategy.MaxUnavailable != nil {
-		maxUnavailable, _ = intstrutil.GetValueFromIntOrPercent(strategy.MaxUnavailable, totalReplicas, true)
+	if maxUnavailableStr != nil {
+		maxUnavailable, _ = intstrutil.GetValueFromIntOrPercent(maxUnavailableStr, totalReplicas, true)
 	}
 
-	var upgradeAndNotReadyCount int
-	for _, pod := range pods {
-		// 1. sidecar containers have been updated to the latest sidecarSet version, for pod.spec.containers
-		// 2. whether pod.spec and pod.status is inconsistent after updating the sidecar containers
-		// 3. whether pod is not ready
-		if sidecarcontrol.IsPodSidecarUpdated(sidecarSet, pod) && (!coreControl.IsPodStateConsistent(pod, nil) || !coreControl.IsPodReady(pod)) {
-			upgradeAndNotReadyCount++
-		}
-	}
 	var needUpgradeCount int
 	for _, i := range waitUpdateIndexes {
 		// If pod is not ready, then not included in the calculation of maxUnavailable
@@ -176,7 +241,7 @@ func calculateUpgradeCount(coreControl sidecarcontrol.SidecarControl, waitUpdate
 		upgradeAndNotReadyCount++
 		needUpgradeCount++
 	}
-	return needUpgradeCount
+	return needUpgradeCount, false
 }
 
 func parseUpdateScatterTerms(scatter appsv1beta1.UpdateScatterStrategy, pods []*corev1.Pod) appsv1beta1.UpdateScatterStrategy {
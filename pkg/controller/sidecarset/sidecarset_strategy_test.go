@@ -431,6 +431,113 @@ func testGetNextUpgradePods(t *testing.T, factoryPods FactoryPods, factorySideca
 	}
 }
 
+func TestGetNextUpgradePodsWithNamespaceWaves(t *testing.T) {
+	// pod-0~2 in ns-a, pod-3~5 in ns-b, pod-6~9 in default
+	getPods := func(upgraded, upgradedAndReady int) []*corev1.Pod {
+		pods := factoryPods(10, upgraded, upgradedAndReady)
+		for i, pod := range pods {
+			switch {
+			case i < 3:
+				pod.Namespace = "ns-a"
+			case i < 6:
+				pod.Namespace = "ns-b"
+			default:
+				pod.Namespace = "default"
+			}
+		}
+		return pods
+	}
+	getSidecarSet := func(waves ...appsv1beta1.SidecarSetNamespaceWave) *appsv1beta1.SidecarSet {
+		sidecarSet := factorySidecarSet()
+		sidecarSet.Spec.UpdateStrategy.MaxUnavailable = &intstr.IntOrString{Type: intstr.Int, IntVal: 3}
+		sidecarSet.Spec.UpdateStrategy.NamespaceWaves = waves
+		return sidecarSet
+	}
+
+	cases := []struct {
+		name            string
+		pods            []*corev1.Pod
+		sidecarSet      *appsv1beta1.SidecarSet
+		expectNamespace string
+		expectCount     int
+	}{
+		{
+			name: "update the first wave with its own maxUnavailable",
+			pods: getPods(0, 0),
+			sidecarSet: getSidecarSet(
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-a"}, MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 2}},
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-b"}},
+			),
+			expectNamespace: "ns-a",
+			expectCount:     2,
+		},
+		{
+			name: "wait for the first wave to be ready",
+			pods: getPods(3, 2),
+			sidecarSet: getSidecarSet(
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-a"}},
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-b"}},
+			),
+			expectCount: 0,
+		},
+		{
+			name: "update the second wave with the default maxUnavailable",
+			pods: getPods(3, 3),
+			sidecarSet: getSidecarSet(
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-a"}},
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-b"}},
+			),
+			expectNamespace: "ns-b",
+			expectCount:     3,
+		},
+		{
+			name: "update the second wave after the partition of the first wave",
+			pods: getPods(2, 2),
+			sidecarSet: getSidecarSet(
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-a"}, Partition: &intstr.IntOrString{Type: intstr.Int, IntVal: 1}},
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-b"}, MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1}},
+			),
+			expectNamespace: "ns-b",
+			expectCount:     1,
+		},
+		{
+			name: "the second wave is paused",
+			pods: getPods(3, 3),
+			sidecarSet: getSidecarSet(
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-a"}},
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-b"}, Paused: true},
+			),
+			expectCount: 0,
+		},
+		{
+			name: "update the namespaces not listed in the last wave",
+			pods: getPods(6, 6),
+			sidecarSet: getSidecarSet(
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-a"}},
+				appsv1beta1.SidecarSetNamespaceWave{Namespaces: []string{"ns-b"}},
+			),
+			expectNamespace: "default",
+			expectCount:     3,
+		},
+	}
+
+	strategy := NewStrategy()
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			control := sidecarcontrol.New(cs.sidecarSet)
+			upgradePods, _ := strategy.GetNextUpgradePods(control, cs.pods)
+			if cs.expectCount != len(upgradePods) {
+				t.Fatalf("expect NeedUpgradeCount(%d), but get value(%d)", cs.expectCount, len(upgradePods))
+			}
+			for _, pod := range upgradePods {
+				if pod.Namespace != cs.expectNamespace {
+					t.Fatalf("expect pods in namespace(%s), but get pod(%s/%s)", cs.expectNamespace, pod.Namespace, pod.Name)
+				}
+			}
+		})
+	}
+}
+
 func TestParseUpdateScatterTerms(t *testing.T) {
 	cases := []struct {
 		name                  string
@@ -26,6 +26,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
 )
 
 type pvcEventHandler struct {
@@ -55,6 +57,21 @@ func (e *pvcEventHandler) Update(ctx context.Context, evt event.TypedUpdateEvent
 }
 
 func (e *pvcEventHandler) Delete(ctx context.Context, evt event.TypedDeleteEvent[*v1.PersistentVolumeClaim], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// the pod waits for the claim deleted for recreation to be gone
+	pvc := evt.Object
+	if pvc.Labels[appspub.LifecycleStateKey] != string(appspub.LifecycleStatePreparingDelete) {
+		return
+	}
+	ownedByAstsName, exist := pvc.Annotations[PVCOwnedByStsAnnotationKey]
+	if !exist {
+		return
+	}
+
+	klog.V(4).InfoS("pvc deletion trigger asts reconcile", "pvc", klog.KObj(pvc), "sts", ownedByAstsName)
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: pvc.Namespace,
+		Name:      ownedByAstsName,
+	}})
 }
 
 func (e *pvcEventHandler) Generic(ctx context.Context, evt event.TypedGenericEvent[*v1.PersistentVolumeClaim], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
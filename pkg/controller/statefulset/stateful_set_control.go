@@ -460,18 +460,36 @@ func (ssc *defaultStatefulSetControl) updateStatefulSet(
 		klog.V(4).InfoS("StatefulSet has unhealthy Pods", "statefulSet", klog.KObj(set), "unhealthyReplicas", unhealthy, "pod", klog.KObj(firstUnhealthyPod))
 	}
 
+	// the quorum guard limits the members to be disrupted by both scale-down and rolling update
+	quorum, err := newQuorumGuard(set, replicas, condemned, minReadySeconds)
+	if err != nil {
+		return &status, err
+	}
+	if quorum != nil {
+		status.QuorumHeadroom = ptr.To(quorum.headroom())
+	}
+
 	// If the StatefulSet is being deleted, don't do anything other than updating
 	// status.
 	if set.DeletionTimestamp != nil {
 		return &status, nil
 	}
 
+	// the pods of ordinals being adopted from another StatefulSet will not be created until their PVCs are adopted
+	adoptingOrdinals, err := ssc.syncOrdinalHandoffs(set, &status, pods, replicas, reserveOrdinals)
+	if err != nil {
+		return &status, err
+	}
+
 	// First, process each living replica. Exit if we run into an error or something blocking in monotonic mode.
 	scaleMaxUnavailable, err := getScaleMaxUnavailable(set)
 	if err != nil {
 		return &status, err
 	}
 	processReplicaFn := func(i int) (bool, bool, error) {
+		if replicas[i] != nil && !isCreated(replicas[i]) && adoptingOrdinals.Has(getOrdinal(replicas[i])) {
+			return monotonic, false, nil
+		}
 		return ssc.processReplica(ctx, set, updateSet, monotonic, replicas, i, &status, scaleMaxUnavailable)
 	}
 	if shouldExit, err := runForAllWithBreak(replicas, processReplicaFn, monotonic); shouldExit || err != nil {
@@ -483,6 +501,10 @@ func (ssc *defaultStatefulSetControl) updateStatefulSet(
 	if utilfeature.DefaultFeatureGate.Enabled(features.StatefulSetAutoDeletePVC) {
 		// Ensure ownerRefs are set correctly for the condemned pods.
 		fixPodClaim := func(i int) (bool, error) {
+			// the claims of ordinals released to another StatefulSet are retained for adoption
+			if isOrdinalReleased(set, reserveOrdinals, getOrdinal(condemned[i])) {
+				return false, nil
+			}
 			if matchPolicy, err := ssc.podControl.ClaimsMatchRetentionPolicy(updateSet, condemned[i]); err != nil {
 				return true, err
 			} else if !matchPolicy {
@@ -506,7 +528,7 @@ func (ssc *defaultStatefulSetControl) updateStatefulSet(
 	// Note that we do not resurrect Pods in this interval. Also note that scaling will take precedence over
 	// updates.
 	processCondemnedFn := func(i int) (bool, error) {
-		return ssc.processCondemned(ctx, set, firstUnhealthyPod, monotonic, condemned, i)
+		return ssc.processCondemned(ctx, set, firstUnhealthyPod, monotonic, condemned, i, quorum)
 	}
 	if shouldExit, err := runForAll(condemned, processCondemnedFn, monotonic); shouldExit || err != nil {
 		ssc.updatePVCStatus(&status, set, replicas)
@@ -522,7 +544,7 @@ func (ssc *defaultStatefulSetControl) updateStatefulSet(
 	}
 
 	return ssc.rollingUpdateStatefulsetPods(
-		set, &status, currentRevision, updateRevision, revisions, pods, replicas, minReadySeconds,
+		set, &status, currentRevision, updateRevision, revisions, pods, replicas, minReadySeconds, quorum,
 	)
 }
 
@@ -535,6 +557,7 @@ func (ssc *defaultStatefulSetControl) rollingUpdateStatefulsetPods(
 	pods []*v1.Pod,
 	replicas []*v1.Pod,
 	minReadySeconds int32,
+	quorum *quorumGuard,
 ) (*appsv1beta1.StatefulSetStatus, error) {
 
 	// If update expectations have not satisfied yet, skip updating pods
@@ -624,9 +647,32 @@ func (ssc *defaultStatefulSetControl) rollingUpdateStatefulsetPods(
 		}
 	}
 
+	// count pods whose claims are being recreated as unavailable, the claims are recreated ordinal by ordinal
+	recreatingPods := sets.NewString()
+	recreateClaimsEnabled := utilfeature.DefaultFeatureGate.Enabled(features.StatefulSetAutoResizePVCGate) && isVolumeClaimRecreateEnabled(set)
+	if recreateClaimsEnabled {
+		for target := range replicas {
+			if replicas[target] == nil {
+				continue
+			}
+			if recreating, err := ssc.podControl.IsOwnedPVCsRecreating(set, replicas[target]); err != nil {
+				return status, err
+			} else if recreating {
+				recreatingPods.Insert(replicas[target].Name)
+				unavailablePods.Insert(replicas[target].Name)
+			}
+		}
+	}
+
 	// handle specified deleted pod under maxUnavailable constrain
 	// NOTE: specified deletion is not constraint by partition setting
-	specifiedDeletedPods, err := ssc.handleSpecifiedDeletedPods(set, status, currentRevision, updateRevision, replicas, maxUnavailable, unavailablePods)
+	specifiedDeletedPods, err := ssc.handleSpecifiedDeletedPods(set, status, currentRevision, updateRevision, replicas, maxUnavailable, unavailablePods, quorum)
+	if err != nil {
+		return status, err
+	}
+
+	// limit the unavailable pods in each topology domain if topologyUpdateConstraint is set
+	topologyLimiter, err := newTopologyUpdateLimiter(sigsruntimeClient, set, replicas, unavailablePods)
 	if err != nil {
 		return status, err
 	}
@@ -656,11 +702,33 @@ func (ssc *defaultStatefulSetControl) rollingUpdateStatefulsetPods(
 			return status, nil
 		}
 
+		// the unavailable pods in the topology domain of target exceed the limit, skip it for unordered update,
+		// or wait for the unavailable pods in this domain
+		if !topologyLimiter.allow(replicas[target]) {
+			klog.V(4).InfoS("StatefulSet was waiting for unavailable Pods in the same topology domain to update, blocked pod",
+				"statefulSet", klog.KObj(set), "blockedPod", klog.KObj(replicas[target]))
+			if set.Spec.UpdateStrategy.RollingUpdate.UnorderedUpdate != nil {
+				continue
+			}
+			return status, nil
+		}
+
+		// the Pod which is already terminating or being deleted as specified is not disrupted again
+		disruptable := !specifiedDeletedPods.Has(replicas[target].Name) && !isTerminating(replicas[target])
+
 		// Kruise currently will not patch pvc size until a pod references the resized volume.
 		// online-file-system-expansion: if no pods referencing the volume are running, file system expansion will not happen.
 		// refer to https://kubernetes.io/blog/2018/07/12/resizing-persistent-volumes-using-kubernetes/#online-file-system-expansion
+		var recreateClaims bool
 		if utilfeature.DefaultFeatureGate.Enabled(features.StatefulSetAutoResizePVCGate) &&
 			set.Spec.VolumeClaimUpdateStrategy.Type == appsv1beta1.OnPodRollingUpdateVolumeClaimUpdateStrategyType {
+			// wait for the claims of other pod to be recreated
+			if !pvcMatched && recreatingPods.Len() > 0 && !recreatingPods.Has(replicas[target].Name) {
+				klog.V(4).InfoS("StatefulSet was waiting for claims of other Pods to be recreated, blocked pod",
+					"statefulSet", klog.KObj(set), "recreatingPods", recreatingPods.List(), "blockedPod", klog.KObj(replicas[target]))
+				continue
+			}
+
 			// resize pvc if necessary and wait for resize completed
 			if !pvcMatched {
 				err = ssc.podControl.TryPatchPVC(set, replicas[target])
@@ -669,23 +737,61 @@ func (ssc *defaultStatefulSetControl) rollingUpdateStatefulsetPods(
 				}
 			}
 
-			allCompleted, err := ssc.podControl.IsOwnedPVCsCompleted(set, replicas[target])
-			if err != nil {
-				return status, err
-			} else if !allCompleted {
-				// mark target as unavailable because pvc's updated
-				unavailablePods.Insert(replicas[target].Name)
-				// need to wait for pvc resize completed, continue to handle next pod
-				continue
+			// recreate pvc which can not be patched, and recreate the pod once the claims have been deleted.
+			// the claims are only deleted once the pod is allowed to be disrupted.
+			if !pvcMatched && recreateClaimsEnabled && disruptable {
+				if !quorum.tryDisrupt(replicas[target]) {
+					klog.V(4).InfoS("StatefulSet was waiting for members to be available to keep quorum, blocked pod",
+						"statefulSet", klog.KObj(set), "blockedPod", klog.KObj(replicas[target]))
+					return status, nil
+				}
+				needRecreate, deleted, err := ssc.podControl.TryRecreatePVC(set, replicas[target])
+				if err != nil {
+					return status, err
+				}
+				if needRecreate {
+					recreatingPods.Insert(replicas[target].Name)
+					unavailablePods.Insert(replicas[target].Name)
+					topologyLimiter.markUnavailable(replicas[target])
+					if !deleted {
+						// need to wait for preDeleteHook of claims, continue to handle next pod
+						continue
+					}
+					recreateClaims = true
+				}
+			}
+
+			if !recreateClaims {
+				allCompleted, err := ssc.podControl.IsOwnedPVCsCompleted(set, replicas[target])
+				if err != nil {
+					return status, err
+				} else if !allCompleted {
+					// mark target as unavailable because pvc's updated
+					unavailablePods.Insert(replicas[target].Name)
+					topologyLimiter.markUnavailable(replicas[target])
+					// need to wait for pvc resize completed, continue to handle next pod
+					continue
+				}
 			}
 		}
 
 		// delete the Pod if it is not already terminating and does not match the update revision.
-		if !specifiedDeletedPods.Has(replicas[target].Name) && !isTerminating(replicas[target]) {
+		if disruptable {
+			// the available members would drop below the quorum, wait for the disrupted members to be available.
+			// it has been counted as disrupted if its claims are being recreated.
+			if !quorum.tryDisrupt(replicas[target]) {
+				klog.V(4).InfoS("StatefulSet was waiting for members to be available to keep quorum, blocked pod",
+					"statefulSet", klog.KObj(set), "blockedPod", klog.KObj(replicas[target]))
+				return status, nil
+			}
 			// todo validate in-place for pub
-			inplacing, inplaceUpdateErr := ssc.inPlaceUpdatePod(set, replicas[target], updateRevision, revisions)
-			if inplaceUpdateErr != nil {
-				return status, inplaceUpdateErr
+			// the pod must be recreated to use the recreated claims
+			var inplacing bool
+			if !recreateClaims {
+				var inplaceUpdateErr error
+				if inplacing, inplaceUpdateErr = ssc.inPlaceUpdatePod(set, replicas[target], updateRevision, revisions); inplaceUpdateErr != nil {
+					return status, inplaceUpdateErr
+				}
 			}
 			// if pod is inplacing or actual deleting, decrease revision
 			revisionNeedDecrease := inplacing
@@ -699,6 +805,7 @@ func (ssc *defaultStatefulSetControl) rollingUpdateStatefulsetPods(
 			}
 			// mark target as unavailable because it's updated
 			unavailablePods.Insert(replicas[target].Name)
+			topologyLimiter.markUnavailable(replicas[target])
 
 			if revisionNeedDecrease && getPodRevision(replicas[target]) == currentRevision.Name {
 				status.CurrentReplicas--
@@ -716,7 +823,8 @@ func (ssc *defaultStatefulSetControl) handleSpecifiedDeletedPods(
 	updateRevision *apps.ControllerRevision,
 	replicas []*v1.Pod,
 	maxUnavailable int,
-	unavailablePods sets.String) (sets.String, error) {
+	unavailablePods sets.String,
+	quorum *quorumGuard) (sets.String, error) {
 	specifiedDeletedPods := sets.NewString()
 	for target := len(replicas) - 1; target >= 0; target-- {
 		if replicas[target] == nil || !specifieddelete.IsSpecifiedDelete(replicas[target]) {
@@ -732,6 +840,11 @@ func (ssc *defaultStatefulSetControl) handleSpecifiedDeletedPods(
 				"statefulSet", klog.KObj(set), "unavailablePods", unavailablePods.List(), "blockedPod", klog.KObj(replicas[target]))
 			continue
 		}
+		if !quorum.tryDisrupt(replicas[target]) {
+			klog.V(4).InfoS("StatefulSet was waiting for members to be available to keep quorum, blocked pod",
+				"statefulSet", klog.KObj(set), "blockedPod", klog.KObj(replicas[target]))
+			continue
+		}
 
 		specifiedDeletedPods.Insert(replicas[target].Name)
 		if _, actualDeleting, err := ssc.deletePod(set, replicas[target]); err != nil {
@@ -1024,7 +1137,7 @@ func getStartOrdinal(set *appsv1beta1.StatefulSet) int {
 	return 0
 }
 
-func (ssc *defaultStatefulSetControl) processCondemned(ctx context.Context, set *appsv1beta1.StatefulSet, firstUnhealthyPod *v1.Pod, monotonic bool, condemned []*v1.Pod, i int) (bool, error) {
+func (ssc *defaultStatefulSetControl) processCondemned(ctx context.Context, set *appsv1beta1.StatefulSet, firstUnhealthyPod *v1.Pod, monotonic bool, condemned []*v1.Pod, i int, quorum *quorumGuard) (bool, error) {
 	logger := klog.FromContext(ctx)
 	if isTerminating(condemned[i]) {
 		// if we are in monotonic mode, block and wait for terminating pods to expire
@@ -1050,6 +1163,20 @@ func (ssc *defaultStatefulSetControl) processCondemned(ctx context.Context, set
 		}
 		return true, nil
 	}
+	// if scaling down the condemned target would break the quorum, block in monotonic mode or skip it.
+	if !quorum.tryDisrupt(condemned[i]) {
+		logger.V(4).Info("StatefulSet is waiting for members to be available to keep quorum prior to scale down",
+			"statefulSet", klog.KObj(set), "pod", klog.KObj(condemned[i]))
+		return monotonic, nil
+	}
+	// wait for the drain hook to move the data out of the condemned target, block in monotonic mode or skip it.
+	if drained, err := ssc.drainPod(set, condemned[i]); err != nil {
+		return true, err
+	} else if !drained {
+		logger.V(4).Info("StatefulSet is waiting for Pod to be drained prior to scale down",
+			"statefulSet", klog.KObj(set), "pod", klog.KObj(condemned[i]))
+		return monotonic, nil
+	}
 
 	logger.V(2).Info("Pod of StatefulSet is terminating for scale down",
 		"statefulSet", klog.KObj(set), "pod", klog.KObj(condemned[i]))
@@ -1169,6 +1296,14 @@ func (ssc *defaultStatefulSetControl) processReplica(
 		return false, true, nil
 	}
 
+	// Reset the drain state of pod which has been scaled back before deleted
+	if modified, err := ssc.resetPodDrainState(set, replicas[i]); err != nil {
+		return true, false, err
+	} else if modified {
+		// pod updated, no more work possible for this round
+		return monotonic, false, nil
+	}
+
 	// Update InPlaceUpdateReady condition for pod
 	_, duration, err := ssc.refreshPodState(set, replicas[i], status.UpdateRevision)
 	if err != nil {
@@ -3952,6 +3952,17 @@ func (om *fakeObjectManager) UpdateClaim(claim *v1.PersistentVolumeClaim) error
 	return nil
 }
 
+func (om *fakeObjectManager) DeleteClaim(claim *v1.PersistentVolumeClaim) error {
+	if key, err := controller.KeyFunc(claim); err != nil {
+		return err
+	} else if obj, found, err := om.claimsIndexer.GetByKey(key); err != nil {
+		return err
+	} else if found {
+		return om.claimsIndexer.Delete(obj)
+	}
+	return nil
+}
+
 func (om *fakeObjectManager) GetStorageClass(scName string) (*storagev1.StorageClass, error) {
 	return om.scLister.Get(scName)
 }
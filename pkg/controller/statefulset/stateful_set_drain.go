@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+const (
+	// drainHookPollInterval is the interval to requeue the StatefulSet while the drain hook is running.
+	drainHookPollInterval = time.Second
+)
+
+var (
+	// drainHooks calls the drain hooks in background, so that slow hooks never block the workers.
+	drainHooks = &drainHookRunner{results: map[string]*drainHookResult{}}
+)
+
+type drainHookResult struct {
+	uid     types.UID
+	done    bool
+	drained bool
+	message string
+}
+
+type drainHookRunner struct {
+	sync.Mutex
+	// namespace/name of pod -> the result of the latest call
+	results map[string]*drainHookResult
+}
+
+// Run starts calling the hook in background if it is not running on the pod, and returns the result once it has finished.
+// The result is returned only once.
+func (r *drainHookRunner) Run(hook *appsv1beta1.StatefulSetDrainHook, pod *v1.Pod) (done, drained bool, message string) {
+	key := pod.Namespace + "/" + pod.Name
+	r.Lock()
+	defer r.Unlock()
+	if result, ok := r.results[key]; ok && result.uid == pod.UID {
+		if !result.done {
+			return false, false, ""
+		}
+		delete(r.results, key)
+		return true, result.drained, result.message
+	}
+
+	result := &drainHookResult{uid: pod.UID}
+	r.results[key] = result
+	hook, pod = hook.DeepCopy(), pod.DeepCopy()
+	go func() {
+		drained, message := callDrainHook(hook, pod)
+		r.Lock()
+		defer r.Unlock()
+		result.done, result.drained, result.message = true, drained, message
+	}()
+	return false, false, ""
+}
+
+// Forget drops the result of the hook on the pod, if any.
+func (r *drainHookRunner) Forget(pod *v1.Pod) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.results, pod.Namespace+"/"+pod.Name)
+}
+
+func getDrainHook(set *appsv1beta1.StatefulSet) *appsv1beta1.StatefulSetDrainHook {
+	if set.Spec.ScaleStrategy == nil {
+		return nil
+	}
+	return set.Spec.ScaleStrategy.DrainHook
+}
+
+// getPodDrainState returns nil if the pod has no valid drain state.
+func getPodDrainState(pod *v1.Pod) *appsv1beta1.StatefulSetPodDrainState {
+	value, ok := pod.Annotations[appsv1beta1.StatefulSetPodDrainStateKey]
+	if !ok {
+		return nil
+	}
+	state := &appsv1beta1.StatefulSetPodDrainState{}
+	if err := json.Unmarshal([]byte(value), state); err != nil {
+		klog.ErrorS(err, "Failed to unmarshal drain state of pod", "pod", klog.KObj(pod))
+		return nil
+	}
+	return state
+}
+
+// drainPod calls the drain hook on the pod to be scaled down, and returns true if the pod has been drained
+// or the maxWaitSeconds has been exceeded.
+// The hook is called in background, and its result is recorded in the drain state of pod once it has finished.
+// The pod is updated once its drain state changes, which triggers the next round to delete it.
+func (ssc *defaultStatefulSetControl) drainPod(set *appsv1beta1.StatefulSet, pod *v1.Pod) (bool, error) {
+	hook := getDrainHook(set)
+	if hook == nil {
+		return true, nil
+	}
+	state := getPodDrainState(pod)
+	if state != nil && state.Phase != appsv1beta1.PodDraining {
+		return true, nil
+	}
+	if state == nil && pod.Status.Phase != v1.PodRunning {
+		// nothing to drain in the pod not running
+		klog.V(3).InfoS("StatefulSet skipped to drain pod not running", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod))
+		return true, nil
+	}
+
+	period := time.Duration(hook.PeriodSeconds) * time.Second
+	if period <= 0 {
+		period = 5 * time.Second
+	}
+	now := metav1.Now()
+	started := state != nil
+	if !started {
+		state = &appsv1beta1.StatefulSetPodDrainState{Phase: appsv1beta1.PodDraining, StartTime: now}
+	} else if now.Sub(state.StartTime.Time) >= time.Duration(hook.MaxWaitSeconds)*time.Second {
+		state.Phase = appsv1beta1.PodDrainTimedOut
+		drainHooks.Forget(pod)
+		ssc.recorder.Eventf(set, v1.EventTypeWarning, "DrainTimedOut",
+			"pod %s was not drained in %d seconds: %s", pod.Name, hook.MaxWaitSeconds, state.Message)
+	} else if wait := state.LastProbeTime.Add(period).Sub(now.Time); wait > 0 {
+		durationStore.Push(getStatefulSetKey(set), wait)
+		return false, nil
+	}
+
+	if state.Phase == appsv1beta1.PodDraining {
+		done, drained, msg := drainHooks.Run(hook, pod)
+		if !done {
+			durationStore.Push(getStatefulSetKey(set), drainHookPollInterval)
+			if started {
+				// wait for the hook running in background, the start time has been recorded
+				return false, nil
+			}
+		} else {
+			state.LastProbeTime = now
+			state.Message = msg
+			if drained {
+				state.Phase = appsv1beta1.PodDrained
+				ssc.recorder.Eventf(set, v1.EventTypeNormal, "Drained", "pod %s was drained for scale down", pod.Name)
+			} else {
+				durationStore.Push(getStatefulSetKey(set), period)
+			}
+		}
+	}
+
+	stateBytes, _ := json.Marshal(state)
+	clone := pod.DeepCopy()
+	if clone.Annotations == nil {
+		clone.Annotations = map[string]string{}
+	}
+	clone.Annotations[appsv1beta1.StatefulSetPodDrainStateKey] = string(stateBytes)
+	if err := ssc.podControl.objectMgr.UpdatePod(clone); err != nil {
+		return false, err
+	}
+	klog.V(3).InfoS("StatefulSet updated drain state of pod", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod), "state", state.Phase)
+	return false, nil
+}
+
+// resetPodDrainState removes the drain state of the pod which is no longer to be scaled down,
+// so that it will be drained again on the next scale down. Returns true if the pod is updated.
+func (ssc *defaultStatefulSetControl) resetPodDrainState(set *appsv1beta1.StatefulSet, pod *v1.Pod) (bool, error) {
+	if _, ok := pod.Annotations[appsv1beta1.StatefulSetPodDrainStateKey]; !ok {
+		return false, nil
+	}
+	drainHooks.Forget(pod)
+	clone := pod.DeepCopy()
+	delete(clone.Annotations, appsv1beta1.StatefulSetPodDrainStateKey)
+	if err := ssc.podControl.objectMgr.UpdatePod(clone); err != nil {
+		return false, err
+	}
+	klog.V(3).InfoS("StatefulSet reset drain state of pod", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod))
+	return true, nil
+}
+
+// callDrainHook returns whether the pod has been drained, and the message of the response.
+func callDrainHook(hook *appsv1beta1.StatefulSetDrainHook, pod *v1.Pod) (bool, string) {
+	return util.CallPodHTTPGetAction(&hook.HTTPGet, pod, time.Duration(hook.TimeoutSeconds)*time.Second)
+}
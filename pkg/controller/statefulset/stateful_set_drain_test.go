@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/controller"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	kruisefake "github.com/openkruise/kruise/pkg/client/clientset/versioned/fake"
+	kruiseinformers "github.com/openkruise/kruise/pkg/client/informers/externalversions"
+)
+
+func TestDrainPod(t *testing.T) {
+	var drained atomic.Bool
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/drain" {
+			w.WriteHeader(http.StatusNotFound)
+		} else if drained.Load() {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	host, portStr, _ := net.SplitHostPort(server.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	set := newStatefulSet(3)
+	set.Spec.ScaleStrategy = &appsv1beta1.StatefulSetScaleStrategy{
+		DrainHook: &appsv1beta1.StatefulSetDrainHook{
+			HTTPGet:        v1.HTTPGetAction{Path: "/drain", Port: intstr.FromInt32(int32(port))},
+			PeriodSeconds:  5,
+			MaxWaitSeconds: 60,
+		},
+	}
+	newPod := func() *v1.Pod {
+		pod := newStatefulSetPod(set, 2)
+		pod.Status.Phase = v1.PodRunning
+		pod.Status.PodIP = host
+		return pod
+	}
+
+	informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), controller.NoResyncPeriodFunc())
+	kruiseInformerFactory := kruiseinformers.NewSharedInformerFactory(kruisefake.NewSimpleClientset(), controller.NoResyncPeriodFunc())
+	om := newFakeObjectManager(informerFactory, kruiseInformerFactory)
+	ssc := &defaultStatefulSetControl{podControl: NewStatefulPodControlFromManager(om, &noopRecorder{}), recorder: &noopRecorder{}}
+
+	drain := func(pod *v1.Pod) (bool, *v1.Pod) {
+		if err := om.podsIndexer.Update(pod); err != nil {
+			t.Fatal(err)
+		}
+		ok, err := ssc.drainPod(set, pod)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := om.GetPod(pod.Namespace, pod.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ok, got
+	}
+	// wait for the hook running in background to finish
+	waitHook := func(pod *v1.Pod) {
+		err := wait.PollUntilContextTimeout(context.TODO(), 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+			drainHooks.Lock()
+			defer drainHooks.Unlock()
+			result, ok := drainHooks.results[pod.Namespace+"/"+pod.Name]
+			return !ok || result.done, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	setState := func(pod *v1.Pod, fn func(state *appsv1beta1.StatefulSetPodDrainState)) {
+		state := getPodDrainState(pod)
+		fn(state)
+		stateBytes, _ := json.Marshal(state)
+		pod.Annotations[appsv1beta1.StatefulSetPodDrainStateKey] = string(stateBytes)
+	}
+
+	// the first call starts draining and calls the hook in background
+	ok, pod := drain(newPod())
+	if state := getPodDrainState(pod); ok || state == nil || state.Phase != appsv1beta1.PodDraining || state.Message != "" {
+		t.Fatalf("expected draining, got %v %+v", ok, state)
+	}
+	// the result of hook is recorded once it has finished
+	waitHook(pod)
+	ok, pod = drain(pod)
+	if state := getPodDrainState(pod); ok || state.Phase != appsv1beta1.PodDraining || state.Message != "HTTP status 503" || state.LastProbeTime.IsZero() {
+		t.Fatalf("expected draining with the result of hook, got %v %+v", ok, state)
+	}
+
+	// no call within the period
+	if ok, _ = drain(pod); ok || calls.Load() != 1 {
+		t.Fatalf("expected no call within the period, got %v with %d calls", ok, calls.Load())
+	}
+
+	// drained after the period
+	drained.Store(true)
+	setState(pod, func(state *appsv1beta1.StatefulSetPodDrainState) {
+		state.LastProbeTime = metav1.NewTime(state.LastProbeTime.Add(-5 * time.Second))
+	})
+	if ok, _ = drain(pod); ok {
+		t.Fatalf("expected waiting for the hook")
+	}
+	waitHook(pod)
+	ok, pod = drain(pod)
+	if state := getPodDrainState(pod); ok || state.Phase != appsv1beta1.PodDrained {
+		t.Fatalf("expected drained in state, got %v %+v", ok, state)
+	}
+	if ok, _ = drain(pod); !ok {
+		t.Fatalf("expected drained")
+	}
+
+	// timed out after maxWaitSeconds
+	drained.Store(false)
+	ok, pod = drain(newPod())
+	if ok {
+		t.Fatalf("expected draining")
+	}
+	waitHook(pod)
+	setState(pod, func(state *appsv1beta1.StatefulSetPodDrainState) {
+		state.StartTime = metav1.NewTime(state.StartTime.Add(-time.Minute))
+	})
+	ok, pod = drain(pod)
+	if state := getPodDrainState(pod); ok || state.Phase != appsv1beta1.PodDrainTimedOut {
+		t.Fatalf("expected timed out in state, got %v %+v", ok, state)
+	}
+	if ok, _ = drain(pod); !ok {
+		t.Fatalf("expected timed out")
+	}
+
+	// reset the state when scaled back
+	if modified, err := ssc.resetPodDrainState(set, pod); err != nil || !modified {
+		t.Fatalf("expected drain state reset, got %v %v", modified, err)
+	}
+	if pod, _ = om.GetPod(pod.Namespace, pod.Name); getPodDrainState(pod) != nil {
+		t.Fatalf("expected no drain state")
+	}
+
+	// skip the pod not running
+	notRunning := newPod()
+	notRunning.Status.Phase = v1.PodPending
+	if ok, _ = drain(notRunning); !ok {
+		t.Fatalf("expected pod not running skipped")
+	}
+}
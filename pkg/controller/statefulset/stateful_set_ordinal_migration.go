@@ -0,0 +1,400 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+const (
+	// PVCMigratedFromAnnotationKey is the name of source PVC, which is annotated on the PVC adopted by target StatefulSet.
+	PVCMigratedFromAnnotationKey = "apps.kruise.io/migrated-from"
+	// PVCReleasedToAnnotationKey is the name of target StatefulSet, which is annotated on the PVC of released ordinal
+	// by the source StatefulSet. It records the release durably, so that the target can adopt the PVC after the source is deleted.
+	PVCReleasedToAnnotationKey = "apps.kruise.io/released-to"
+	// PVReclaimPolicyAnnotationKey is the original reclaim policy of PV, which is set to Retain during migration.
+	PVReclaimPolicyAnnotationKey = "apps.kruise.io/migration-reclaim-policy"
+
+	// ordinalHandoffRequeueDuration is the duration to requeue the target waiting for the source to release ordinals.
+	ordinalHandoffRequeueDuration = 5 * time.Second
+)
+
+// syncOrdinalHandoffs records the handoff state of ordinals in status, and returns the ordinals whose pods
+// should not be created because they are still being adopted from the source StatefulSet.
+func (ssc *defaultStatefulSetControl) syncOrdinalHandoffs(
+	set *appsv1beta1.StatefulSet,
+	status *appsv1beta1.StatefulSetStatus,
+	pods, replicas []*v1.Pod,
+	reserveOrdinals sets.Set[int],
+) (sets.Set[int], error) {
+	adoptingOrdinals := sets.New[int]()
+	if set.Spec.OrdinalMigration == nil {
+		return adoptingOrdinals, nil
+	}
+
+	if set.Spec.OrdinalMigration.ReleaseTo != "" {
+		handoffs, err := ssc.getReleasedOrdinalHandoffs(set, pods, reserveOrdinals)
+		if err != nil {
+			return adoptingOrdinals, err
+		}
+		status.OrdinalHandoffs = append(status.OrdinalHandoffs, handoffs...)
+	}
+
+	if set.Spec.OrdinalMigration.AdoptFrom != "" {
+		adopter, err := newOrdinalAdopter(sigsruntimeClient, set)
+		if err != nil {
+			return adoptingOrdinals, err
+		}
+		for _, pod := range replicas {
+			if pod == nil || isCreated(pod) {
+				continue
+			}
+			ordinal := getOrdinal(pod)
+			phase, err := adopter.adopt(pod)
+			if err != nil {
+				return adoptingOrdinals, err
+			}
+			if phase == "" {
+				continue
+			}
+			adoptingOrdinals.Insert(ordinal)
+			status.OrdinalHandoffs = append(status.OrdinalHandoffs, appsv1beta1.StatefulSetOrdinalHandoff{
+				Ordinal: int32(ordinal),
+				Peer:    set.Spec.OrdinalMigration.AdoptFrom,
+				Phase:   phase,
+			})
+		}
+		if adoptingOrdinals.Len() > 0 {
+			durationStore.Push(getStatefulSetKey(set), ordinalHandoffRequeueDuration)
+		}
+	}
+	return adoptingOrdinals, nil
+}
+
+// isOrdinalReleased returns true if the ordinal is reserved to be released to another StatefulSet.
+func isOrdinalReleased(set *appsv1beta1.StatefulSet, reserveOrdinals sets.Set[int], ordinal int) bool {
+	return set.Spec.OrdinalMigration != nil && set.Spec.OrdinalMigration.ReleaseTo != "" && reserveOrdinals.Has(ordinal)
+}
+
+// getReleasedOrdinalHandoffs returns the reserved ordinals whose PVCs have not been adopted by the target.
+func (ssc *defaultStatefulSetControl) getReleasedOrdinalHandoffs(set *appsv1beta1.StatefulSet, pods []*v1.Pod, reserveOrdinals sets.Set[int]) ([]appsv1beta1.StatefulSetOrdinalHandoff, error) {
+	existingOrdinals := sets.New[int]()
+	for _, pod := range pods {
+		existingOrdinals.Insert(getOrdinal(pod))
+	}
+
+	// the ordinals in use again are no longer released
+	for ordinal := range existingOrdinals {
+		if reserveOrdinals.Has(ordinal) {
+			continue
+		}
+		if _, err := ssc.recordClaimsReleasedTo(set, ordinal, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	var handoffs []appsv1beta1.StatefulSetOrdinalHandoff
+	for _, ordinal := range sets.List(reserveOrdinals) {
+		claimExists, err := ssc.recordClaimsReleasedTo(set, ordinal, set.Spec.OrdinalMigration.ReleaseTo)
+		if err != nil {
+			return nil, err
+		} else if !claimExists {
+			continue
+		}
+
+		phase := appsv1beta1.OrdinalHandoffReleased
+		if existingOrdinals.Has(ordinal) {
+			phase = appsv1beta1.OrdinalHandoffReleasing
+		}
+		handoffs = append(handoffs, appsv1beta1.StatefulSetOrdinalHandoff{
+			Ordinal: int32(ordinal),
+			Peer:    set.Spec.OrdinalMigration.ReleaseTo,
+			Phase:   phase,
+		})
+	}
+	return handoffs, nil
+}
+
+// recordClaimsReleasedTo annotates the claims of the ordinal with the target they are released to, or removes the
+// annotation if target is empty. It returns true if any claim of the ordinal exists and is not being deleted.
+func (ssc *defaultStatefulSetControl) recordClaimsReleasedTo(set *appsv1beta1.StatefulSet, ordinal int, target string) (bool, error) {
+	var claimExists bool
+	for i := range set.Spec.VolumeClaimTemplates {
+		claimName := getPersistentVolumeClaimName(set, &set.Spec.VolumeClaimTemplates[i], ordinal)
+		claim, err := ssc.podControl.objectMgr.GetClaim(set.Namespace, claimName)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		if claim.DeletionTimestamp != nil {
+			continue
+		}
+		claimExists = true
+		if claim.Annotations[PVCReleasedToAnnotationKey] == target {
+			continue
+		}
+		claim = claim.DeepCopy()
+		if target == "" {
+			delete(claim.Annotations, PVCReleasedToAnnotationKey)
+		} else {
+			if claim.Annotations == nil {
+				claim.Annotations = map[string]string{}
+			}
+			claim.Annotations[PVCReleasedToAnnotationKey] = target
+		}
+		if err := ssc.podControl.objectMgr.UpdateClaim(claim); err != nil {
+			return false, err
+		}
+	}
+	return claimExists, nil
+}
+
+// ordinalAdopter adopts the PVCs of ordinals released by the source StatefulSet.
+// Each step checks the current state of PVCs and PVs before acting, so the adoption can be resumed
+// if it is interrupted midway:
+//  1. set the reclaim policy of PV to Retain, so that the volume will not be deleted with the source PVC.
+//  2. create the target PVC with the volumeName of PV and the migrated-from annotation.
+//  3. delete the source PVC.
+//  4. bind the PV to the target PVC by its claimRef.
+//  5. restore the reclaim policy of PV once the target PVC is bound.
+type ordinalAdopter struct {
+	client client.Client
+	set    *appsv1beta1.StatefulSet
+	// source is nil if the source StatefulSet has been deleted
+	source *appsv1beta1.StatefulSet
+}
+
+func newOrdinalAdopter(c client.Client, set *appsv1beta1.StatefulSet) (*ordinalAdopter, error) {
+	if c == nil {
+		return nil, fmt.Errorf("no client to adopt ordinals from StatefulSet %s", set.Spec.OrdinalMigration.AdoptFrom)
+	}
+	a := &ordinalAdopter{client: c, set: set}
+	source := &appsv1beta1.StatefulSet{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: set.Namespace, Name: set.Spec.OrdinalMigration.AdoptFrom}, source)
+	if err == nil {
+		a.source = source
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	return a, nil
+}
+
+// adopt returns the handoff phase of the ordinal of pod, or empty if the pod can be created.
+func (a *ordinalAdopter) adopt(pod *v1.Pod) (appsv1beta1.OrdinalHandoffPhase, error) {
+	ordinal := getOrdinal(pod)
+	// the source may have been deleted, only its name is used to find the pods and PVCs left
+	sourceSet := &appsv1beta1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: a.set.Namespace, Name: a.set.Spec.OrdinalMigration.AdoptFrom}}
+	released, err := a.isReleased(sourceSet, ordinal)
+	if err != nil {
+		return "", err
+	}
+	if a.source != nil {
+		startOrdinal, endOrdinal, reserveOrdinals := getStatefulSetReplicasRange(a.source)
+		if ordinal >= startOrdinal && ordinal < endOrdinal && !reserveOrdinals.Has(ordinal) {
+			return appsv1beta1.OrdinalHandoffPending, nil
+		}
+	}
+	// wait for the source pod to be deleted, which is still using the PVCs
+	sourcePod := &v1.Pod{}
+	if err := a.client.Get(context.TODO(), types.NamespacedName{Namespace: a.set.Namespace, Name: getPodName(sourceSet, ordinal)}, sourcePod); err == nil {
+		return appsv1beta1.OrdinalHandoffPending, nil
+	} else if !apierrors.IsNotFound(err) {
+		return "", err
+	}
+
+	adopted := true
+	claims := getPersistentVolumeClaims(a.set, pod)
+	for i := range a.set.Spec.VolumeClaimTemplates {
+		template := &a.set.Spec.VolumeClaimTemplates[i]
+		claim := claims[template.Name]
+		done, err := a.adoptClaim(&claim, getPersistentVolumeClaimName(sourceSet, template, ordinal), released)
+		if err != nil {
+			return "", err
+		}
+		adopted = adopted && done
+	}
+	if !adopted {
+		if !released {
+			// the claims of source can not be touched until the source releases the ordinal to this StatefulSet
+			return appsv1beta1.OrdinalHandoffPending, nil
+		}
+		return appsv1beta1.OrdinalHandoffAdopting, nil
+	}
+	return "", nil
+}
+
+// isReleased returns true if the source releases the ordinal to this StatefulSet, which means the source agrees
+// to hand off the PVCs of the ordinal. If the source StatefulSet exists, its spec decides. Otherwise, the release
+// recorded on the source PVCs before the source was deleted is honored.
+func (a *ordinalAdopter) isReleased(sourceSet *appsv1beta1.StatefulSet, ordinal int) (bool, error) {
+	if a.source != nil {
+		if a.source.Spec.OrdinalMigration == nil || a.source.Spec.OrdinalMigration.ReleaseTo != a.set.Name {
+			return false, nil
+		}
+		_, _, reserveOrdinals := getStatefulSetReplicasRange(a.source)
+		return reserveOrdinals.Has(ordinal), nil
+	}
+
+	for i := range a.set.Spec.VolumeClaimTemplates {
+		sourceClaim := &v1.PersistentVolumeClaim{}
+		sourceClaimName := getPersistentVolumeClaimName(sourceSet, &a.set.Spec.VolumeClaimTemplates[i], ordinal)
+		err := a.client.Get(context.TODO(), types.NamespacedName{Namespace: a.set.Namespace, Name: sourceClaimName}, sourceClaim)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		if sourceClaim.Annotations[PVCReleasedToAnnotationKey] != a.set.Name {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// adoptClaim returns true if the target claim is ready to be used by the pod.
+// The source claim and its volume are modified only if the ordinal has been released to this StatefulSet.
+func (a *ordinalAdopter) adoptClaim(claim *v1.PersistentVolumeClaim, sourceClaimName string, released bool) (bool, error) {
+	targetClaim := &v1.PersistentVolumeClaim{}
+	err := a.client.Get(context.TODO(), types.NamespacedName{Namespace: claim.Namespace, Name: claim.Name}, targetClaim)
+	if err == nil {
+		if targetClaim.Annotations[PVCMigratedFromAnnotationKey] == "" {
+			return true, nil
+		}
+		if targetClaim.Status.Phase == v1.ClaimBound {
+			return true, a.restoreReclaimPolicy(targetClaim.Spec.VolumeName)
+		}
+		return false, a.rebindVolume(targetClaim, targetClaim.Annotations[PVCMigratedFromAnnotationKey], released)
+	} else if !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	sourceClaim := &v1.PersistentVolumeClaim{}
+	err = a.client.Get(context.TODO(), types.NamespacedName{Namespace: claim.Namespace, Name: sourceClaimName}, sourceClaim)
+	if apierrors.IsNotFound(err) {
+		// nothing to adopt, a new claim will be created from template
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	if sourceClaim.Spec.VolumeName == "" || sourceClaim.DeletionTimestamp != nil {
+		klog.InfoS("StatefulSet skipped to adopt claim not bound", "statefulSet", klog.KObj(a.set), "claim", klog.KObj(sourceClaim))
+		return true, nil
+	}
+	if !released {
+		klog.V(4).InfoS("StatefulSet waiting for source to release claim", "statefulSet", klog.KObj(a.set), "sourceClaim", klog.KObj(sourceClaim))
+		return false, nil
+	}
+
+	pv := &v1.PersistentVolume{}
+	if err := a.client.Get(context.TODO(), types.NamespacedName{Name: sourceClaim.Spec.VolumeName}, pv); err != nil {
+		return false, err
+	}
+	if pv.Spec.PersistentVolumeReclaimPolicy != v1.PersistentVolumeReclaimRetain {
+		if pv.Annotations == nil {
+			pv.Annotations = map[string]string{}
+		}
+		pv.Annotations[PVReclaimPolicyAnnotationKey] = string(pv.Spec.PersistentVolumeReclaimPolicy)
+		pv.Spec.PersistentVolumeReclaimPolicy = v1.PersistentVolumeReclaimRetain
+		if err := a.client.Update(context.TODO(), pv); err != nil {
+			return false, err
+		}
+	}
+
+	newClaim := claim.DeepCopy()
+	if newClaim.Annotations == nil {
+		newClaim.Annotations = map[string]string{}
+	}
+	newClaim.Annotations[PVCMigratedFromAnnotationKey] = sourceClaim.Name
+	newClaim.Annotations[PVCOwnedByStsAnnotationKey] = a.set.Name
+	newClaim.Spec.VolumeName = pv.Name
+	newClaim.Spec.StorageClassName = sourceClaim.Spec.StorageClassName
+	newClaim.Spec.AccessModes = sourceClaim.Spec.AccessModes
+	newClaim.Spec.VolumeMode = sourceClaim.Spec.VolumeMode
+	newClaim.Spec.Resources = sourceClaim.Spec.Resources
+	if err := a.client.Create(context.TODO(), newClaim); err != nil && !apierrors.IsAlreadyExists(err) {
+		return false, err
+	}
+	klog.V(2).InfoS("StatefulSet created claim to adopt volume", "statefulSet", klog.KObj(a.set),
+		"claim", klog.KObj(newClaim), "sourceClaim", klog.KObj(sourceClaim), "volume", pv.Name)
+	return false, nil
+}
+
+// rebindVolume deletes the source claim and then binds the volume to the target claim.
+// The source claim is deleted only if the ordinal has been released to this StatefulSet.
+func (a *ordinalAdopter) rebindVolume(targetClaim *v1.PersistentVolumeClaim, sourceClaimName string, released bool) error {
+	sourceClaim := &v1.PersistentVolumeClaim{}
+	err := a.client.Get(context.TODO(), types.NamespacedName{Namespace: targetClaim.Namespace, Name: sourceClaimName}, sourceClaim)
+	if err == nil {
+		if sourceClaim.DeletionTimestamp == nil {
+			if !released {
+				klog.V(4).InfoS("StatefulSet waiting for source to release claim", "statefulSet", klog.KObj(a.set), "sourceClaim", klog.KObj(sourceClaim))
+				return nil
+			}
+			klog.V(2).InfoS("StatefulSet deleting source claim adopted", "statefulSet", klog.KObj(a.set), "sourceClaim", klog.KObj(sourceClaim))
+			return client.IgnoreNotFound(a.client.Delete(context.TODO(), sourceClaim))
+		}
+		// wait for the source claim to be gone
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	pv := &v1.PersistentVolume{}
+	if err := a.client.Get(context.TODO(), types.NamespacedName{Name: targetClaim.Spec.VolumeName}, pv); err != nil {
+		return err
+	}
+	if pv.Spec.ClaimRef != nil && pv.Spec.ClaimRef.Namespace == targetClaim.Namespace && pv.Spec.ClaimRef.Name == targetClaim.Name {
+		return nil
+	}
+	pv.Spec.ClaimRef = &v1.ObjectReference{
+		Kind:       "PersistentVolumeClaim",
+		APIVersion: "v1",
+		Namespace:  targetClaim.Namespace,
+		Name:       targetClaim.Name,
+		UID:        targetClaim.UID,
+	}
+	klog.V(2).InfoS("StatefulSet binding volume to claim adopted", "statefulSet", klog.KObj(a.set), "claim", klog.KObj(targetClaim), "volume", pv.Name)
+	return a.client.Update(context.TODO(), pv)
+}
+
+// restoreReclaimPolicy restores the original reclaim policy of the volume adopted.
+func (a *ordinalAdopter) restoreReclaimPolicy(volumeName string) error {
+	pv := &v1.PersistentVolume{}
+	if err := a.client.Get(context.TODO(), types.NamespacedName{Name: volumeName}, pv); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	policy, ok := pv.Annotations[PVReclaimPolicyAnnotationKey]
+	if !ok {
+		return nil
+	}
+	delete(pv.Annotations, PVReclaimPolicyAnnotationKey)
+	pv.Spec.PersistentVolumeReclaimPolicy = v1.PersistentVolumeReclaimPolicy(policy)
+	return a.client.Update(context.TODO(), pv)
+}
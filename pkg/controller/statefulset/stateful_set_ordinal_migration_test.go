@@ -0,0 +1,339 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	kruisefake "github.com/openkruise/kruise/pkg/client/clientset/versioned/fake"
+)
+
+func TestOrdinalAdopter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1.AddToScheme(scheme))
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+
+	newSets := func() (*appsv1beta1.StatefulSet, *appsv1beta1.StatefulSet) {
+		source := newStatefulSetWithVolumes(2, "source", []v1.VolumeMount{{Name: "data", MountPath: "/data"}}, nil)
+		source.Spec.OrdinalMigration = &appsv1beta1.StatefulSetOrdinalMigration{ReleaseTo: "target"}
+		target := newStatefulSetWithVolumes(1, "target", []v1.VolumeMount{{Name: "data", MountPath: "/data"}}, nil)
+		target.Spec.Ordinals = &appsv1beta1.StatefulSetOrdinals{Start: 1}
+		target.Spec.OrdinalMigration = &appsv1beta1.StatefulSetOrdinalMigration{AdoptFrom: "source"}
+		return source, target
+	}
+	newSourceObjects := func() (*v1.PersistentVolumeClaim, *v1.PersistentVolume) {
+		claim := newPVC("data-source-1")
+		claim.Spec.VolumeName = "pv-1"
+		claim.Status.Phase = v1.ClaimBound
+		pv := &v1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+			Spec: v1.PersistentVolumeSpec{
+				PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete,
+				ClaimRef:                      &v1.ObjectReference{Namespace: v1.NamespaceDefault, Name: "data-source-1"},
+			},
+		}
+		return &claim, pv
+	}
+
+	t.Run("wait for source to release", func(t *testing.T) {
+		source, target := newSets()
+		claim, pv := newSourceObjects()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(source, claim, pv).Build()
+		adopter, err := newOrdinalAdopter(c, target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		phase, err := adopter.adopt(newStatefulSetPod(target, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if phase != appsv1beta1.OrdinalHandoffPending {
+			t.Fatalf("expected Pending, got %q", phase)
+		}
+	})
+
+	t.Run("wait for source pod to be deleted", func(t *testing.T) {
+		source, target := newSets()
+		source.Spec.ReserveOrdinals = []intstr.IntOrString{intstr.FromInt32(1)}
+		claim, pv := newSourceObjects()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(source, claim, pv, newStatefulSetPod(source, 1)).Build()
+		adopter, err := newOrdinalAdopter(c, target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		phase, err := adopter.adopt(newStatefulSetPod(target, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if phase != appsv1beta1.OrdinalHandoffPending {
+			t.Fatalf("expected Pending, got %q", phase)
+		}
+	})
+
+	t.Run("adopt claim step by step", func(t *testing.T) {
+		source, target := newSets()
+		source.Spec.ReserveOrdinals = []intstr.IntOrString{intstr.FromInt32(1)}
+		claim, pv := newSourceObjects()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(source, claim, pv).Build()
+		pod := newStatefulSetPod(target, 1)
+
+		adopt := func() appsv1beta1.OrdinalHandoffPhase {
+			// a new adopter in each round, as if the controller was restarted
+			adopter, err := newOrdinalAdopter(c, target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			phase, err := adopter.adopt(pod)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return phase
+		}
+
+		// retain the volume and create the target claim
+		if phase := adopt(); phase != appsv1beta1.OrdinalHandoffAdopting {
+			t.Fatalf("expected Adopting, got %q", phase)
+		}
+		gotPV := &v1.PersistentVolume{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: "pv-1"}, gotPV); err != nil {
+			t.Fatal(err)
+		}
+		if gotPV.Spec.PersistentVolumeReclaimPolicy != v1.PersistentVolumeReclaimRetain ||
+			gotPV.Annotations[PVReclaimPolicyAnnotationKey] != string(v1.PersistentVolumeReclaimDelete) {
+			t.Fatalf("expected volume retained, got %+v", gotPV)
+		}
+		targetClaim := &v1.PersistentVolumeClaim{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: v1.NamespaceDefault, Name: "data-target-1"}, targetClaim); err != nil {
+			t.Fatal(err)
+		}
+		if targetClaim.Spec.VolumeName != "pv-1" || targetClaim.Annotations[PVCMigratedFromAnnotationKey] != "data-source-1" {
+			t.Fatalf("unexpected target claim %+v", targetClaim)
+		}
+
+		// delete the source claim
+		if phase := adopt(); phase != appsv1beta1.OrdinalHandoffAdopting {
+			t.Fatalf("expected Adopting, got %q", phase)
+		}
+		if err := c.Get(context.TODO(), client.ObjectKeyFromObject(claim), &v1.PersistentVolumeClaim{}); !apierrors.IsNotFound(err) {
+			t.Fatalf("expected source claim deleted, got %v", err)
+		}
+
+		// bind the volume to the target claim
+		if phase := adopt(); phase != appsv1beta1.OrdinalHandoffAdopting {
+			t.Fatalf("expected Adopting, got %q", phase)
+		}
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: "pv-1"}, gotPV); err != nil {
+			t.Fatal(err)
+		}
+		if gotPV.Spec.ClaimRef == nil || gotPV.Spec.ClaimRef.Name != "data-target-1" {
+			t.Fatalf("expected volume bound to target claim, got %+v", gotPV.Spec.ClaimRef)
+		}
+
+		// restore the reclaim policy once the target claim is bound
+		if err := c.Get(context.TODO(), client.ObjectKeyFromObject(targetClaim), targetClaim); err != nil {
+			t.Fatal(err)
+		}
+		targetClaim.Status.Phase = v1.ClaimBound
+		if err := c.Status().Update(context.TODO(), targetClaim); err != nil {
+			t.Fatal(err)
+		}
+		if phase := adopt(); phase != "" {
+			t.Fatalf("expected adopted, got %q", phase)
+		}
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: "pv-1"}, gotPV); err != nil {
+			t.Fatal(err)
+		}
+		if gotPV.Spec.PersistentVolumeReclaimPolicy != v1.PersistentVolumeReclaimDelete {
+			t.Fatalf("expected reclaim policy restored, got %v", gotPV.Spec.PersistentVolumeReclaimPolicy)
+		}
+		if _, ok := gotPV.Annotations[PVReclaimPolicyAnnotationKey]; ok {
+			t.Fatalf("expected annotation of reclaim policy removed")
+		}
+	})
+
+	t.Run("refuse to adopt without the consent of source", func(t *testing.T) {
+		cases := []struct {
+			name       string
+			withSource bool
+			releaseTo  string
+		}{
+			{name: "source released to another StatefulSet", withSource: true, releaseTo: "other"},
+			{name: "source deleted without recording the release", withSource: false},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				source, target := newSets()
+				source.Spec.ReserveOrdinals = []intstr.IntOrString{intstr.FromInt32(1)}
+				source.Spec.OrdinalMigration.ReleaseTo = tc.releaseTo
+				claim, pv := newSourceObjects()
+				objects := []client.Object{claim, pv}
+				if tc.withSource {
+					objects = append(objects, source)
+				}
+				c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+				adopter, err := newOrdinalAdopter(c, target)
+				if err != nil {
+					t.Fatal(err)
+				}
+				phase, err := adopter.adopt(newStatefulSetPod(target, 1))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if phase != appsv1beta1.OrdinalHandoffPending {
+					t.Fatalf("expected Pending, got %q", phase)
+				}
+				gotPV := &v1.PersistentVolume{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: "pv-1"}, gotPV); err != nil {
+					t.Fatal(err)
+				}
+				if gotPV.Spec.PersistentVolumeReclaimPolicy != v1.PersistentVolumeReclaimDelete {
+					t.Fatalf("expected volume untouched, got %+v", gotPV)
+				}
+				if err := c.Get(context.TODO(), types.NamespacedName{Namespace: v1.NamespaceDefault, Name: "data-target-1"}, &v1.PersistentVolumeClaim{}); !apierrors.IsNotFound(err) {
+					t.Fatalf("expected no target claim, got %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("keep source claim if release is revoked during adoption", func(t *testing.T) {
+		source, target := newSets()
+		// the source has scaled down without releasing ordinal 1 to the target any longer
+		source.Spec.Replicas = ptr.To[int32](1)
+		source.Spec.OrdinalMigration = nil
+		claim, pv := newSourceObjects()
+		targetClaim := newPVC("data-target-1")
+		targetClaim.Annotations = map[string]string{PVCMigratedFromAnnotationKey: "data-source-1"}
+		targetClaim.Spec.VolumeName = "pv-1"
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(source, claim, pv, &targetClaim).Build()
+		adopter, err := newOrdinalAdopter(c, target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		phase, err := adopter.adopt(newStatefulSetPod(target, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if phase != appsv1beta1.OrdinalHandoffPending {
+			t.Fatalf("expected Pending, got %q", phase)
+		}
+		if err := c.Get(context.TODO(), client.ObjectKeyFromObject(claim), &v1.PersistentVolumeClaim{}); err != nil {
+			t.Fatalf("expected source claim kept, got %v", err)
+		}
+	})
+
+	t.Run("adopt claim released before source deleted", func(t *testing.T) {
+		_, target := newSets()
+		claim, pv := newSourceObjects()
+		claim.Annotations = map[string]string{PVCReleasedToAnnotationKey: "target"}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(claim, pv).Build()
+		adopter, err := newOrdinalAdopter(c, target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		phase, err := adopter.adopt(newStatefulSetPod(target, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if phase != appsv1beta1.OrdinalHandoffAdopting {
+			t.Fatalf("expected Adopting, got %q", phase)
+		}
+		targetClaim := &v1.PersistentVolumeClaim{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: v1.NamespaceDefault, Name: "data-target-1"}, targetClaim); err != nil {
+			t.Fatal(err)
+		}
+		if targetClaim.Spec.VolumeName != "pv-1" {
+			t.Fatalf("unexpected target claim %+v", targetClaim)
+		}
+	})
+
+	t.Run("nothing to adopt", func(t *testing.T) {
+		_, target := newSets()
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		adopter, err := newOrdinalAdopter(c, target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		phase, err := adopter.adopt(newStatefulSetPod(target, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if phase != "" {
+			t.Fatalf("expected adopted, got %q", phase)
+		}
+	})
+}
+
+func TestRecordClaimsReleasedTo(t *testing.T) {
+	source := newStatefulSetWithVolumes(2, "source", []v1.VolumeMount{{Name: "data", MountPath: "/data"}}, nil)
+	source.Spec.ReserveOrdinals = []intstr.IntOrString{intstr.FromInt32(1)}
+	source.Spec.OrdinalMigration = &appsv1beta1.StatefulSetOrdinalMigration{ReleaseTo: "target"}
+
+	client := k8sfake.NewSimpleClientset()
+	kruiseClient := kruisefake.NewSimpleClientset(source)
+	om, _, ssc, stop := setupController(client, kruiseClient)
+	defer close(stop)
+
+	// the claim of ordinal 0 was released before, but the ordinal is in use again
+	claim0 := newPVC("data-source-0")
+	claim0.Annotations = map[string]string{PVCReleasedToAnnotationKey: "target"}
+	claim1 := newPVC("data-source-1")
+	for _, claim := range []*v1.PersistentVolumeClaim{&claim0, &claim1} {
+		if err := om.claimsIndexer.Add(claim); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pods := []*v1.Pod{newStatefulSetPod(source, 0)}
+	handoffs, err := ssc.(*defaultStatefulSetControl).getReleasedOrdinalHandoffs(source, pods, sets.New[int](1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(handoffs) != 1 || handoffs[0].Ordinal != 1 || handoffs[0].Phase != appsv1beta1.OrdinalHandoffReleased {
+		t.Fatalf("unexpected handoffs %+v", handoffs)
+	}
+
+	got, err := om.GetClaim(v1.NamespaceDefault, "data-source-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Annotations[PVCReleasedToAnnotationKey] != "target" {
+		t.Fatalf("expected release recorded on claim, got %v", got.Annotations)
+	}
+	got, err = om.GetClaim(v1.NamespaceDefault, "data-source-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Annotations[PVCReleasedToAnnotationKey]; ok {
+		t.Fatalf("expected release removed from claim in use, got %v", got.Annotations)
+	}
+}
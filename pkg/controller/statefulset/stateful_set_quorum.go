@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+// quorumGuard prevents the pods from being disrupted when the available members would drop below the quorum.
+// It is safe for concurrent use, because condemned pods are processed in parallel in burst mode.
+type quorumGuard struct {
+	mu     sync.Mutex
+	quorum int
+	// available is the available members that have not been disrupted
+	available sets.String
+}
+
+// newQuorumGuard returns nil if spec.quorumGuard is not set.
+// The quorum is calculated from the target members, which is spec.replicas, so that scaling down never waits for
+// a quorum that the remaining members can not satisfy. The condemned pods are still available members until deleted.
+func newQuorumGuard(set *appsv1beta1.StatefulSet, replicas, condemned []*v1.Pod, minReadySeconds int32) (*quorumGuard, error) {
+	if set.Spec.QuorumGuard == nil {
+		return nil, nil
+	}
+
+	available := sets.NewString()
+	for _, pods := range [][]*v1.Pod{replicas, condemned} {
+		for _, pod := range pods {
+			if pod == nil || !isCreated(pod) || isTerminating(pod) {
+				continue
+			}
+			if avail, _ := isRunningAndAvailable(pod, minReadySeconds); avail {
+				available.Insert(pod.Name)
+			}
+		}
+	}
+
+	var members int
+	if set.Spec.Replicas != nil {
+		members = int(*set.Spec.Replicas)
+	}
+	quorum := 0
+	if members > 0 {
+		quorum = members/2 + 1
+	}
+	if set.Spec.QuorumGuard.Quorum != nil {
+		var err error
+		if quorum, err = intstrutil.GetScaledValueFromIntOrPercent(set.Spec.QuorumGuard.Quorum, members, true); err != nil {
+			return nil, err
+		}
+		if quorum > members {
+			quorum = members
+		}
+	}
+	return &quorumGuard{quorum: quorum, available: available}, nil
+}
+
+// headroom returns the number of available members minus the quorum.
+func (g *quorumGuard) headroom() int32 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return int32(g.available.Len() - g.quorum)
+}
+
+// tryDisrupt returns whether the pod can be disrupted without breaking the quorum,
+// and takes it out of the available members if so.
+func (g *quorumGuard) tryDisrupt(pod *v1.Pod) bool {
+	if g == nil {
+		return true
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.available.Has(pod.Name) {
+		return true
+	}
+	if g.available.Len()-1 < g.quorum {
+		return false
+	}
+	g.available.Delete(pod.Name)
+	return true
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func TestQuorumGuard(t *testing.T) {
+	newPod := func(name string, ready, terminating bool) *v1.Pod {
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: v1.PodStatus{Phase: v1.PodRunning}}
+		if ready {
+			pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+		}
+		if terminating {
+			pod.DeletionTimestamp = ptr.To(metav1.Now())
+		}
+		return pod
+	}
+
+	cases := []struct {
+		name             string
+		replicas         int32
+		quorum           *intstr.IntOrString
+		pods             []*v1.Pod
+		condemned        []*v1.Pod
+		expectedHeadroom int32
+		expectedDisrupt  []string
+	}{
+		{
+			name:             "majority of five members",
+			replicas:         5,
+			pods:             []*v1.Pod{newPod("p0", true, false), newPod("p1", true, false), newPod("p2", true, false), newPod("p3", true, false), newPod("p4", false, false)},
+			expectedHeadroom: 1,
+			expectedDisrupt:  []string{"p0", "p4"},
+		},
+		{
+			name:             "scale down from five to three",
+			replicas:         3,
+			pods:             []*v1.Pod{newPod("p0", true, false), newPod("p1", true, false), newPod("p2", true, false)},
+			condemned:        []*v1.Pod{newPod("p4", true, false), newPod("p3", true, false)},
+			expectedHeadroom: 3,
+			expectedDisrupt:  []string{"p4", "p3", "p0"},
+		},
+		{
+			name:             "scale down from three to one",
+			replicas:         1,
+			pods:             []*v1.Pod{newPod("p0", true, false)},
+			condemned:        []*v1.Pod{newPod("p2", true, false), newPod("p1", true, false)},
+			expectedHeadroom: 2,
+			expectedDisrupt:  []string{"p2", "p1"},
+		},
+		{
+			name:             "scale down from three to one with the last condemned pod",
+			replicas:         1,
+			pods:             []*v1.Pod{newPod("p0", true, false)},
+			condemned:        []*v1.Pod{newPod("p1", true, false)},
+			expectedHeadroom: 1,
+			expectedDisrupt:  []string{"p1"},
+		},
+		{
+			name:             "scale down from one to zero",
+			replicas:         0,
+			condemned:        []*v1.Pod{newPod("p0", true, false)},
+			expectedHeadroom: 1,
+			expectedDisrupt:  []string{"p0"},
+		},
+		{
+			name:             "absolute quorum more than replicas",
+			replicas:         1,
+			quorum:           ptr.To(intstr.FromInt32(3)),
+			pods:             []*v1.Pod{newPod("p0", true, false)},
+			condemned:        []*v1.Pod{newPod("p1", true, false)},
+			expectedHeadroom: 1,
+			expectedDisrupt:  []string{"p1"},
+		},
+		{
+			name:             "terminating pod is not a member",
+			replicas:         3,
+			quorum:           ptr.To(intstr.FromString("50%")),
+			pods:             []*v1.Pod{newPod("p0", true, false), newPod("p1", true, false), newPod("p2", true, true)},
+			expectedHeadroom: 0,
+			expectedDisrupt:  []string{"p2"},
+		},
+		{
+			name:             "quorum broken",
+			replicas:         3,
+			quorum:           ptr.To(intstr.FromInt32(3)),
+			pods:             []*v1.Pod{newPod("p0", true, false), newPod("p1", false, false), newPod("p2", true, false)},
+			expectedHeadroom: -1,
+			expectedDisrupt:  []string{"p1"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			set := &appsv1beta1.StatefulSet{Spec: appsv1beta1.StatefulSetSpec{
+				Replicas:    ptr.To(tc.replicas),
+				QuorumGuard: &appsv1beta1.StatefulSetQuorumGuard{Quorum: tc.quorum},
+			}}
+			guard, err := newQuorumGuard(set, tc.pods, tc.condemned, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if headroom := guard.headroom(); headroom != tc.expectedHeadroom {
+				t.Fatalf("expected headroom %d, got %d", tc.expectedHeadroom, headroom)
+			}
+			// condemned pods are processed before the replicas
+			disrupted := sets.NewString()
+			for _, pod := range append(append([]*v1.Pod{}, tc.condemned...), tc.pods...) {
+				if guard.tryDisrupt(pod) {
+					disrupted.Insert(pod.Name)
+				}
+			}
+			if expected := sets.NewString(tc.expectedDisrupt...); !disrupted.Equal(expected) {
+				t.Fatalf("expected disrupted pods %v, got %v", expected.List(), disrupted.List())
+			}
+		})
+	}
+}
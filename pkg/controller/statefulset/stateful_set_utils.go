@@ -28,6 +28,7 @@ import (
 
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -37,6 +38,7 @@ import (
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	"k8s.io/kubernetes/pkg/controller"
 	"k8s.io/kubernetes/pkg/controller/history"
+	"k8s.io/utils/ptr"
 
 	appspub "github.com/openkruise/kruise/apis/apps/pub"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
@@ -493,13 +495,41 @@ func getPodRevision(pod *v1.Pod) string {
 
 // newStatefulSetPod returns a new Pod conforming to the set's Spec with an identity generated from ordinal.
 func newStatefulSetPod(set *appsv1beta1.StatefulSet, ordinal int) *v1.Pod {
-	pod, _ := controller.GetPodFromTemplate(&set.Spec.Template, set, metav1.NewControllerRef(set, controllerKind))
+	template, err := getOrdinalPodTemplate(set, ordinal)
+	if err != nil {
+		klog.ErrorS(err, "Failed to apply ordinal patch to pod template", "statefulSet", klog.KObj(set), "ordinal", ordinal)
+		template = &set.Spec.Template
+	}
+	pod, _ := controller.GetPodFromTemplate(template, set, metav1.NewControllerRef(set, controllerKind))
 	pod.Name = getPodName(set, ordinal)
 	initIdentity(set, pod)
 	updateStorage(set, pod)
 	return pod
 }
 
+// getOrdinalPodTemplate returns the pod template of set with the strategic merge patch in spec.ordinalPatches
+// applied for the ordinal. The patches are not recorded in revisions, so the pods built from the patched
+// template are labeled with the same revision as the others.
+func getOrdinalPodTemplate(set *appsv1beta1.StatefulSet, ordinal int) (*v1.PodTemplateSpec, error) {
+	patch, ok := set.Spec.OrdinalPatches[strconv.Itoa(ordinal)]
+	if !ok || len(patch.Raw) == 0 {
+		return &set.Spec.Template, nil
+	}
+	templateBytes, err := json.Marshal(set.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := strategicpatch.StrategicMergePatch(templateBytes, patch.Raw, &v1.PodTemplateSpec{})
+	if err != nil {
+		return nil, err
+	}
+	template := &v1.PodTemplateSpec{}
+	if err := json.Unmarshal(modified, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
 // newVersionedStatefulSetPod creates a new Pod for a StatefulSet. currentSet is the representation of the set at the
 // current revision. updateSet is the representation of the set at the updateRevision. currentRevision is the name of
 // the current revision. updateRevision is the name of the update revision. ordinal is the ordinal of the Pod. If the
@@ -652,7 +682,9 @@ func inconsistentStatus(set *appsv1beta1.StatefulSet, status *appsv1beta1.Statef
 		status.UpdatedReplicas != set.Status.UpdatedReplicas ||
 		status.CurrentRevision != set.Status.CurrentRevision ||
 		status.UpdateRevision != set.Status.UpdateRevision ||
-		status.LabelSelector != set.Status.LabelSelector {
+		status.LabelSelector != set.Status.LabelSelector ||
+		!ptr.Equal(status.QuorumHeadroom, set.Status.QuorumHeadroom) ||
+		!apiequality.Semantic.DeepEqual(status.OrdinalHandoffs, set.Status.OrdinalHandoffs) {
 		return true
 	}
 
@@ -665,7 +697,8 @@ func inconsistentStatus(set *appsv1beta1.StatefulSet, status *appsv1beta1.Statef
 			// raw template not exist in current status => inconsistent
 			return true
 		} else if status.VolumeClaims[idx].CompatibleReplicas != v.CompatibleReplicas ||
-			status.VolumeClaims[idx].CompatibleReadyReplicas != v.CompatibleReadyReplicas {
+			status.VolumeClaims[idx].CompatibleReadyReplicas != v.CompatibleReadyReplicas ||
+			status.VolumeClaims[idx].RecreatingReplicas != v.RecreatingReplicas {
 			return true
 		}
 	}
@@ -796,6 +796,38 @@ func TestNewPodControllerRef(t *testing.T) {
 	}
 }
 
+func TestNewStatefulSetPodWithOrdinalPatches(t *testing.T) {
+	set := newStatefulSet(3)
+	set.Status.CollisionCount = new(int32)
+	revision, err := newRevision(set, 1, set.Status.CollisionCount)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set.Spec.OrdinalPatches = map[string]runtime.RawExtension{
+		"0": {Raw: []byte(`{"spec":{"containers":[{"name":"nginx","resources":{"limits":{"memory":"2Gi"}}}],"nodeSelector":{"disk":"ssd"}}}`)},
+	}
+	if matched, err := Match(set, revision); err != nil || !matched {
+		t.Fatalf("expected ordinal patches not in revision, got %v %v", matched, err)
+	}
+
+	pod := newVersionedStatefulSetPod(set, set, revision.Name, revision.Name, 0, nil)
+	if got := pod.Spec.Containers[0].Resources.Limits.Memory().String(); got != "2Gi" {
+		t.Errorf("expected memory limit patched, got %s", got)
+	}
+	if pod.Spec.NodeSelector["disk"] != "ssd" || pod.Spec.Containers[0].Image != "nginx" || len(pod.Spec.Containers[0].VolumeMounts) != 2 {
+		t.Errorf("expected patch merged into template, got %+v", pod.Spec)
+	}
+	if getPodRevision(pod) != revision.Name {
+		t.Errorf("expected revision %s, got %s", revision.Name, getPodRevision(pod))
+	}
+
+	pod = newStatefulSetPod(set, 1)
+	if !reflect.DeepEqual(pod.Spec.Containers, set.Spec.Template.Spec.Containers) || pod.Spec.NodeSelector != nil {
+		t.Errorf("expected pod of ordinal 1 not patched, got %+v", pod.Spec)
+	}
+}
+
 func TestCreateApplyRevision(t *testing.T) {
 	set := newStatefulSet(1)
 	set.Status.CollisionCount = new(int32)
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+// topologyUpdateLimiter limits the number of unavailable pods in each topology domain during update.
+type topologyUpdateLimiter struct {
+	maxUnavailable *intstrutil.IntOrString
+	// podDomains is the topology domain of each pod, pods not scheduled are not in it
+	podDomains map[string]string
+	// domainReplicas is the number of pods in each topology domain
+	domainReplicas map[string]int
+	// unavailable is the unavailable pods in each topology domain
+	unavailable map[string]sets.String
+	// unscheduledUnavailable is the unavailable pods not scheduled, they are counted in all the domains
+	unscheduledUnavailable sets.String
+}
+
+// newTopologyUpdateLimiter returns nil if spec.updateStrategy.rollingUpdate.topologyUpdateConstraint is not set.
+func newTopologyUpdateLimiter(reader client.Reader, set *appsv1beta1.StatefulSet, replicas []*v1.Pod, unavailablePods sets.String) (*topologyUpdateLimiter, error) {
+	if set.Spec.UpdateStrategy.RollingUpdate == nil || set.Spec.UpdateStrategy.RollingUpdate.TopologyUpdateConstraint == nil {
+		return nil, nil
+	}
+	if reader == nil {
+		return nil, fmt.Errorf("no client to get nodes for topologyUpdateConstraint")
+	}
+	constraint := set.Spec.UpdateStrategy.RollingUpdate.TopologyUpdateConstraint
+	l := &topologyUpdateLimiter{
+		maxUnavailable:         intstrutil.ValueOrDefault(constraint.MaxUnavailable, intstrutil.FromInt(1)),
+		podDomains:             map[string]string{},
+		domainReplicas:         map[string]int{},
+		unavailable:            map[string]sets.String{},
+		unscheduledUnavailable: sets.NewString(),
+	}
+
+	nodeDomains := map[string]string{}
+	for _, pod := range replicas {
+		if pod == nil || pod.Spec.NodeName == "" {
+			continue
+		}
+		domain, ok := nodeDomains[pod.Spec.NodeName]
+		if !ok {
+			node := &v1.Node{}
+			if err := reader.Get(context.TODO(), types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			}
+			domain = node.Labels[constraint.TopologyKey]
+			nodeDomains[pod.Spec.NodeName] = domain
+		}
+		l.podDomains[pod.Name] = domain
+		l.domainReplicas[domain]++
+	}
+
+	for _, pod := range replicas {
+		if pod != nil && unavailablePods.Has(pod.Name) {
+			l.markUnavailable(pod)
+		}
+	}
+	return l, nil
+}
+
+// allow returns whether the pod can be updated without exceeding the limit of its topology domain.
+func (l *topologyUpdateLimiter) allow(pod *v1.Pod) bool {
+	if l == nil || l.unscheduledUnavailable.Has(pod.Name) {
+		return true
+	}
+	domain, ok := l.podDomains[pod.Name]
+	if !ok {
+		// the pod not scheduled can be updated only if no pod is unavailable
+		if l.unscheduledUnavailable.Len() > 0 {
+			return false
+		}
+		for _, pods := range l.unavailable {
+			if pods.Len() > 0 {
+				return false
+			}
+		}
+		return true
+	}
+	if l.unavailable[domain].Has(pod.Name) {
+		return true
+	}
+	limit, _ := intstrutil.GetScaledValueFromIntOrPercent(l.maxUnavailable, l.domainReplicas[domain], false)
+	if limit < 1 {
+		limit = 1
+	}
+	return l.unavailable[domain].Len()+l.unscheduledUnavailable.Len() < limit
+}
+
+// markUnavailable records the pod as unavailable in its topology domain.
+func (l *topologyUpdateLimiter) markUnavailable(pod *v1.Pod) {
+	if l == nil {
+		return
+	}
+	domain, ok := l.podDomains[pod.Name]
+	if !ok {
+		l.unscheduledUnavailable.Insert(pod.Name)
+		return
+	}
+	if l.unavailable[domain] == nil {
+		l.unavailable[domain] = sets.NewString()
+	}
+	l.unavailable[domain].Insert(pod.Name)
+}
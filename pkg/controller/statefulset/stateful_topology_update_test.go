@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func TestTopologyUpdateLimiter(t *testing.T) {
+	const zoneKey = "topology.kubernetes.io/zone"
+	newNode := func(name, zone string) *v1.Node {
+		return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{zoneKey: zone}}}
+	}
+	newPod := func(name, nodeName string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: v1.PodSpec{NodeName: nodeName}}
+	}
+	reader := fake.NewClientBuilder().WithObjects(newNode("n1", "a"), newNode("n2", "a"), newNode("n3", "b")).Build()
+	replicas := []*v1.Pod{newPod("p0", "n1"), newPod("p1", "n2"), newPod("p2", "n3"), newPod("p3", "n3"), newPod("p4", "")}
+
+	cases := []struct {
+		name           string
+		maxUnavailable *intstr.IntOrString
+		unavailable    []string
+		updated        []string
+		expectedAllow  sets.String
+	}{
+		{
+			name:          "one pod per zone",
+			unavailable:   []string{"p0"},
+			expectedAllow: sets.NewString("p0", "p2", "p3"),
+		},
+		{
+			name:          "zone blocked after update",
+			unavailable:   []string{"p0"},
+			updated:       []string{"p2"},
+			expectedAllow: sets.NewString("p0", "p2"),
+		},
+		{
+			name:           "percentage of pods in zone",
+			maxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "100%"},
+			unavailable:    []string{"p0"},
+			expectedAllow:  sets.NewString("p0", "p1", "p2", "p3"),
+		},
+		{
+			name:          "unscheduled pod counted in all zones",
+			unavailable:   []string{"p4"},
+			expectedAllow: sets.NewString("p4"),
+		},
+		{
+			name:          "no unavailable pods",
+			expectedAllow: sets.NewString("p0", "p1", "p2", "p3", "p4"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			set := &appsv1beta1.StatefulSet{Spec: appsv1beta1.StatefulSetSpec{UpdateStrategy: appsv1beta1.StatefulSetUpdateStrategy{
+				RollingUpdate: &appsv1beta1.RollingUpdateStatefulSetStrategy{
+					TopologyUpdateConstraint: &appsv1beta1.TopologyUpdateConstraint{TopologyKey: zoneKey, MaxUnavailable: tc.maxUnavailable},
+				},
+			}}}
+			limiter, err := newTopologyUpdateLimiter(reader, set, replicas, sets.NewString(tc.unavailable...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, pod := range replicas {
+				if sets.NewString(tc.updated...).Has(pod.Name) {
+					limiter.markUnavailable(pod)
+				}
+			}
+			allowed := sets.NewString()
+			for _, pod := range replicas {
+				if limiter.allow(pod) {
+					allowed.Insert(pod.Name)
+				}
+			}
+			if !allowed.Equal(tc.expectedAllow) {
+				t.Fatalf("expected allowed pods %v, got %v", tc.expectedAllow.List(), allowed.List())
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util/pvc"
+)
+
+// isVolumeClaimRecreateEnabled returns true if the volume claims can be deleted and recreated during rolling update.
+func isVolumeClaimRecreateEnabled(set *appsv1beta1.StatefulSet) bool {
+	return set.Spec.VolumeClaimUpdateStrategy.Type == appsv1beta1.OnPodRollingUpdateVolumeClaimUpdateStrategyType &&
+		set.Spec.VolumeClaimUpdateStrategy.DeletePolicy == appsv1beta1.OnPodRollingUpdateVolumeClaimDeletePolicyType
+}
+
+// isClaimPreparingDelete returns true if the claim has been marked to be deleted for recreation.
+func isClaimPreparingDelete(claim *v1.PersistentVolumeClaim) bool {
+	return claim.Labels[appspub.LifecycleStateKey] == string(appspub.LifecycleStatePreparingDelete)
+}
+
+// isClaimHooked returns true if the claim still has any labels or finalizers in the hook.
+func isClaimHooked(hook *appspub.LifecycleHook, claim *v1.PersistentVolumeClaim) bool {
+	if hook == nil {
+		return false
+	}
+	for _, f := range hook.FinalizersHandler {
+		if controllerutil.ContainsFinalizer(claim, f) {
+			return true
+		}
+	}
+	for k, v := range hook.LabelsHandler {
+		if claim.Labels[k] == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOwnedPVCsRecreating checks if any PVC associated with the given Pod is being deleted for recreation.
+func (spc *StatefulPodControl) IsOwnedPVCsRecreating(set *appsv1beta1.StatefulSet, pod *v1.Pod) (bool, error) {
+	checkFn := func(claim, template *v1.PersistentVolumeClaim) (bool, error) {
+		return !isClaimPreparingDelete(claim), nil
+	}
+	notRecreating, err := spc.handlePVCWithCustomFn(set, pod, false, checkFn)
+	return !notRecreating, err
+}
+
+// TryRecreatePVC deletes the PVCs associated with the given Pod which are not compatible with the templates
+// in storageClassName or accessModes, so that they will be recreated from the templates together with the Pod.
+// The PVCs are labeled as PreparingDelete first, and will not be deleted until the preDeleteHook is removed.
+// Returns:
+// - A boolean indicating whether any PVC needs to be recreated.
+// - A boolean indicating whether all the PVCs to be recreated have been deleted.
+// - An error if there was an issue updating or deleting the PVCs.
+func (spc *StatefulPodControl) TryRecreatePVC(set *appsv1beta1.StatefulSet, pod *v1.Pod) (bool, bool, error) {
+	ordinal := getOrdinal(pod)
+	templates := set.Spec.VolumeClaimTemplates
+	var claimsToDelete []*v1.PersistentVolumeClaim
+	needRecreate, hooked := false, false
+	for i := range templates {
+		claimName := getPersistentVolumeClaimName(set, &templates[i], ordinal)
+		claim, err := spc.objectMgr.GetClaim(set.Namespace, claimName)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, false, fmt.Errorf("could not retrieve claim %s for %s when recreating PVC: %w", claimName, pod.Name, err)
+		}
+
+		if claim.DeletionTimestamp != nil {
+			// the claim deleted for recreation is waiting for the pod to release it
+			if isClaimPreparingDelete(claim) {
+				needRecreate = true
+			}
+			continue
+		}
+		if !isClaimPreparingDelete(claim) && pvc.IsClaimCompatibleWithoutSize(claim, &templates[i]) {
+			continue
+		}
+		needRecreate = true
+
+		if !isClaimPreparingDelete(claim) {
+			claimClone := claim.DeepCopy()
+			if claimClone.Labels == nil {
+				claimClone.Labels = map[string]string{}
+			}
+			if claimClone.Annotations == nil {
+				claimClone.Annotations = map[string]string{}
+			}
+			claimClone.Labels[appspub.LifecycleStateKey] = string(appspub.LifecycleStatePreparingDelete)
+			claimClone.Annotations[PVCOwnedByStsAnnotationKey] = set.Name
+			if err := spc.objectMgr.UpdateClaim(claimClone); err != nil {
+				return true, false, fmt.Errorf("could not update claim %s to PreparingDelete: %w", claimName, err)
+			}
+			klog.V(3).InfoS("StatefulSet updated claim to PreparingDelete for recreation", "statefulSet", klog.KObj(set), "claim", klog.KObj(claim))
+			claim = claimClone
+		}
+		if isClaimHooked(set.Spec.VolumeClaimUpdateStrategy.PreDeleteHook, claim) {
+			klog.V(4).InfoS("StatefulSet was waiting for preDeleteHook of claim", "statefulSet", klog.KObj(set), "claim", klog.KObj(claim))
+			hooked = true
+			continue
+		}
+		claimsToDelete = append(claimsToDelete, claim)
+	}
+
+	// delete the claims of a pod together after all of them have passed the hook
+	if hooked {
+		return needRecreate, false, nil
+	}
+	for _, claim := range claimsToDelete {
+		err := spc.objectMgr.DeleteClaim(claim)
+		spc.recordClaimEvent("delete", set, pod, claim, err)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return true, false, fmt.Errorf("could not delete claim %s for recreation: %w", claim.Name, err)
+		}
+	}
+	return needRecreate, needRecreate, nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	kruisefake "github.com/openkruise/kruise/pkg/client/clientset/versioned/fake"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+func TestTryRecreatePVC(t *testing.T) {
+	sc1 := newStorageClass("sc1", true)
+	sc2 := newStorageClass("sc2", true)
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "foo-0"}}
+
+	testCases := []struct {
+		name                 string
+		hook                 *appspub.LifecycleHook
+		pvc2SC               string
+		pvc2Labels           map[string]string
+		expectedNeedRecreate bool
+		expectedDeleted      bool
+	}{
+		{
+			name:   "compatible pvcs",
+			pvc2SC: sc1.Name,
+		},
+		{
+			name:                 "storage class changed",
+			pvc2SC:               sc2.Name,
+			expectedNeedRecreate: true,
+			expectedDeleted:      true,
+		},
+		{
+			name:                 "storage class changed and waiting for hook",
+			hook:                 &appspub.LifecycleHook{LabelsHandler: map[string]string{"snapshot": "pending"}},
+			pvc2SC:               sc2.Name,
+			pvc2Labels:           map[string]string{"snapshot": "pending"},
+			expectedNeedRecreate: true,
+		},
+		{
+			name:                 "storage class changed and hook removed",
+			hook:                 &appspub.LifecycleHook{LabelsHandler: map[string]string{"snapshot": "pending"}},
+			pvc2SC:               sc2.Name,
+			pvc2Labels:           map[string]string{"snapshot": "done"},
+			expectedNeedRecreate: true,
+			expectedDeleted:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			set := newStatefulSetWithGivenSC(5, 2, []*string{&sc1.Name, &sc1.Name})
+			set.Spec.VolumeClaimUpdateStrategy = appsv1beta1.VolumeClaimUpdateStrategy{
+				Type:          appsv1beta1.OnPodRollingUpdateVolumeClaimUpdateStrategyType,
+				DeletePolicy:  appsv1beta1.OnPodRollingUpdateVolumeClaimDeletePolicyType,
+				PreDeleteHook: tc.hook,
+			}
+			pvc1 := newTestPVCWithSC("datadir-0-foo-0", &sc1.Name, true, true, nil)
+			pvc2 := newTestPVCWithSC("datadir-1-foo-0", &tc.pvc2SC, true, true, nil)
+			pvc2.Labels = tc.pvc2Labels
+			client := fake.NewSimpleClientset(&sc1, &sc2, &pvc1, &pvc2)
+			kruiseClient := kruisefake.NewSimpleClientset(set)
+			om, _, _, stop := setupController(client, kruiseClient)
+			defer close(stop)
+
+			spc := NewStatefulPodControlFromManager(om, &noopRecorder{})
+			needRecreate, deleted, err := spc.TryRecreatePVC(set, pod)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedNeedRecreate, needRecreate)
+			assert.Equal(t, tc.expectedDeleted, deleted)
+
+			recreating, err := spc.IsOwnedPVCsRecreating(set, pod)
+			assert.Nil(t, err)
+			_, getErr := om.GetClaim(pvc2.Namespace, pvc2.Name)
+			if tc.expectedDeleted {
+				assert.NotNil(t, getErr)
+			} else {
+				assert.Nil(t, getErr)
+				// the pvc waiting for hook is labeled as PreparingDelete
+				assert.Equal(t, tc.expectedNeedRecreate, recreating)
+			}
+		})
+	}
+}
+
+func TestRecreatePVCBlockedByQuorum(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.StatefulSetAutoResizePVCGate, true)()
+
+	sc1 := newStorageClass("sc1", true)
+	sc2 := newStorageClass("sc2", true)
+	set := newStatefulSetWithGivenSC(3, 1, []*string{&sc1.Name})
+	set.Spec.VolumeClaimUpdateStrategy = appsv1beta1.VolumeClaimUpdateStrategy{
+		Type:         appsv1beta1.OnPodRollingUpdateVolumeClaimUpdateStrategyType,
+		DeletePolicy: appsv1beta1.OnPodRollingUpdateVolumeClaimDeletePolicyType,
+	}
+	quorum := intstr.FromInt32(3)
+	set.Spec.QuorumGuard = &appsv1beta1.StatefulSetQuorumGuard{Quorum: &quorum}
+
+	client := fake.NewSimpleClientset(&sc1, &sc2)
+	kruiseClient := kruisefake.NewSimpleClientset(set)
+	om, _, ssc, stop := setupController(client, kruiseClient)
+	defer close(stop)
+	if err := scaleUpStatefulSetControl(set, ssc, om, emptyInvariants); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := om.setsLister.StatefulSets(set.Namespace).Get(set.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set = set.DeepCopy()
+	set.Spec.VolumeClaimTemplates[0].Spec.StorageClassName = &sc2.Name
+	set.Spec.Template.Spec.Containers[0].Image = "busybox"
+	selector, err := metav1.LabelSelectorAsSelector(set.Spec.Selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pods, err := om.podsLister.Pods(set.Namespace).List(selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ssc.UpdateStatefulSet(context.TODO(), set, pods); err != nil {
+		t.Fatal(err)
+	}
+
+	// all members are needed for the quorum, so neither the claims nor the pods are disrupted
+	for _, pod := range pods {
+		claimName := fmt.Sprintf("datadir-0-%s", pod.Name)
+		claim, err := om.GetClaim(set.Namespace, claimName)
+		if err != nil {
+			t.Fatalf("expected claim %s not deleted, got %v", claimName, err)
+		}
+		assert.False(t, isClaimPreparingDelete(claim), "claim %s should not be preparing delete", claimName)
+		assert.Equal(t, sc1.Name, *claim.Spec.StorageClassName)
+
+		got, err := om.podsLister.Pods(set.Namespace).Get(pod.Name)
+		if err != nil {
+			t.Fatalf("expected pod %s not deleted, got %v", pod.Name, err)
+		}
+		assert.False(t, isTerminating(got), "pod %s should not be terminating", pod.Name)
+	}
+}
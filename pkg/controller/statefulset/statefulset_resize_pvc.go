@@ -67,10 +67,33 @@ type PVCHandler interface {
 	// - A boolean indicating whether all PVCs are completed: status.capacity >= spec.request or in FileSystemResizePending condition.
 	// - An error if there was an issue checking the PVCs' completion.
 	IsOwnedPVCsCompleted(set *appsv1beta1.StatefulSet, pod *v1.Pod) (bool, error)
+
+	// IsOwnedPVCsRecreating checks if any PVC owned by the given StatefulSet and associated with the given Pod is being recreated.
+	// Parameters:
+	// - set: A pointer to the StatefulSet object that owns the PVCs.
+	// - pod: A pointer to the Pod object for which the PVCs are being checked.
+	// Returns:
+	// - A boolean indicating whether any PVC is labeled as PreparingDelete for recreation.
+	// - An error if there was an issue checking the PVCs.
+	IsOwnedPVCsRecreating(set *appsv1beta1.StatefulSet, pod *v1.Pod) (bool, error)
+
+	// TryRecreatePVC attempts to delete the PVCs that can not be patched, to recreate them from the templates.
+	// Parameters:
+	// - set: A pointer to the StatefulSet object that owns the PVCs.
+	// - pod: A pointer to the Pod object for which the PVCs might need recreation.
+	// Returns:
+	// - A boolean indicating whether any PVC needs recreation: storageClassName or accessModes differs from template.
+	// - A boolean indicating whether the PVCs needing recreation have been deleted.
+	// - An error if there was an issue deleting the PVCs.
+	TryRecreatePVC(set *appsv1beta1.StatefulSet, pod *v1.Pod) (bool, bool, error)
 }
 
 func (spc *StatefulPodControl) IsOwnedPVCsReady(set *appsv1beta1.StatefulSet, pod *v1.Pod) (bool, error) {
 	checkFn := func(claim, template *v1.PersistentVolumeClaim) (bool, error) {
+		// the claim to be recreated still works until it is deleted
+		if isVolumeClaimRecreateEnabled(set) && !pvc.IsClaimCompatibleWithoutSize(claim, template) {
+			return true, nil
+		}
 		_, ready := pvc.IsPVCCompatibleAndReady(claim, template)
 		if !ready {
 			return false, nil
@@ -98,6 +121,10 @@ func (spc *StatefulPodControl) TryPatchPVC(set *appsv1beta1.StatefulSet, pod *v1
 			return true, nil
 		}
 		if !needExpand {
+			// the claim will be recreated instead of patched
+			if isVolumeClaimRecreateEnabled(set) {
+				return true, nil
+			}
 			spc.recorder.Eventf(set, v1.EventTypeWarning, "FailedUpdatePVC", "failed to update pvc %s: contains diff other than spec resource, wait pvc to be deleted", claim.Name)
 			return false, fmt.Errorf("can not patch pvc %s: contains diff other than spec resource, wait pvc to be deleted", claim.Name)
 		}
@@ -153,8 +180,15 @@ func (ssc *defaultStatefulSetControl) updatePVCStatus(status *appsv1beta1.Statef
 	}
 
 	fn := func(claim, template *v1.PersistentVolumeClaim) (bool, error) {
+		templateStatus := templateNameMap[template.Name]
+		if isClaimPreparingDelete(claim) {
+			templateStatus.RecreatingReplicas++
+			return true, nil
+		}
+		if claim.DeletionTimestamp != nil {
+			return true, nil
+		}
 		if compatible, ready := pvc.IsPVCCompatibleAndReady(claim, template); compatible {
-			templateStatus := templateNameMap[template.Name]
 			templateStatus.CompatibleReplicas++
 			if ready {
 				templateStatus.CompatibleReadyReplicas++
@@ -170,7 +204,7 @@ func (ssc *defaultStatefulSetControl) updatePVCStatus(status *appsv1beta1.Statef
 			continue
 		}
 
-		success, err := ssc.podControl.handlePVCWithCustomFn(set, pod, true, fn)
+		success, err := ssc.podControl.handlePVCWithCustomFn(set, pod, false, fn)
 		if err != nil || !success {
 			return
 		}
@@ -135,8 +135,8 @@ const (
 	// ForceDeleteTimeoutExpectationFeatureGate enable delete timeout expectation, for example: cloneSet ScaleExpectation
 	ForceDeleteTimeoutExpectationFeatureGate = "ForceDeleteTimeoutExpectationGate"
 
-	// InPlaceWorkloadVerticalScaling enable CloneSet/Advanced StatefulSet controller to support vertical scaling
-	// of managed Pods.
+	// InPlaceWorkloadVerticalScaling enable CloneSet/Advanced StatefulSet/Advanced DaemonSet controller to support
+	// vertical scaling of managed Pods.
 	InPlaceWorkloadVerticalScaling featuregate.Feature = "InPlaceWorkloadVerticalScaling"
 
 	// EnablePodProbeMarkerOnServerless enable PodProbeMarker on Serverless Pod
@@ -161,6 +161,10 @@ const (
 	// node affinity, then the pods on the nodes that have undergone
 	// this reduction will not be counted in the maxUnavailable.
 	DaemonSetPruneIneligibleNodes featuregate.Feature = "DaemonSetPruneIneligibleNodes"
+
+	// CloneSetEndpointReadinessGate enables CloneSet to watch EndpointSlices and to honor
+	// spec.updateStrategy.rollingUpdate.endpointReadinessGate during rolling update.
+	CloneSetEndpointReadinessGate featuregate.Feature = "CloneSetEndpointReadinessGate"
 )
 
 var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -204,6 +208,7 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	DefaultHostNetworkHostPortsInPodTemplates: {Default: false, PreRelease: featuregate.Alpha},
 
 	DaemonSetPruneIneligibleNodes: {Default: false, PreRelease: featuregate.Alpha},
+	CloneSetEndpointReadinessGate: {Default: false, PreRelease: featuregate.Alpha},
 }
 
 func init() {
@@ -51,6 +51,24 @@ func GetSidecarSetPatchMetadataWhiteList(client client.Client) (*SidecarSetPatch
 	return whiteList, nil
 }
 
+func GetSidecarSetSelectorImpactLimit(client client.Client) (*SidecarSetSelectorImpactLimit, error) {
+	data, err := getKruiseConfiguration(client)
+	if err != nil {
+		return nil, err
+	} else if len(data) == 0 {
+		return nil, nil
+	}
+	value, ok := data[SidecarSetSelectorImpactLimitKey]
+	if !ok {
+		return nil, nil
+	}
+	limit := &SidecarSetSelectorImpactLimit{}
+	if err = json.Unmarshal([]byte(value), limit); err != nil {
+		return nil, err
+	}
+	return limit, nil
+}
+
 func GetPPSWatchCustomWorkloadWhiteList(client client.Client) (*CustomWorkloadWhiteList, error) {
 	whiteList := &CustomWorkloadWhiteList{Workloads: make([]schema.GroupVersionKind, 0)}
 	data, err := getKruiseConfiguration(client)
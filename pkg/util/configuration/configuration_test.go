@@ -162,6 +162,55 @@ func TestGetSidecarSetPatchMetadataWhiteList(t *testing.T) {
 	}
 }
 
+func TestGetSidecarSetSelectorImpactLimit(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	testCases := []struct {
+		name         string
+		existingObjs []client.Object
+		expectErr    bool
+		expectResult *SidecarSetSelectorImpactLimit
+	}{
+		{
+			name: "Success: ConfigMap and key exist",
+			existingObjs: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: util.GetKruiseNamespace(), Name: KruiseConfigurationName},
+					Data:       map[string]string{SidecarSetSelectorImpactLimitKey: `{"maxAffectedPods":100,"maxAffectedWorkloads":10}`},
+				},
+			},
+			expectResult: &SidecarSetSelectorImpactLimit{MaxAffectedPods: 100, MaxAffectedWorkloads: 10},
+		},
+		{
+			name: "Success: ConfigMap not found",
+		},
+		{
+			name: "Error: Invalid JSON",
+			existingObjs: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: util.GetKruiseNamespace(), Name: KruiseConfigurationName},
+					Data:       map[string]string{SidecarSetSelectorImpactLimitKey: `{"maxAffectedPods":"100"}`},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.existingObjs...).Build()
+			result, err := GetSidecarSetSelectorImpactLimit(fakeClient)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectResult, result)
+			}
+		})
+	}
+}
+
 func TestGetPPSWatchCustomWorkloadWhiteList(t *testing.T) {
 	scheme := runtime.NewScheme()
 	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
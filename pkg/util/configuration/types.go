@@ -28,6 +28,7 @@ const (
 	SidecarSetPatchPodMetadataWhiteListKey = "SidecarSet_PatchPodMetadata_WhiteList"
 	PPSWatchCustomWorkloadWhiteList        = "PPS_Watch_Custom_Workload_WhiteList"
 	WSWatchCustomWorkloadWhiteList         = "WorkloadSpread_Watch_Custom_Workload_WhiteList"
+	SidecarSetSelectorImpactLimitKey       = "SidecarSet_Selector_Impact_Limit"
 )
 
 type SidecarSetPatchMetadataWhiteList struct {
@@ -42,6 +43,14 @@ type SidecarSetPatchMetadataWhiteRule struct {
 	AllowedAnnotationKeyExprs []string `json:"allowedAnnotationKeyExprs"`
 }
 
+// SidecarSetSelectorImpactLimit limits the number of active pods and workloads that newly match or stop matching
+// a SidecarSet in one update of its selector or namespaceSelector. Zero means no limit.
+// The update is rejected if the impact can not be calculated in time during admission.
+type SidecarSetSelectorImpactLimit struct {
+	MaxAffectedPods      int `json:"maxAffectedPods,omitempty"`
+	MaxAffectedWorkloads int `json:"maxAffectedWorkloads,omitempty"`
+}
+
 type CustomWorkloadWhiteList struct {
 	Workloads []schema.GroupVersionKind `json:"workloads,omitempty"`
 }
@@ -28,17 +28,20 @@ import (
 
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/features"
 	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 )
 
 const (
-	IndexNameForPodNodeName          = "spec.nodeName"
-	IndexNameForOwnerRefUID          = "ownerRefUID"
-	IndexNameForController           = ".metadata.controller"
-	IndexNameForIsActive             = "isActive"
-	IndexNameForSidecarSetNamespace  = "namespace"
-	IndexValueSidecarSetClusterScope = "clusterScope"
-	LabelMetadataName                = v1.LabelMetadataName
+	IndexNameForPodNodeName                      = "spec.nodeName"
+	IndexNameForOwnerRefUID                      = "ownerRefUID"
+	IndexNameForController                       = ".metadata.controller"
+	IndexNameForIsActive                         = "isActive"
+	IndexNameForSidecarSetNamespace              = "namespace"
+	IndexValueSidecarSetClusterScope             = "clusterScope"
+	IndexNameForCloneSetEndpointReadinessService = "spec.updateStrategy.rollingUpdate.endpointReadinessGate.serviceName"
+	LabelMetadataName                            = v1.LabelMetadataName
 )
 
 var (
@@ -115,6 +118,12 @@ func RegisterFieldIndexes(c cache.Cache) error {
 				return
 			}
 		}
+		// cloneset endpoint readiness service
+		if utilfeature.DefaultFeatureGate.Enabled(features.CloneSetEndpointReadinessGate) && utildiscovery.DiscoverObject(&appsv1beta1.CloneSet{}) {
+			if err = indexCloneSetEndpointReadinessService(c); err != nil {
+				return
+			}
+		}
 		// sidecar spec namespaces
 		if utildiscovery.DiscoverObject(&appsv1alpha1.SidecarSet{}) {
 			if err = indexSidecarSet(c); err != nil {
@@ -302,3 +311,15 @@ func indexSidecarSetV1Beta1(c cache.Cache) error {
 		return IndexSidecarSetV1Beta1(rawObj)
 	})
 }
+
+func IndexCloneSetEndpointReadinessService(rawObj client.Object) []string {
+	obj := rawObj.(*appsv1beta1.CloneSet)
+	if rollingUpdate := obj.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.EndpointReadinessGate != nil {
+		return []string{rollingUpdate.EndpointReadinessGate.ServiceName}
+	}
+	return nil
+}
+
+func indexCloneSetEndpointReadinessService(c cache.Cache) error {
+	return c.IndexField(context.TODO(), &appsv1beta1.CloneSet{}, IndexNameForCloneSetEndpointReadinessService, IndexCloneSetEndpointReadinessService)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+)
+
+// MaxHTTPGetTimeoutSeconds is the upper bound of the timeout of HTTP requests performed by the controllers,
+// so that a slow endpoint never holds a worker for long.
+const MaxHTTPGetTimeoutSeconds = 30
+
+var (
+	// podHTTPGetTransport skips the verification of certificates like the probes of kubelet,
+	// and does not keep the connections to the pods.
+	podHTTPGetTransport = &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		DisableKeepAlives: true,
+	}
+
+	// httpGetTransport is used for the addresses given by users, e.g. metric analysis services.
+	httpGetTransport = &http.Transport{
+		Proxy:             http.ProxyFromEnvironment,
+		DisableKeepAlives: true,
+	}
+)
+
+// CallPodHTTPGetAction performs the HTTPGetAction on the pod IP, and returns whether the response status code is 2xx
+// and the message of the response or error.
+func CallPodHTTPGetAction(action *v1.HTTPGetAction, pod *v1.Pod, timeout time.Duration) (bool, string) {
+	port, err := podutil.FindPort(pod, &v1.ServicePort{TargetPort: action.Port})
+	if err != nil {
+		return false, err.Error()
+	}
+	scheme := string(action.Scheme)
+	if scheme == "" {
+		scheme = "http"
+	}
+	u, err := url.Parse(action.Path)
+	if err != nil {
+		return false, err.Error()
+	}
+	u.Scheme = scheme
+	u.Host = net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(port))
+
+	code, err := doHTTPGet(podHTTPGetTransport, u.String(), action.HTTPHeaders, timeout)
+	if err != nil {
+		return false, err.Error()
+	}
+	return code >= http.StatusOK && code < http.StatusMultipleChoices, fmt.Sprintf("HTTP status %d", code)
+}
+
+// CallHTTPGet performs a GET request to the url and returns the response status code.
+func CallHTTPGet(rawURL string, headers []v1.HTTPHeader, timeout time.Duration) (int, error) {
+	return doHTTPGet(httpGetTransport, rawURL, headers, timeout)
+}
+
+// doHTTPGet never follows redirects, which may lead the requests from kruise-manager to anywhere.
+// The timeout defaults to 1 second and is limited to MaxHTTPGetTimeoutSeconds.
+func doHTTPGet(transport http.RoundTripper, rawURL string, headers []v1.HTTPHeader, timeout time.Duration) (int, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	for _, header := range headers {
+		req.Header.Add(header.Name, header.Value)
+	}
+
+	if timeout <= 0 {
+		timeout = time.Second
+	} else if timeout > MaxHTTPGetTimeoutSeconds*time.Second {
+		timeout = MaxHTTPGetTimeoutSeconds * time.Second
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestCallHTTPGet(t *testing.T) {
+	var redirected bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Check") != "canary" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		http.Redirect(w, r, target.URL, http.StatusFound)
+	}))
+	defer server.Close()
+
+	code, err := CallHTTPGet(server.URL, []v1.HTTPHeader{{Name: "X-Check", Value: "canary"}}, time.Second)
+	if err != nil {
+		t.Fatalf("CallHTTPGet failed: %v", err)
+	}
+	if code != http.StatusFound || redirected {
+		t.Fatalf("expected redirect not followed, got code %d, redirected %v", code, redirected)
+	}
+}
+
+func TestCallPodHTTPGetAction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/drain" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	pod := &v1.Pod{Status: v1.PodStatus{PodIP: host}}
+
+	if ok, msg := CallPodHTTPGetAction(&v1.HTTPGetAction{Path: "/drain", Port: intstr.FromInt32(int32(portNum))}, pod, 0); !ok {
+		t.Fatalf("expected success, got %s", msg)
+	}
+	if ok, msg := CallPodHTTPGetAction(&v1.HTTPGetAction{Path: "/other", Port: intstr.FromInt32(int32(portNum))}, pod, 0); ok || msg != "HTTP status 404" {
+		t.Fatalf("expected failure with 404, got %v %s", ok, msg)
+	}
+}
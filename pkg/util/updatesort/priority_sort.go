@@ -51,6 +51,22 @@ func (ps *prioritySort) Sort(pods []*v1.Pod, indexes []int) []int {
 	return indexes
 }
 
+// SortByLabels helps sort the indexes of objects other than pods, such as nodes, by UpdatePriorityStrategy
+// over their labels. The objects with the same priority are kept in the original order.
+func SortByLabels(s *appspub.UpdatePriorityStrategy, objLabels []map[string]string, indexes []int) []int {
+	if s == nil || (len(s.WeightPriority) == 0 && len(s.OrderPriority) == 0) {
+		return indexes
+	}
+
+	ps := &prioritySort{strategy: s}
+	f := func(i, j int) bool {
+		return ps.compare(objLabels[indexes[i]], objLabels[indexes[j]], false)
+	}
+
+	sort.SliceStable(indexes, f)
+	return indexes
+}
+
 func (ps *prioritySort) compare(podI, podJ map[string]string, defaultVal bool) bool {
 	if len(ps.strategy.WeightPriority) > 0 {
 		if wI, wJ := ps.getPodWeightPriority(podI), ps.getPodWeightPriority(podJ); wI != wJ {
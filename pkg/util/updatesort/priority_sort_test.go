@@ -356,3 +356,28 @@ func Test_prioritySort_Sort(t *testing.T) {
 		})
 	}
 }
+
+func TestSortByLabels(t *testing.T) {
+	objLabels := []map[string]string{
+		{"pool": "critical"},
+		{},
+		{"pool": "canary"},
+		{"pool": "critical"},
+		{"pool": "canary"},
+	}
+	strategy := &appspub.UpdatePriorityStrategy{
+		WeightPriority: []appspub.UpdatePriorityWeightTerm{
+			{Weight: 100, MatchSelector: metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}},
+			{Weight: 50, MatchSelector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "pool", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"critical"}},
+			}}},
+		},
+	}
+
+	if got := SortByLabels(nil, objLabels, []int{0, 1, 2, 3, 4}); !reflect.DeepEqual(got, []int{0, 1, 2, 3, 4}) {
+		t.Fatalf("expected original order without strategy, got %v", got)
+	}
+	if got := SortByLabels(strategy, objLabels, []int{0, 1, 2, 3, 4}); !reflect.DeepEqual(got, []int{2, 4, 1, 0, 3}) {
+		t.Fatalf("expected [2 4 1 0 3], got %v", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -23,7 +24,9 @@ import (
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	"github.com/openkruise/kruise/apis/apps/v1beta1"
 	clonesetcore "github.com/openkruise/kruise/pkg/controller/cloneset/core"
+	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
 	"github.com/openkruise/kruise/pkg/webhook/util/convertor"
 )
@@ -103,6 +106,7 @@ func (h *CloneSetCreateUpdateHandler) validateCloneSetSpec(spec, oldSpec *appsv1
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("progressDeadlineSeconds"), spec.ProgressDeadlineSeconds, "must be greater than minReadySeconds"))
 		}
 	}
+	allErrs = append(allErrs, validateRollbackToRevisionAnnotation(metadata)...)
 
 	return allErrs
 }
@@ -302,10 +306,55 @@ func validateCloneSetV1beta1Spec(spec, oldSpec *v1beta1.CloneSetSpec, metadata *
 
 	allErrs = append(allErrs, validateScaleStrategyV1beta1(&spec.ScaleStrategy, oldScaleStrategy, metadata, fldPath.Child("scaleStrategy"))...)
 	allErrs = append(allErrs, validateUpdateStrategyV1beta1(&spec.UpdateStrategy, int(*spec.Replicas), fldPath.Child("updateStrategy"))...)
+	allErrs = append(allErrs, validateProgressDeadlineExceededPolicyV1beta1(spec, fldPath)...)
+
+	switch spec.VolumeClaimUpdateStrategy.Type {
+	case "", v1beta1.OnDeleteCloneSetVolumeClaimUpdateStrategyType, v1beta1.InPlaceExpandCloneSetVolumeClaimUpdateStrategyType:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("volumeClaimUpdateStrategy", "type"), spec.VolumeClaimUpdateStrategy.Type,
+			[]string{string(v1beta1.OnDeleteCloneSetVolumeClaimUpdateStrategyType), string(v1beta1.InPlaceExpandCloneSetVolumeClaimUpdateStrategyType)}))
+	}
+
+	if spec.RollbackTo != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(spec.RollbackTo.Revision, fldPath.Child("rollbackTo", "revision"))...)
+	}
+	allErrs = append(allErrs, validateRollbackToRevisionAnnotation(metadata)...)
 
 	return allErrs
 }
 
+func validateRollbackToRevisionAnnotation(metadata *metav1.ObjectMeta) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if value, ok := metadata.Annotations[v1beta1.CloneSetRollbackToRevisionAnnotation]; ok {
+		if revision, err := strconv.ParseInt(value, 10, 64); err != nil || revision < 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "annotations").Key(v1beta1.CloneSetRollbackToRevisionAnnotation),
+				value, "must be a non-negative integer"))
+		}
+	}
+	return allErrs
+}
+
+func validateProgressDeadlineExceededPolicyV1beta1(spec *v1beta1.CloneSetSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch spec.ProgressDeadlineExceededPolicy {
+	case "", v1beta1.NoneProgressDeadlineExceededPolicyType:
+	case v1beta1.PauseProgressDeadlineExceededPolicyType, v1beta1.RollbackPartitionProgressDeadlineExceededPolicyType:
+		if spec.ProgressDeadlineSeconds == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("progressDeadlineSeconds"), "required by progressDeadlineExceededPolicy"))
+		}
+		if spec.ProgressDeadlineExceededPolicy == v1beta1.RollbackPartitionProgressDeadlineExceededPolicyType &&
+			spec.UpdateStrategy.RollingUpdate != nil && len(spec.UpdateStrategy.RollingUpdate.CanarySteps) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("progressDeadlineExceededPolicy"),
+				"RollbackPartition can not be used with canarySteps, which manage the partition"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("progressDeadlineExceededPolicy"), spec.ProgressDeadlineExceededPolicy,
+			[]string{string(v1beta1.NoneProgressDeadlineExceededPolicyType), string(v1beta1.PauseProgressDeadlineExceededPolicyType),
+				string(v1beta1.RollbackPartitionProgressDeadlineExceededPolicyType)}))
+	}
+	return allErrs
+}
+
 func validateScaleStrategyV1beta1(strategy, oldStrategy *v1beta1.CloneSetScaleStrategy, metadata *metav1.ObjectMeta, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -314,6 +363,33 @@ func validateScaleStrategyV1beta1(strategy, oldStrategy *v1beta1.CloneSetScaleSt
 		return allErrs
 	}
 
+	if policy := strategy.ScaleDownPolicy; policy != nil {
+		policyPath := fldPath.Child("scaleDownPolicy")
+		switch policy.Type {
+		case v1beta1.DefaultScaleDownPolicyType, v1beta1.TopologySpreadScaleDownPolicyType, "":
+		default:
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("type"), policy.Type, fmt.Sprintf("must be '%s' or '%s'",
+				v1beta1.DefaultScaleDownPolicyType, v1beta1.TopologySpreadScaleDownPolicyType)))
+		}
+		if list := util.CheckDuplicate(policy.TopologyKeys); len(list) > 0 {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("topologyKeys"), policy.TopologyKeys, fmt.Sprintf("duplicated items %v", list)))
+		}
+		for i, key := range policy.TopologyKeys {
+			allErrs = append(allErrs, unversionedvalidation.ValidateLabelName(key, policyPath.Child("topologyKeys").Index(i))...)
+		}
+	}
+
+	if strategy.StandbyReplicas != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(*strategy.StandbyReplicas), fldPath.Child("standbyReplicas"))...)
+	}
+
+	switch strategy.InstanceIDPolicy {
+	case v1beta1.RandomInstanceIDPolicyType, v1beta1.OrdinalInstanceIDPolicyType, "":
+	default:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("instanceIDPolicy"), strategy.InstanceIDPolicy, fmt.Sprintf("must be '%s' or '%s'",
+			v1beta1.RandomInstanceIDPolicyType, v1beta1.OrdinalInstanceIDPolicyType)))
+	}
+
 	return allErrs
 }
 
@@ -402,6 +478,17 @@ func validateUpdateStrategyV1beta1(strategy *v1beta1.CloneSetUpdateStrategy, rep
 				"maxUnavailable and maxSurge should not both be less than 1"))
 		}
 
+		// Validate EndpointReadinessGate
+		if gate := rollingUpdate.EndpointReadinessGate; gate != nil {
+			if !utilfeature.DefaultFeatureGate.Enabled(features.CloneSetEndpointReadinessGate) {
+				allErrs = append(allErrs, field.Forbidden(rollingUpdatePath.Child("endpointReadinessGate"),
+					fmt.Sprintf("feature-gate %s is not enabled", features.CloneSetEndpointReadinessGate)))
+			}
+			for _, msg := range apimachineryvalidation.NameIsDNS1035Label(gate.ServiceName, false) {
+				allErrs = append(allErrs, field.Invalid(rollingUpdatePath.Child("endpointReadinessGate", "serviceName"), gate.ServiceName, msg))
+			}
+		}
+
 		// Validate InPlaceUpdateStrategy
 		if rollingUpdate.InPlaceUpdateStrategy != nil {
 			inPlaceUpdatePath := rollingUpdatePath.Child("inPlaceUpdateStrategy")
@@ -453,6 +540,51 @@ func validateUpdateStrategyV1beta1(strategy *v1beta1.CloneSetUpdateStrategy, rep
 					inPlaceUpdatePath.Child("imagePreDownloadMinUpdatedReadyPods"))...)
 			}
 		}
+
+		// Validate CanarySteps
+		allErrs = append(allErrs, validateCanaryStepsV1beta1(rollingUpdate.CanarySteps, replicas, rollingUpdatePath.Child("canarySteps"))...)
+	}
+
+	return allErrs
+}
+
+func validateCanaryStepsV1beta1(steps []v1beta1.CloneSetCanaryStep, replicas int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	lastPartition := math.MaxInt32
+	for i := range steps {
+		step := &steps[i]
+		stepPath := fldPath.Index(i)
+
+		partition, err := util.GetScaledValueFromIntOrPercent(&step.Partition, replicas, true)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(stepPath.Child("partition"), step.Partition.String(),
+				fmt.Sprintf("failed GetScaledValueFromIntOrPercent for partition: %v", err)))
+		} else if partition < 0 {
+			allErrs = append(allErrs, field.Invalid(stepPath.Child("partition"), step.Partition.String(), "must be non-negative"))
+		} else if partition > lastPartition {
+			allErrs = append(allErrs, field.Invalid(stepPath.Child("partition"), step.Partition.String(),
+				"must not be greater than the partition of previous step"))
+		} else {
+			lastPartition = partition
+		}
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(step.PauseSeconds), stepPath.Child("pauseSeconds"))...)
+
+		if step.Check == nil {
+			continue
+		}
+		checkPath := stepPath.Child("check")
+		if step.Check.HTTPGet == nil {
+			allErrs = append(allErrs, field.Required(checkPath.Child("httpGet"), "check must specify httpGet"))
+		} else if u, err := url.Parse(step.Check.HTTPGet.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(checkPath.Child("httpGet", "url"), step.Check.HTTPGet.URL, "must be a valid http or https url"))
+		}
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(step.Check.TimeoutSeconds), checkPath.Child("timeoutSeconds"))...)
+		if step.Check.TimeoutSeconds > util.MaxHTTPGetTimeoutSeconds {
+			allErrs = append(allErrs, field.Invalid(checkPath.Child("timeoutSeconds"), step.Check.TimeoutSeconds,
+				fmt.Sprintf("must be no more than %d", util.MaxHTTPGetTimeoutSeconds)))
+		}
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(step.Check.FailureThreshold), checkPath.Child("failureThreshold"))...)
 	}
 
 	return allErrs
@@ -469,11 +601,14 @@ func ValidateCloneSetUpdateV1beta1(cloneSet, oldCloneSet *v1beta1.CloneSet) fiel
 	clone.Spec.UpdateStrategy = oldCloneSet.Spec.UpdateStrategy
 	clone.Spec.MinReadySeconds = oldCloneSet.Spec.MinReadySeconds
 	clone.Spec.ProgressDeadlineSeconds = oldCloneSet.Spec.ProgressDeadlineSeconds
+	clone.Spec.ProgressDeadlineExceededPolicy = oldCloneSet.Spec.ProgressDeadlineExceededPolicy
 	clone.Spec.Lifecycle = oldCloneSet.Spec.Lifecycle
 	clone.Spec.RevisionHistoryLimit = oldCloneSet.Spec.RevisionHistoryLimit
 	clone.Spec.VolumeClaimTemplates = oldCloneSet.Spec.VolumeClaimTemplates
+	clone.Spec.VolumeClaimUpdateStrategy = oldCloneSet.Spec.VolumeClaimUpdateStrategy
+	clone.Spec.RollbackTo = oldCloneSet.Spec.RollbackTo
 	if !apiequality.Semantic.DeepEqual(clone.Spec, oldCloneSet.Spec) {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "updates to cloneset spec for fields other than 'replicas', 'template', 'lifecycle', 'scaleStrategy', 'updateStrategy', 'minReadySeconds', 'progressDeadlineSeconds', 'volumeClaimTemplates' and 'revisionHistoryLimit' are forbidden"))
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "updates to cloneset spec for fields other than 'replicas', 'template', 'lifecycle', 'scaleStrategy', 'updateStrategy', 'minReadySeconds', 'progressDeadlineSeconds', 'progressDeadlineExceededPolicy', 'volumeClaimTemplates', 'volumeClaimUpdateStrategy', 'rollbackTo' and 'revisionHistoryLimit' are forbidden"))
 	}
 
 	// Note: v1beta1 CloneSet cannot use the v1alpha1 core control for validation
@@ -21,7 +21,9 @@ import (
 	appspub "github.com/openkruise/kruise/apis/apps/pub"
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	"github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 )
 
 type testCase struct {
@@ -772,3 +774,252 @@ func TestValidateImagePreDownloadParallelism(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCanarySteps(t *testing.T) {
+	tests := []struct {
+		name        string
+		steps       []v1beta1.CloneSetCanaryStep
+		expectError bool
+	}{
+		{
+			name: "valid steps",
+			steps: []v1beta1.CloneSetCanaryStep{
+				{Partition: intstr.FromInt32(9), PauseSeconds: 60},
+				{Partition: intstr.FromString("50%"), Check: &v1beta1.CloneSetCanaryCheck{
+					HTTPGet: &v1beta1.CloneSetCanaryHTTPGetAction{URL: "http://analysis.monitoring.svc/check"},
+				}},
+				{Partition: intstr.FromInt32(0)},
+			},
+		},
+		{
+			name: "increasing partition",
+			steps: []v1beta1.CloneSetCanaryStep{
+				{Partition: intstr.FromInt32(5)},
+				{Partition: intstr.FromInt32(8)},
+			},
+			expectError: true,
+		},
+		{
+			name: "negative pause seconds",
+			steps: []v1beta1.CloneSetCanaryStep{
+				{Partition: intstr.FromInt32(5), PauseSeconds: -1},
+			},
+			expectError: true,
+		},
+		{
+			name: "check without httpGet",
+			steps: []v1beta1.CloneSetCanaryStep{
+				{Partition: intstr.FromInt32(5), Check: &v1beta1.CloneSetCanaryCheck{}},
+			},
+			expectError: true,
+		},
+		{
+			name: "check with invalid url",
+			steps: []v1beta1.CloneSetCanaryStep{
+				{Partition: intstr.FromInt32(5), Check: &v1beta1.CloneSetCanaryCheck{
+					HTTPGet: &v1beta1.CloneSetCanaryHTTPGetAction{URL: "analysis/check"},
+				}},
+			},
+			expectError: true,
+		},
+		{
+			name: "check with too long timeout",
+			steps: []v1beta1.CloneSetCanaryStep{
+				{Partition: intstr.FromInt32(5), Check: &v1beta1.CloneSetCanaryCheck{
+					HTTPGet:        &v1beta1.CloneSetCanaryHTTPGetAction{URL: "http://analysis.monitoring.svc/check"},
+					TimeoutSeconds: 600,
+				}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allErrs := validateCanaryStepsV1beta1(tt.steps, 10, field.NewPath("canarySteps"))
+			if hasError := len(allErrs) > 0; hasError != tt.expectError {
+				t.Errorf("expected error: %v, got error: %v, errors: %v", tt.expectError, hasError, allErrs)
+			}
+		})
+	}
+}
+
+func TestValidateScaleDownPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      *v1beta1.CloneSetScaleDownPolicy
+		expectError bool
+	}{
+		{
+			name:   "topology spread with default keys",
+			policy: &v1beta1.CloneSetScaleDownPolicy{Type: v1beta1.TopologySpreadScaleDownPolicyType},
+		},
+		{
+			name: "topology spread with keys",
+			policy: &v1beta1.CloneSetScaleDownPolicy{
+				Type:         v1beta1.TopologySpreadScaleDownPolicyType,
+				TopologyKeys: []string{v1.LabelTopologyZone, v1.LabelHostname},
+			},
+		},
+		{
+			name:        "invalid type",
+			policy:      &v1beta1.CloneSetScaleDownPolicy{Type: "Random"},
+			expectError: true,
+		},
+		{
+			name: "duplicated keys",
+			policy: &v1beta1.CloneSetScaleDownPolicy{
+				Type:         v1beta1.TopologySpreadScaleDownPolicyType,
+				TopologyKeys: []string{v1.LabelTopologyZone, v1.LabelTopologyZone},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid key",
+			policy: &v1beta1.CloneSetScaleDownPolicy{
+				Type:         v1beta1.TopologySpreadScaleDownPolicyType,
+				TopologyKeys: []string{"invalid key"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := &v1beta1.CloneSetScaleStrategy{ScaleDownPolicy: tt.policy}
+			allErrs := validateScaleStrategyV1beta1(strategy, nil, &metav1.ObjectMeta{}, field.NewPath("scaleStrategy"))
+			if hasError := len(allErrs) > 0; hasError != tt.expectError {
+				t.Errorf("expected error: %v, got error: %v, errors: %v", tt.expectError, hasError, allErrs)
+			}
+		})
+	}
+}
+
+func TestValidateProgressDeadlineExceededPolicy(t *testing.T) {
+	canarySteps := []v1beta1.CloneSetCanaryStep{{Partition: intstr.FromInt32(5)}}
+	tests := []struct {
+		name        string
+		policy      v1beta1.CloneSetProgressDeadlineExceededPolicyType
+		deadline    *int32
+		canarySteps []v1beta1.CloneSetCanaryStep
+		expectError bool
+	}{
+		{
+			name:   "no policy",
+			policy: "",
+		},
+		{
+			name:     "rollback partition",
+			policy:   v1beta1.RollbackPartitionProgressDeadlineExceededPolicyType,
+			deadline: ptr.To[int32](600),
+		},
+		{
+			name:        "pause with canary steps",
+			policy:      v1beta1.PauseProgressDeadlineExceededPolicyType,
+			deadline:    ptr.To[int32](600),
+			canarySteps: canarySteps,
+		},
+		{
+			name:        "pause without deadline",
+			policy:      v1beta1.PauseProgressDeadlineExceededPolicyType,
+			expectError: true,
+		},
+		{
+			name:        "rollback partition with canary steps",
+			policy:      v1beta1.RollbackPartitionProgressDeadlineExceededPolicyType,
+			deadline:    ptr.To[int32](600),
+			canarySteps: canarySteps,
+			expectError: true,
+		},
+		{
+			name:        "invalid policy",
+			policy:      "Abort",
+			deadline:    ptr.To[int32](600),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1beta1.CloneSetSpec{
+				ProgressDeadlineSeconds:        tt.deadline,
+				ProgressDeadlineExceededPolicy: tt.policy,
+				UpdateStrategy: v1beta1.CloneSetUpdateStrategy{
+					RollingUpdate: &v1beta1.RollingUpdateCloneSetStrategy{CanarySteps: tt.canarySteps},
+				},
+			}
+			allErrs := validateProgressDeadlineExceededPolicyV1beta1(spec, field.NewPath("spec"))
+			if hasError := len(allErrs) > 0; hasError != tt.expectError {
+				t.Errorf("expected error: %v, got error: %v, errors: %v", tt.expectError, hasError, allErrs)
+			}
+		})
+	}
+}
+
+func TestValidateRollbackToRevisionAnnotation(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expectError bool
+	}{
+		{name: "no annotation"},
+		{name: "last revision", annotations: map[string]string{v1beta1.CloneSetRollbackToRevisionAnnotation: "0"}},
+		{name: "specified revision", annotations: map[string]string{v1beta1.CloneSetRollbackToRevisionAnnotation: "3"}},
+		{name: "negative revision", annotations: map[string]string{v1beta1.CloneSetRollbackToRevisionAnnotation: "-1"}, expectError: true},
+		{name: "invalid revision", annotations: map[string]string{v1beta1.CloneSetRollbackToRevisionAnnotation: "abc"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allErrs := validateRollbackToRevisionAnnotation(&metav1.ObjectMeta{Annotations: tt.annotations})
+			if hasError := len(allErrs) > 0; hasError != tt.expectError {
+				t.Errorf("expected error: %v, got error: %v, errors: %v", tt.expectError, hasError, allErrs)
+			}
+		})
+	}
+}
+
+func TestValidateEndpointReadinessGate(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		serviceName string
+		expectError bool
+	}{
+		{
+			name:        "feature-gate enabled",
+			enabled:     true,
+			serviceName: "svc",
+		},
+		{
+			name:        "invalid service name",
+			enabled:     true,
+			serviceName: "Svc_1",
+			expectError: true,
+		},
+		{
+			name:        "feature-gate disabled",
+			serviceName: "svc",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.CloneSetEndpointReadinessGate, tt.enabled)()
+			partition := intstr.FromInt32(0)
+			maxUnavailable := intstr.FromInt32(1)
+			strategy := &v1beta1.CloneSetUpdateStrategy{
+				RollingUpdate: &v1beta1.RollingUpdateCloneSetStrategy{
+					Partition:             &partition,
+					MaxUnavailable:        &maxUnavailable,
+					EndpointReadinessGate: &v1beta1.CloneSetEndpointReadinessGate{ServiceName: tt.serviceName},
+				},
+			}
+			allErrs := validateUpdateStrategyV1beta1(strategy, 3, field.NewPath("spec", "updateStrategy"))
+			if hasError := len(allErrs) > 0; hasError != tt.expectError {
+				t.Errorf("expected error: %v, got error: %v, errors: %v", tt.expectError, hasError, allErrs)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	appsvalidation "k8s.io/kubernetes/pkg/apis/apps/validation"
@@ -271,6 +272,20 @@ func validateRollingUpdateDaemonSetV1beta1(rollingUpdate *appsv1beta1.RollingUpd
 		allErrs = append(allErrs, field.Required(fldPath.Child("maxUnavailable"), "cannot be 0 when maxSurge is 0"))
 	}
 
+	if rollingUpdate.HostPortConflict != nil {
+		if !hasSurge {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("hostPortConflict"), "may only be set when maxSurge is non-zero"))
+		}
+		allErrs = append(allErrs, corevalidation.ValidateNonnegativeField(int64(rollingUpdate.HostPortConflict.HandoffSeconds), fldPath.Child("hostPortConflict", "handoffSeconds"))...)
+		if maxUnavailable := rollingUpdate.HostPortConflict.MaxUnavailable; maxUnavailable != nil {
+			allErrs = append(allErrs, appsvalidation.ValidatePositiveIntOrPercent(*maxUnavailable, fldPath.Child("hostPortConflict", "maxUnavailable"))...)
+			allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*maxUnavailable, fldPath.Child("hostPortConflict", "maxUnavailable"))...)
+			if getIntOrPercentValue(*maxUnavailable) == 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPortConflict", "maxUnavailable"), maxUnavailable, "cannot be 0"))
+			}
+		}
+	}
+
 	switch rollingUpdate.Type {
 	case "", appsv1beta1.StandardRollingUpdateType:
 	case appsv1beta1.InplaceRollingUpdateType:
@@ -287,5 +302,87 @@ func validateRollingUpdateDaemonSetV1beta1(rollingUpdate *appsv1beta1.RollingUpd
 		allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*rollingUpdate.Partition, fldPath.Child("rollingUpdate").Child("partition"))...)
 	}
 
+	if rollingUpdate.PriorityStrategy != nil {
+		if err := rollingUpdate.PriorityStrategy.FieldsValidation(); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("priorityStrategy"), rollingUpdate.PriorityStrategy, err.Error()))
+		}
+	}
+	allErrs = append(allErrs, validateDaemonSetUpdateWaves(rollingUpdate.Waves, hasSurge, fldPath.Child("waves"))...)
+	if rollingUpdate.NodeHealthCheck != nil {
+		allErrs = append(allErrs, validateDaemonSetNodeHealthCheck(rollingUpdate.NodeHealthCheck, rollingUpdate.Type, fldPath.Child("nodeHealthCheck"))...)
+	}
+
+	return allErrs
+}
+
+func validateDaemonSetNodeHealthCheck(check *appsv1beta1.DaemonSetNodeHealthCheck, rollingUpdateType appsv1beta1.RollingUpdateType, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, c := range check.UnhealthyConditions {
+		idxPath := fldPath.Child("unhealthyConditions").Index(i)
+		if c.Type == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("type"), ""))
+		}
+		switch c.Status {
+		case corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown:
+		default:
+			validValues := []string{string(corev1.ConditionTrue), string(corev1.ConditionFalse), string(corev1.ConditionUnknown)}
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("status"), c.Status, validValues))
+		}
+	}
+	if check.SoakSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("soakSeconds"), check.SoakSeconds, "must be greater than 0"))
+	}
+	switch check.Policy {
+	case "", appsv1beta1.SkipUnhealthyNodePolicyType:
+	case appsv1beta1.RollbackUnhealthyNodePolicyType:
+		if rollingUpdateType != appsv1beta1.InplaceRollingUpdateType {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("policy"), "Rollback only works with InPlaceIfPossible rollingUpdateType"))
+		}
+	default:
+		validValues := []string{string(appsv1beta1.SkipUnhealthyNodePolicyType), string(appsv1beta1.RollbackUnhealthyNodePolicyType)}
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("policy"), check.Policy, validValues))
+	}
+	if check.MaxFailedNodes != nil {
+		allErrs = append(allErrs, validateNonnegativeIntOrPercent(*check.MaxFailedNodes, fldPath.Child("maxFailedNodes"))...)
+		allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*check.MaxFailedNodes, fldPath.Child("maxFailedNodes"))...)
+	}
+	return allErrs
+}
+
+func validateDaemonSetUpdateWaves(waves []appsv1beta1.DaemonSetUpdateWave, hasSurge bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.NewString()
+	for i := range waves {
+		wave := &waves[i]
+		idxPath := fldPath.Index(i)
+		if wave.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else if names.Has(wave.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), wave.Name))
+		} else {
+			for _, msg := range validation.IsDNS1123Label(wave.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), wave.Name, msg))
+			}
+		}
+		names.Insert(wave.Name)
+
+		if wave.Selector == nil {
+			allErrs = append(allErrs, field.Required(idxPath.Child("selector"), ""))
+		} else {
+			allErrs = append(allErrs, metavalidation.ValidateLabelSelector(wave.Selector, metavalidation.LabelSelectorValidationOptions{}, idxPath.Child("selector"))...)
+			if len(wave.Selector.MatchLabels)+len(wave.Selector.MatchExpressions) == 0 {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("selector"), wave.Selector, "empty selector is invalid for wave"))
+			}
+		}
+		if wave.MaxUnavailable != nil {
+			// the surge path replaces pods by maxSurge, which never takes maxUnavailable into account
+			if hasSurge {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("maxUnavailable"), "may not be set when maxSurge is non-zero"))
+			}
+			allErrs = append(allErrs, validateNonnegativeIntOrPercent(*wave.MaxUnavailable, idxPath.Child("maxUnavailable"))...)
+			allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*wave.MaxUnavailable, idxPath.Child("maxUnavailable"))...)
+		}
+		allErrs = append(allErrs, corevalidation.ValidateNonnegativeField(int64(wave.SoakSeconds), idxPath.Child("soakSeconds"))...)
+	}
 	return allErrs
 }
@@ -989,6 +989,169 @@ func TestValidateRollingUpdateDaemonSetV1beta1(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "Valid hostPortConflict",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxSurge:         &maxSurge,
+				HostPortConflict: &appsv1beta1.DaemonSetHostPortConflict{HandoffSeconds: 10},
+			},
+			expectErr: false,
+		},
+		{
+			name: "HostPortConflict without maxSurge",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable:   &maxUnavailable,
+				HostPortConflict: &appsv1beta1.DaemonSetHostPortConflict{},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Negative handoffSeconds",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxSurge:         &maxSurge,
+				HostPortConflict: &appsv1beta1.DaemonSetHostPortConflict{HandoffSeconds: -1},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Valid hostPortConflict maxUnavailable",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxSurge:         &maxSurge,
+				HostPortConflict: &appsv1beta1.DaemonSetHostPortConflict{MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "20%"}},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Zero hostPortConflict maxUnavailable",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxSurge:         &maxSurge,
+				HostPortConflict: &appsv1beta1.DaemonSetHostPortConflict{MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Valid priorityStrategy",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				PriorityStrategy: &appspub.UpdatePriorityStrategy{
+					WeightPriority: []appspub.UpdatePriorityWeightTerm{
+						{Weight: 50, MatchSelector: metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "PriorityStrategy with both weight and order terms",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				PriorityStrategy: &appspub.UpdatePriorityStrategy{
+					WeightPriority: []appspub.UpdatePriorityWeightTerm{
+						{Weight: 50, MatchSelector: metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}},
+					},
+					OrderPriority: []appspub.UpdatePriorityOrderTerm{{OrderedKey: "order"}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Valid nodeHealthCheck",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				Type:           appsv1beta1.InplaceRollingUpdateType,
+				MaxUnavailable: &maxUnavailable,
+				NodeHealthCheck: &appsv1beta1.DaemonSetNodeHealthCheck{
+					UnhealthyConditions: []appsv1beta1.DaemonSetNodeCondition{{Type: "KernelDeadlock", Status: corev1.ConditionTrue}},
+					SoakSeconds:         300,
+					Policy:              appsv1beta1.RollbackUnhealthyNodePolicyType,
+					MaxFailedNodes:      &percentValue,
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "NodeHealthCheck rollback with Standard type",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				Type:           appsv1beta1.StandardRollingUpdateType,
+				MaxUnavailable: &maxUnavailable,
+				NodeHealthCheck: &appsv1beta1.DaemonSetNodeHealthCheck{
+					SoakSeconds: 300,
+					Policy:      appsv1beta1.RollbackUnhealthyNodePolicyType,
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "NodeHealthCheck with invalid condition and soakSeconds",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				NodeHealthCheck: &appsv1beta1.DaemonSetNodeHealthCheck{
+					UnhealthyConditions: []appsv1beta1.DaemonSetNodeCondition{{Type: "KernelDeadlock", Status: "Maybe"}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Valid waves",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}, SoakSeconds: 600},
+					{Name: "prod", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "prod"}}, MaxUnavailable: &percentValue},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Duplicate wave names",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}},
+					{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "prod"}}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Wave without selector",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				Waves:          []appsv1beta1.DaemonSetUpdateWave{{Name: "canary"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Wave with invalid maxUnavailable and soakSeconds",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}, MaxUnavailable: &invalidPercent, SoakSeconds: -1},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Wave with maxUnavailable and maxSurge",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &zeroUnavailable,
+				MaxSurge:       &maxSurge,
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}, MaxUnavailable: &percentValue},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Wave without maxUnavailable and maxSurge",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &zeroUnavailable,
+				MaxSurge:       &maxSurge,
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}, SoakSeconds: 600},
+				},
+			},
+			expectErr: false,
+		},
 	}
 
 	for _, tt := range tests {
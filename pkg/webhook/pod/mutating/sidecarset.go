@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"sort"
 	"strings"
@@ -169,7 +170,12 @@ func (h *PodCreateHandler) sidecarsetMutatingPod(ctx context.Context, req admiss
 	klog.V(4).InfoS("before mutating", "func", "sidecar inject", "pod", klog.KObj(pod))
 	// apply sidecar set info into pod
 	// 1. inject init containers, sort by their name, after the original init containers
+	// the native sidecars are placed after the normal init containers of SidecarSets, which may prepare for them
 	sort.SliceStable(sidecarInitContainers, func(i, j int) bool {
+		iNative := sidecarcontrol.IsSidecarContainer(sidecarInitContainers[i].Container)
+		if jNative := sidecarcontrol.IsSidecarContainer(sidecarInitContainers[j].Container); iNative != jNative {
+			return jNative
+		}
 		return sidecarInitContainers[i].Name < sidecarInitContainers[j].Name
 	})
 	pod.Spec.InitContainers = mergeSidecarContainers(pod.Spec.InitContainers, sidecarInitContainers)
@@ -257,6 +263,11 @@ func (h *PodCreateHandler) getSuitableRevisionSidecarSet(sidecarSet *appsv1beta1
 			klog.V(3).InfoS("New pod is updated, which has a probability to be injected with the latest sidecar",
 				"pod", klog.KObj(newPod), "sidecarSet", klog.KObj(sidecarSet), "partition", sidecarSet.Spec.UpdateStrategy.Partition)
 			return h.selectRevisionRandomly(specificHistory, sidecarSet.DeepCopy(), sidecarSet.Spec.UpdateStrategy.Partition)
+		case appsv1beta1.WeightedSidecarSetInjectRevisionPolicy:
+			suitableSidecarSet := selectRevisionByWeight(specificHistory, sidecarSet.DeepCopy(), revisionInfo.Weight, newPod)
+			klog.V(3).InfoS("New pod is injected with the revision selected by weight", "pod", klog.KObj(newPod), "sidecarSet", klog.KObj(sidecarSet),
+				"weight", revisionInfo.Weight, "latest", suitableSidecarSet != specificHistory)
+			return suitableSidecarSet, nil
 		default: // Always strategy
 			return specificHistory, nil
 		}
@@ -279,6 +290,31 @@ func (h *PodCreateHandler) selectRevisionRandomly(old, new *appsv1beta1.SidecarS
 	}
 }
 
+// selectRevisionByWeight selects 'new' for the pods whose owner hashes into the weight, otherwise 'old'.
+// The same owner always gets the same result for the same SidecarSet, and a larger weight selects a superset of owners.
+func selectRevisionByWeight(old, new *appsv1beta1.SidecarSet, weight *int32, pod *corev1.Pod) *appsv1beta1.SidecarSet {
+	if weight == nil || *weight <= 0 {
+		return old
+	}
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(new.Name + "/" + getPodOwnerKey(pod)))
+	if int32(hasher.Sum32()%100) < *weight {
+		return new
+	}
+	return old
+}
+
+// getPodOwnerKey returns the uid of the controller owner, or the name of the pod without owner.
+func getPodOwnerKey(pod *corev1.Pod) string {
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		return string(owner.UID)
+	}
+	if pod.Name != "" {
+		return pod.Namespace + "/" + pod.Name
+	}
+	return pod.Namespace + "/" + pod.GenerateName
+}
+
 func (h *PodCreateHandler) getSpecificRevisionSidecarSetForPod(sidecarSet *appsv1beta1.SidecarSet, revisions []*apps.ControllerRevision, pod *corev1.Pod) (*appsv1beta1.SidecarSet, error) {
 	var err error
 	var matchedSidecarSet *appsv1beta1.SidecarSet
@@ -569,6 +605,13 @@ func buildSidecars(isUpdated bool, pod *corev1.Pod, oldPod *corev1.Pod, matchedS
 			sidecarContainer.VolumeDevices = util.MergeVolumeDevices(sidecarContainer.Container, injectedDevices)
 			klog.InfoS("try to inject Container sidecar",
 				"containerName", sidecarContainer.Name, "namespace", pod.Namespace, "podName", pod.Name, "envs", transferEnvs, "volumeMounts", injectedMounts, "volumeDevices", injectedDevices)
+			// inject the sidecar container as native sidecar, which is an initContainer with restartPolicy Always
+			if sidecarcontrol.IsNativeSidecarInjection(sidecarSet) {
+				restartPolicy := corev1.ContainerRestartPolicyAlways
+				sidecarContainer.RestartPolicy = &restartPolicy
+				sidecarInitContainers = append(sidecarInitContainers, sidecarContainer)
+				continue
+			}
 			// when sidecar container UpgradeStrategy is HotUpgrade
 			if sidecarcontrol.IsHotUpgradeContainer(sidecarContainer) {
 				hotContainers, annotations := injectHotUpgradeContainers(hotUpgradeWorkInfo, sidecarContainer)
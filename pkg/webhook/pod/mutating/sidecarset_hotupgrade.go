@@ -58,7 +58,10 @@ func generateHotUpgradeContainers(container *appsv1beta1.SidecarContainer) (*app
 	container1.Name = name1
 	container2.Name = name2
 	// set the non-working hot upgrade container image to empty, first is container2
-	container2.Container.Image = container.UpgradeStrategy.HotUpgradeEmptyImage
+	// with handoff probe, container2 is the standby with the same image, and it is told by SIDECARSET_VERSION "0"
+	if !sidecarcontrol.IsHotUpgradeWithHandoff(container) {
+		container2.Container.Image = container.UpgradeStrategy.HotUpgradeEmptyImage
+	}
 	// set sidecarset.version in container env
 	setSidecarContainerVersionEnv(&container1.Container)
 	setSidecarContainerVersionEnv(&container2.Container)
@@ -21,7 +21,9 @@ import (
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -39,6 +41,22 @@ func TestInjectHotUpgradeSidecar(t *testing.T) {
 	testInjectHotUpgradeSidecar(t, sidecarSetIn)
 }
 
+func TestGenerateHotUpgradeContainersWithHandoff(t *testing.T) {
+	sidecarContainer := sidecarSet1.Spec.Containers[0].DeepCopy()
+	sidecarContainer.UpgradeStrategy.UpgradeType = appsv1beta1.SidecarContainerHotUpgrade
+	sidecarContainer.UpgradeStrategy.HandoffProbe = &appsv1beta1.SidecarContainerHandoffProbe{
+		HTTPGet: corev1.HTTPGetAction{Path: "/handoff", Port: intstr.FromInt32(8080)},
+	}
+	container1, container2 := generateHotUpgradeContainers(sidecarContainer)
+	if container1.Name != "dns-f-1" || container2.Name != "dns-f-2" {
+		t.Fatalf("expect containers dns-f-1 and dns-f-2, but got %s and %s", container1.Name, container2.Name)
+	}
+	// the standby container is started with the same image instead of the empty image
+	if container1.Image != sidecarContainer.Image || container2.Image != sidecarContainer.Image {
+		t.Fatalf("expect image %s, but got %s and %s", sidecarContainer.Image, container1.Image, container2.Image)
+	}
+}
+
 func testInjectHotUpgradeSidecar(t *testing.T, sidecarSetIn *appsv1beta1.SidecarSet) {
 	podIn := pod1.DeepCopy()
 	decoder := admission.NewDecoder(scheme.Scheme)
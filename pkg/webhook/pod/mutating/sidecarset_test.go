@@ -31,9 +31,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -661,6 +663,83 @@ func testInjectionStrategyRevision(t *testing.T, env []client.Object) {
 	}
 }
 
+func TestSelectRevisionByWeight(t *testing.T) {
+	old := &appsv1beta1.SidecarSet{ObjectMeta: metav1.ObjectMeta{Name: "sidecarset1", ResourceVersion: "1"}}
+	latest := &appsv1beta1.SidecarSet{ObjectMeta: metav1.ObjectMeta{Name: "sidecarset1", ResourceVersion: "2"}}
+	newOwnedPod := func(i int) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			GenerateName:    "app-",
+			OwnerReferences: []metav1.OwnerReference{{Controller: ptr.To(true), UID: types.UID(fmt.Sprintf("uid-%d", i))}},
+		}}
+	}
+
+	var selected10, selected50 int
+	for i := 0; i < 1000; i++ {
+		pod := newOwnedPod(i)
+		in10 := selectRevisionByWeight(old, latest, ptr.To[int32](10), pod) == latest
+		in50 := selectRevisionByWeight(old, latest, ptr.To[int32](50), pod) == latest
+		if in10 && !in50 {
+			t.Fatalf("expected owner %d selected by weight 10 also selected by weight 50", i)
+		}
+		if in10 != (selectRevisionByWeight(old, latest, ptr.To[int32](10), newOwnedPod(i)) == latest) {
+			t.Fatalf("expected the same result for the pods of owner %d", i)
+		}
+		if in10 {
+			selected10++
+		}
+		if in50 {
+			selected50++
+		}
+	}
+	if selected10 < 50 || selected10 > 150 {
+		t.Fatalf("expected about 10%% owners selected, got %d", selected10)
+	}
+	if selected50 < 400 || selected50 > 600 {
+		t.Fatalf("expected about 50%% owners selected, got %d", selected50)
+	}
+
+	if got := selectRevisionByWeight(old, latest, nil, newOwnedPod(0)); got != old {
+		t.Fatalf("expected old revision without weight")
+	}
+	if got := selectRevisionByWeight(old, latest, ptr.To[int32](100), &corev1.Pod{}); got != latest {
+		t.Fatalf("expected latest revision with weight 100")
+	}
+}
+
+func TestNativeSidecarInjection(t *testing.T) {
+	sidecarSetIn := sidecarSet1.DeepCopy()
+	sidecarSetIn.Spec.InjectionStrategy.NativeSidecar = true
+	podIn := pod1.DeepCopy()
+	decoder := admission.NewDecoder(scheme.Scheme)
+	c := fake.NewClientBuilder().WithObjects(sidecarSetIn).WithIndex(
+		&appsv1beta1.SidecarSet{}, fieldindex.IndexNameForSidecarSetNamespace, fieldindex.IndexSidecarSetV1Beta1,
+	).Build()
+	podOut := podIn.DeepCopy()
+	podHandler := &PodCreateHandler{Decoder: decoder, Client: c}
+	req := newAdmission(admissionv1.Create, runtime.RawExtension{}, runtime.RawExtension{}, "")
+	if _, err := podHandler.sidecarsetMutatingPod(context.Background(), req, podOut); err != nil {
+		t.Fatalf("inject sidecar into pod failed, err: %v", err)
+	}
+
+	if len(podOut.Spec.Containers) != 1 || podOut.Spec.Containers[0].Name != "nginx" {
+		t.Fatalf("expect only app container nginx, but got %v", podOut.Spec.Containers)
+	}
+	// the native sidecars are placed by podInjectPolicy, and after the normal initContainers of sidecarSet
+	expectInitContainers := []string{"dns-f", "init-0", "init-1", "init-2", "log-agent"}
+	var initContainers []string
+	for _, container := range podOut.Spec.InitContainers {
+		initContainers = append(initContainers, container.Name)
+		native := container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways
+		if expectNative := container.Name == "dns-f" || container.Name == "log-agent"; native != expectNative {
+			t.Fatalf("expect initContainer %s native sidecar %v, but got %v", container.Name, expectNative, native)
+		}
+	}
+	if !reflect.DeepEqual(initContainers, expectInitContainers) {
+		t.Fatalf("expect initContainers %v, but got %v", expectInitContainers, initContainers)
+	}
+}
+
 func TestSidecarSetPodInjectPolicy(t *testing.T) {
 	sidecarSetIn := sidecarSet1.DeepCopy()
 	testSidecarSetPodInjectPolicy(t, sidecarSetIn)
@@ -849,6 +928,28 @@ func TestCanarySidecarSetInjection(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name:   "weighted with weight 100",
+			getPod: stablePod.DeepCopy,
+			getSidecarSet: func() *appsv1beta1.SidecarSet {
+				ss := sidecarSet.DeepCopy()
+				ss.Spec.InjectionStrategy.Revision.Policy = appsv1beta1.WeightedSidecarSetInjectRevisionPolicy
+				ss.Spec.InjectionStrategy.Revision.Weight = ptr.To[int32](100)
+				return ss
+			},
+			expectImage: canaryImage,
+		},
+		{
+			name:   "weighted with weight 0",
+			getPod: canaryPod.DeepCopy,
+			getSidecarSet: func() *appsv1beta1.SidecarSet {
+				ss := sidecarSet.DeepCopy()
+				ss.Spec.InjectionStrategy.Revision.Policy = appsv1beta1.WeightedSidecarSetInjectRevisionPolicy
+				ss.Spec.InjectionStrategy.Revision.Weight = ptr.To[int32](0)
+				return ss
+			},
+			expectImage: stableImage,
+		},
 		{
 			name:   "canary paused",
 			getPod: canaryPod.DeepCopy,
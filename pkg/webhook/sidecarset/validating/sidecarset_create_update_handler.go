@@ -76,12 +76,17 @@ type SidecarSetCreateUpdateHandler struct {
 	Decoder admission.Decoder
 }
 
-func (h *SidecarSetCreateUpdateHandler) validatingSidecarSetFn(_ context.Context, obj *appsv1beta1.SidecarSet, older *appsv1beta1.SidecarSet) (bool, string, error) {
+func (h *SidecarSetCreateUpdateHandler) validatingSidecarSetFn(ctx context.Context, obj *appsv1beta1.SidecarSet, older *appsv1beta1.SidecarSet) (bool, string, admission.Warnings, error) {
 	allErrs := h.validateSidecarSet(obj, older)
+	var warnings admission.Warnings
+	// preview the impact of selector changes, only if the SidecarSet is valid
+	if len(allErrs) == 0 && older != nil {
+		warnings, allErrs = h.validateSelectorImpact(ctx, obj, older)
+	}
 	if len(allErrs) != 0 {
-		return false, "", allErrs.ToAggregate()
+		return false, "", warnings, allErrs.ToAggregate()
 	}
-	return true, "allowed to be admitted", nil
+	return true, "allowed to be admitted", warnings, nil
 }
 
 func (h *SidecarSetCreateUpdateHandler) validateSidecarSet(obj *appsv1beta1.SidecarSet, older *appsv1beta1.SidecarSet) field.ErrorList {
@@ -159,10 +164,19 @@ func validateSidecarSetName(name string, _ bool) (allErrs []string) {
 func (h *SidecarSetCreateUpdateHandler) validateSidecarSetSpec(obj *appsv1beta1.SidecarSet, fldPath *field.Path) field.ErrorList {
 	spec := &obj.Spec
 	allErrs := field.ErrorList{}
-	// currently when initContainer restartPolicy = Always, kruise don't support in-place update
+	// currently kruise don't support hot upgrade for native sidecars, which are initContainers with restartPolicy = Always
 	for _, c := range obj.Spec.InitContainers {
-		if sidecarcontrol.IsSidecarContainer(c.Container) && obj.Spec.UpdateStrategy.Type == appsv1beta1.RollingUpdateSidecarSetStrategyType {
-			allErrs = append(allErrs, field.Required(fldPath.Child("updateStrategy"), "The initContainer in-place upgrade is not currently supported."))
+		if sidecarcontrol.IsSidecarContainer(c.Container) && sidecarcontrol.IsHotUpgradeContainer(&c) &&
+			obj.Spec.UpdateStrategy.Type == appsv1beta1.RollingUpdateSidecarSetStrategyType {
+			allErrs = append(allErrs, field.Required(fldPath.Child("updateStrategy"), "The native sidecar hot upgrade is not currently supported."))
+		}
+	}
+	if obj.Spec.InjectionStrategy.NativeSidecar {
+		for i := range obj.Spec.Containers {
+			if sidecarcontrol.IsHotUpgradeContainer(&obj.Spec.Containers[i]) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("containers").Index(i).Child("upgradeStrategy"), obj.Spec.Containers[i].UpgradeStrategy,
+					"hot upgrade is not supported for containers injected as native sidecars"))
+			}
 		}
 	}
 
@@ -247,9 +261,19 @@ func (h *SidecarSetCreateUpdateHandler) validateSidecarSetInjectionStrategy(obj
 
 		switch revisionInfo.Policy {
 		case "", appsv1beta1.AlwaysSidecarSetInjectRevisionPolicy, appsv1beta1.PartialSidecarSetInjectRevisionPolicy:
+			if revisionInfo.Weight != nil {
+				errList = append(errList, field.Invalid(field.NewPath("revision").Child("weight"), *revisionInfo.Weight,
+					fmt.Sprintf("weight only works with %s policy", appsv1beta1.WeightedSidecarSetInjectRevisionPolicy)))
+			}
+		case appsv1beta1.WeightedSidecarSetInjectRevisionPolicy:
+			if revisionInfo.Weight == nil {
+				errList = append(errList, field.Required(field.NewPath("revision").Child("weight"), "weight is required for Weighted policy"))
+			} else if *revisionInfo.Weight < 0 || *revisionInfo.Weight > 100 {
+				errList = append(errList, field.Invalid(field.NewPath("revision").Child("weight"), *revisionInfo.Weight, "weight must be in the range 0-100"))
+			}
 		default:
-			errList = append(errList, field.Invalid(field.NewPath("revision").Child("policy"), revisionInfo, fmt.Sprintf("Invalid policy %v, supported: [%s, %s]",
-				revisionInfo.Policy, appsv1beta1.AlwaysSidecarSetInjectRevisionPolicy, appsv1beta1.PartialSidecarSetInjectRevisionPolicy)))
+			errList = append(errList, field.Invalid(field.NewPath("revision").Child("policy"), revisionInfo, fmt.Sprintf("Invalid policy %v, supported: [%s, %s, %s]",
+				revisionInfo.Policy, appsv1beta1.AlwaysSidecarSetInjectRevisionPolicy, appsv1beta1.PartialSidecarSetInjectRevisionPolicy, appsv1beta1.WeightedSidecarSetInjectRevisionPolicy)))
 		}
 	}
 	return errList
@@ -290,6 +314,37 @@ func validateSidecarSetUpdateStrategy(strategy *appsv1beta1.SidecarSetUpdateStra
 				allErrs = append(allErrs, field.Required(fldPath.Child("scatterStrategy"), err.Error()))
 			}
 		}
+		allErrs = append(allErrs, validateNamespaceWaves(strategy, fldPath.Child("namespaceWaves"))...)
+	}
+	return allErrs
+}
+
+func validateNamespaceWaves(strategy *appsv1beta1.SidecarSetUpdateStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	namespaces := sets.NewString()
+	for i, wave := range strategy.NamespaceWaves {
+		idxPath := fldPath.Index(i)
+		if len(wave.Namespaces) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("namespaces"), "no namespace defined for the wave"))
+		}
+		for j, ns := range wave.Namespaces {
+			for _, msg := range validationutil.IsDNS1123Label(ns) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("namespaces").Index(j), ns, msg))
+			}
+			if namespaces.Has(ns) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("namespaces").Index(j), ns))
+			}
+			namespaces.Insert(ns)
+		}
+		if wave.Partition != nil {
+			if strategy.Selector != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("partition"), wave.Partition.String(), "Partition and Selector cannot be used together"))
+			}
+			allErrs = append(allErrs, appsvalidation.ValidatePositiveIntOrPercent(*(wave.Partition), idxPath.Child("partition"))...)
+		}
+		if wave.MaxUnavailable != nil {
+			allErrs = append(allErrs, appsvalidation.ValidatePositiveIntOrPercent(*(wave.MaxUnavailable), idxPath.Child("maxUnavailable"))...)
+		}
 	}
 	return allErrs
 }
@@ -333,6 +388,9 @@ func validateContainersForSidecarSet(
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("container").Child("shareVolumePolicy"), container.ShareVolumePolicy, "unsupported share volume policy"))
 		}
 		allErrs = append(allErrs, validateDownwardAPI(container.TransferEnv, idxPath.Child("transferEnv"))...)
+		if container.UpgradeStrategy.HandoffProbe != nil {
+			allErrs = append(allErrs, validateHandoffProbe(&container.UpgradeStrategy, idxPath.Child("upgradeStrategy"))...)
+		}
 
 		// Validate ResourcesPolicy if present
 		if container.ResourcesPolicy != nil {
@@ -379,6 +437,24 @@ func validateContainersForSidecarSet(
 	return allErrs
 }
 
+func validateHandoffProbe(strategy *appsv1beta1.SidecarContainerUpgradeStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if strategy.UpgradeType != appsv1beta1.SidecarContainerHotUpgrade {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("handoffProbe"), "handoffProbe is only supported in HotUpgrade"))
+	}
+	if strategy.HotUpgradeEmptyImage != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("hotUpgradeEmptyImage"), "hotUpgradeEmptyImage is not needed with handoffProbe"))
+	}
+	probe, probePath := strategy.HandoffProbe, fldPath.Child("handoffProbe")
+	allErrs = append(allErrs, webhookutil.ValidatePodHTTPGetAction(&probe.HTTPGet, probePath.Child("httpGet"))...)
+	allErrs = append(allErrs, corevalidation.ValidateNonnegativeField(int64(probe.TimeoutSeconds), probePath.Child("timeoutSeconds"))...)
+	if probe.TimeoutSeconds > util.MaxHTTPGetTimeoutSeconds {
+		allErrs = append(allErrs, field.Invalid(probePath.Child("timeoutSeconds"), probe.TimeoutSeconds, fmt.Sprintf("must be no more than %d", util.MaxHTTPGetTimeoutSeconds)))
+	}
+	allErrs = append(allErrs, corevalidation.ValidateNonnegativeField(int64(probe.PeriodSeconds), probePath.Child("periodSeconds"))...)
+	return allErrs
+}
+
 func validateSidecarContainerConflict(newContainers, oldContainers []appsv1beta1.SidecarContainer, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -694,11 +770,11 @@ func (h *SidecarSetCreateUpdateHandler) Handle(ctx context.Context, req admissio
 			return admission.Errored(http.StatusBadRequest, err)
 		}
 	}
-	allowed, reason, err := h.validatingSidecarSetFn(ctx, obj, oldSidecarSet)
+	allowed, reason, warnings, err := h.validatingSidecarSetFn(ctx, obj, oldSidecarSet)
 	if err != nil {
-		return admission.Errored(http.StatusInternalServerError, err)
+		return admission.Errored(http.StatusInternalServerError, err).WithWarnings(warnings...)
 	}
-	return admission.ValidationResponse(allowed, reason)
+	return admission.ValidationResponse(allowed, reason).WithWarnings(warnings...)
 }
 
 func (h *SidecarSetCreateUpdateHandler) decodeObject(req admission.Request, obj *appsv1beta1.SidecarSet) error {
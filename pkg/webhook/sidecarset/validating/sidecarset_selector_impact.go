@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/control/sidecarcontrol"
+	"github.com/openkruise/kruise/pkg/util/configuration"
+)
+
+// maxWorkloadsInWarnings is the maximum number of workloads listed in the warnings of each kind of impact.
+const maxWorkloadsInWarnings = 10
+
+// selectorImpactTimeout bounds the time of calculating the impact of selector changes in admission.
+const selectorImpactTimeout = 3 * time.Second
+
+// validateSelectorImpact previews the workloads that newly match or stop matching the SidecarSet after the change
+// of selector or namespaceSelector in the warnings, which can be checked by server-side dry-run without any change.
+// The change is rejected if the impact exceeds the limit configured in kruise-configuration.
+// If the impact can not be calculated in time or there are too many pods to examine, the change is rejected
+// when the limit is configured, otherwise it is admitted with a warning.
+func (h *SidecarSetCreateUpdateHandler) validateSelectorImpact(ctx context.Context, obj, older *appsv1beta1.SidecarSet) (admission.Warnings, field.ErrorList) {
+	if !sidecarcontrol.IsSelectorChanged(older, obj) {
+		return nil, nil
+	}
+	fldPath := field.NewPath("spec", "selector")
+	limit, err := configuration.GetSidecarSetSelectorImpactLimit(h.Client)
+	if err != nil {
+		return nil, field.ErrorList{field.InternalError(fldPath, fmt.Errorf("get %s failed: %v", configuration.SidecarSetSelectorImpactLimitKey, err))}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, selectorImpactTimeout)
+	defer cancel()
+	impact, err := sidecarcontrol.CalculateSelectorImpact(ctx, h.Client, older, obj)
+	if err != nil {
+		klog.ErrorS(err, "Failed to calculate the impact of selector change", "sidecarSet", klog.KObj(obj))
+		if limit != nil {
+			return nil, field.ErrorList{field.Forbidden(fldPath, fmt.Sprintf("the impact of selector change can not be checked against %s: %v",
+				configuration.SidecarSetSelectorImpactLimitKey, err))}
+		}
+		return admission.Warnings{fmt.Sprintf("the impact of selector change is not previewed: %v", err)}, nil
+	}
+	warnings := getSelectorImpactWarnings(impact)
+	if limit == nil {
+		return warnings, nil
+	}
+	allErrs := field.ErrorList{}
+	if limit.MaxAffectedPods > 0 && impact.AffectedPods() > limit.MaxAffectedPods {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("the change of selector affects %d pods, which exceeds the limit %d",
+			impact.AffectedPods(), limit.MaxAffectedPods)))
+	}
+	if limit.MaxAffectedWorkloads > 0 && impact.AffectedWorkloads() > limit.MaxAffectedWorkloads {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("the change of selector affects %d workloads, which exceeds the limit %d",
+			impact.AffectedWorkloads(), limit.MaxAffectedWorkloads)))
+	}
+	return warnings, allErrs
+}
+
+func getSelectorImpactWarnings(impact *sidecarcontrol.SelectorImpact) admission.Warnings {
+	if impact.AffectedWorkloads() == 0 {
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, item := range []struct {
+		action    string
+		workloads []sidecarcontrol.WorkloadPods
+	}{
+		{action: "newly match", workloads: impact.NewlyMatched},
+		{action: "stop matching", workloads: impact.Unmatched},
+	} {
+		if len(item.workloads) == 0 {
+			continue
+		}
+		var pods int
+		for _, w := range item.workloads {
+			pods += w.Pods
+		}
+		warnings = append(warnings, fmt.Sprintf("%d pods in %d workloads will %s the SidecarSet when they are recreated", pods, len(item.workloads), item.action))
+		for i, w := range item.workloads {
+			if i == maxWorkloadsInWarnings {
+				warnings = append(warnings, fmt.Sprintf("%s: and %d more workloads", item.action, len(item.workloads)-i))
+				break
+			}
+			warnings = append(warnings, fmt.Sprintf("%s: %s", item.action, w))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/configuration"
+)
+
+func TestValidateSelectorImpact(t *testing.T) {
+	newSidecarSet := func(app string) *appsv1beta1.SidecarSet {
+		return &appsv1beta1.SidecarSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "sidecarset-test"},
+			Spec: appsv1beta1.SidecarSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			},
+		}
+	}
+	pods := func() []client.Object {
+		var objs []client.Object
+		for i := 0; i < 12; i++ {
+			objs = append(objs, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      fmt.Sprintf("nginx-%d", i),
+					Labels:    map[string]string{"app": "nginx"},
+					OwnerReferences: []metav1.OwnerReference{
+						{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: fmt.Sprintf("nginx-%d", i), UID: "uid", Controller: ptr.To(true)},
+					},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			})
+		}
+		return objs
+	}
+	newLimit := func(limit string) client.Object {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: util.GetKruiseNamespace(), Name: configuration.KruiseConfigurationName},
+			Data:       map[string]string{configuration.SidecarSetSelectorImpactLimitKey: limit},
+		}
+	}
+
+	cases := []struct {
+		name          string
+		oldSidecarSet *appsv1beta1.SidecarSet
+		newSidecarSet *appsv1beta1.SidecarSet
+		limit         client.Object
+		cancelled     bool
+		expectWarns   int
+		expectErrs    int
+	}{
+		{
+			name:          "selector not changed",
+			oldSidecarSet: newSidecarSet("nginx"),
+			newSidecarSet: newSidecarSet("nginx"),
+			limit:         newLimit(`{"maxAffectedPods":1}`),
+		},
+		{
+			name:          "selector changed without limit",
+			oldSidecarSet: newSidecarSet("nginx"),
+			newSidecarSet: newSidecarSet("redis"),
+			// summary + 10 workloads + "and 2 more"
+			expectWarns: 12,
+		},
+		{
+			name:          "selector changed under limit",
+			oldSidecarSet: newSidecarSet("nginx"),
+			newSidecarSet: newSidecarSet("redis"),
+			limit:         newLimit(`{"maxAffectedPods":12,"maxAffectedWorkloads":12}`),
+			expectWarns:   12,
+		},
+		{
+			name:          "selector changed over limit",
+			oldSidecarSet: newSidecarSet("nginx"),
+			newSidecarSet: newSidecarSet("redis"),
+			limit:         newLimit(`{"maxAffectedPods":10,"maxAffectedWorkloads":11}`),
+			expectWarns:   12,
+			expectErrs:    2,
+		},
+		{
+			name:          "selector changed but not previewed in time",
+			oldSidecarSet: newSidecarSet("nginx"),
+			newSidecarSet: newSidecarSet("redis"),
+			limit:         newLimit(`{"maxAffectedPods":10,"maxAffectedWorkloads":11}`),
+			cancelled:     true,
+			expectErrs:    1,
+		},
+		{
+			name:          "selector changed but not previewed in time without limit",
+			oldSidecarSet: newSidecarSet("nginx"),
+			newSidecarSet: newSidecarSet("redis"),
+			cancelled:     true,
+			expectWarns:   1,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			objs := pods()
+			if cs.limit != nil {
+				objs = append(objs, cs.limit)
+			}
+			h := &SidecarSetCreateUpdateHandler{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(objs...).Build()}
+			ctx, cancel := context.WithCancel(context.TODO())
+			if cs.cancelled {
+				cancel()
+			}
+			defer cancel()
+			warnings, errs := h.validateSelectorImpact(ctx, cs.newSidecarSet, cs.oldSidecarSet)
+			if len(warnings) != cs.expectWarns {
+				t.Fatalf("expect %d warnings, but got %d: %v", cs.expectWarns, len(warnings), warnings)
+			}
+			if len(errs) != cs.expectErrs {
+				t.Fatalf("expect %d errors, but got %d: %v", cs.expectErrs, len(errs), errs)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
@@ -649,7 +650,264 @@ func TestValidateSidecarSet(t *testing.T) {
 			expectErrs: 1,
 		},
 		{
-			caseName: "The initContainer in-place upgrade is not currently supported.",
+			caseName: "weighted-injectionStrategy-without-weight",
+			sidecarSet: appsv1beta1.SidecarSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset"},
+				Spec: appsv1beta1.SidecarSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"a": "b"},
+					},
+					InjectionStrategy: appsv1beta1.SidecarSetInjectionStrategy{
+						Revision: &appsv1beta1.SidecarSetInjectRevision{
+							RevisionName: pointer.String("test-sidecarset-678235"),
+							Policy:       appsv1beta1.WeightedSidecarSetInjectRevisionPolicy,
+						},
+					},
+					UpdateStrategy: appsv1beta1.SidecarSetUpdateStrategy{
+						Type: appsv1beta1.NotUpdateSidecarSetStrategyType,
+					},
+					Containers: []appsv1beta1.SidecarContainer{
+						{
+							PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+							ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+								Type: appsv1beta1.ShareVolumePolicyDisabled,
+							},
+							UpgradeStrategy: appsv1beta1.SidecarContainerUpgradeStrategy{
+								UpgradeType: appsv1beta1.SidecarContainerColdUpgrade,
+							},
+							Container: corev1.Container{
+								Name:                     "test-sidecar",
+								Image:                    "test-image",
+								ImagePullPolicy:          corev1.PullIfNotPresent,
+								TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							},
+						},
+					},
+				},
+			},
+			expectErrs: 2,
+		},
+		{
+			caseName: "weight-with-always-injectionStrategy",
+			sidecarSet: appsv1beta1.SidecarSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset"},
+				Spec: appsv1beta1.SidecarSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"a": "b"},
+					},
+					InjectionStrategy: appsv1beta1.SidecarSetInjectionStrategy{
+						Revision: &appsv1beta1.SidecarSetInjectRevision{
+							RevisionName: pointer.String("test-sidecarset-678235"),
+							Policy:       appsv1beta1.AlwaysSidecarSetInjectRevisionPolicy,
+							Weight:       pointer.Int32(10),
+						},
+					},
+					UpdateStrategy: appsv1beta1.SidecarSetUpdateStrategy{
+						Type: appsv1beta1.NotUpdateSidecarSetStrategyType,
+					},
+					Containers: []appsv1beta1.SidecarContainer{
+						{
+							PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+							ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+								Type: appsv1beta1.ShareVolumePolicyDisabled,
+							},
+							UpgradeStrategy: appsv1beta1.SidecarContainerUpgradeStrategy{
+								UpgradeType: appsv1beta1.SidecarContainerColdUpgrade,
+							},
+							Container: corev1.Container{
+								Name:                     "test-sidecar",
+								Image:                    "test-image",
+								ImagePullPolicy:          corev1.PullIfNotPresent,
+								TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							},
+						},
+					},
+				},
+			},
+			expectErrs: 2,
+		},
+		{
+			caseName: "hot-upgrade-with-handoff-probe",
+			sidecarSet: appsv1beta1.SidecarSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset"},
+				Spec: appsv1beta1.SidecarSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"a": "b"},
+					},
+					UpdateStrategy: appsv1beta1.SidecarSetUpdateStrategy{
+						Type: appsv1beta1.RollingUpdateSidecarSetStrategyType,
+					},
+					Containers: []appsv1beta1.SidecarContainer{
+						{
+							PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+							ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+								Type: appsv1beta1.ShareVolumePolicyDisabled,
+							},
+							UpgradeStrategy: appsv1beta1.SidecarContainerUpgradeStrategy{
+								UpgradeType: appsv1beta1.SidecarContainerHotUpgrade,
+								HandoffProbe: &appsv1beta1.SidecarContainerHandoffProbe{
+									HTTPGet: corev1.HTTPGetAction{Path: "/handoff", Port: intstr.FromInt32(8080)},
+								},
+							},
+							Container: corev1.Container{
+								Name:                     "test-sidecar",
+								Image:                    "test-image",
+								ImagePullPolicy:          corev1.PullIfNotPresent,
+								TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							},
+						},
+					},
+				},
+			},
+			expectErrs: 0,
+		},
+		{
+			caseName: "handoff-probe-with-empty-image-host-and-long-timeout",
+			sidecarSet: appsv1beta1.SidecarSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset"},
+				Spec: appsv1beta1.SidecarSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"a": "b"},
+					},
+					UpdateStrategy: appsv1beta1.SidecarSetUpdateStrategy{
+						Type: appsv1beta1.RollingUpdateSidecarSetStrategyType,
+					},
+					Containers: []appsv1beta1.SidecarContainer{
+						{
+							PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+							ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+								Type: appsv1beta1.ShareVolumePolicyDisabled,
+							},
+							UpgradeStrategy: appsv1beta1.SidecarContainerUpgradeStrategy{
+								UpgradeType:          appsv1beta1.SidecarContainerHotUpgrade,
+								HotUpgradeEmptyImage: "test-image:empty",
+								HandoffProbe: &appsv1beta1.SidecarContainerHandoffProbe{
+									HTTPGet:        corev1.HTTPGetAction{Host: "127.0.0.1", Path: "/handoff", Port: intstr.FromInt32(8080)},
+									TimeoutSeconds: 60,
+								},
+							},
+							Container: corev1.Container{
+								Name:                     "test-sidecar",
+								Image:                    "test-image",
+								ImagePullPolicy:          corev1.PullIfNotPresent,
+								TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							},
+						},
+					},
+				},
+			},
+			expectErrs: 3,
+		},
+		{
+			caseName: "handoff-probe-in-cold-upgrade",
+			sidecarSet: appsv1beta1.SidecarSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset"},
+				Spec: appsv1beta1.SidecarSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"a": "b"},
+					},
+					UpdateStrategy: appsv1beta1.SidecarSetUpdateStrategy{
+						Type: appsv1beta1.RollingUpdateSidecarSetStrategyType,
+					},
+					Containers: []appsv1beta1.SidecarContainer{
+						{
+							PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+							ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+								Type: appsv1beta1.ShareVolumePolicyDisabled,
+							},
+							UpgradeStrategy: appsv1beta1.SidecarContainerUpgradeStrategy{
+								UpgradeType: appsv1beta1.SidecarContainerColdUpgrade,
+								HandoffProbe: &appsv1beta1.SidecarContainerHandoffProbe{
+									HTTPGet: corev1.HTTPGetAction{Path: "/handoff", Port: intstr.FromInt32(8080)},
+								},
+							},
+							Container: corev1.Container{
+								Name:                     "test-sidecar",
+								Image:                    "test-image",
+								ImagePullPolicy:          corev1.PullIfNotPresent,
+								TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							},
+						},
+					},
+				},
+			},
+			expectErrs: 1,
+		},
+		{
+			caseName: "namespace-waves",
+			sidecarSet: appsv1beta1.SidecarSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset"},
+				Spec: appsv1beta1.SidecarSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"a": "b"},
+					},
+					UpdateStrategy: appsv1beta1.SidecarSetUpdateStrategy{
+						Type: appsv1beta1.RollingUpdateSidecarSetStrategyType,
+						NamespaceWaves: []appsv1beta1.SidecarSetNamespaceWave{
+							{Namespaces: []string{"ns-canary"}, MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "50%"}},
+							{Namespaces: []string{"ns-a", "ns-b"}, Partition: &intstr.IntOrString{Type: intstr.Int, IntVal: 1}, Paused: true},
+						},
+					},
+					Containers: []appsv1beta1.SidecarContainer{
+						{
+							PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+							ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+								Type: appsv1beta1.ShareVolumePolicyDisabled,
+							},
+							UpgradeStrategy: appsv1beta1.SidecarContainerUpgradeStrategy{
+								UpgradeType: appsv1beta1.SidecarContainerColdUpgrade,
+							},
+							Container: corev1.Container{
+								Name:                     "test-sidecar",
+								Image:                    "test-image",
+								ImagePullPolicy:          corev1.PullIfNotPresent,
+								TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							},
+						},
+					},
+				},
+			},
+			expectErrs: 0,
+		},
+		{
+			caseName: "invalid-namespace-waves",
+			sidecarSet: appsv1beta1.SidecarSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset"},
+				Spec: appsv1beta1.SidecarSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"a": "b"},
+					},
+					UpdateStrategy: appsv1beta1.SidecarSetUpdateStrategy{
+						Type: appsv1beta1.RollingUpdateSidecarSetStrategyType,
+						NamespaceWaves: []appsv1beta1.SidecarSetNamespaceWave{
+							{Namespaces: []string{"ns-a", "Invalid_NS"}},
+							{Namespaces: []string{"ns-a"}, MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: -1}},
+							{},
+						},
+					},
+					Containers: []appsv1beta1.SidecarContainer{
+						{
+							PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+							ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+								Type: appsv1beta1.ShareVolumePolicyDisabled,
+							},
+							UpgradeStrategy: appsv1beta1.SidecarContainerUpgradeStrategy{
+								UpgradeType: appsv1beta1.SidecarContainerColdUpgrade,
+							},
+							Container: corev1.Container{
+								Name:                     "test-sidecar",
+								Image:                    "test-image",
+								ImagePullPolicy:          corev1.PullIfNotPresent,
+								TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							},
+						},
+					},
+				},
+			},
+			expectErrs: 4,
+		},
+		{
+			caseName: "native-sidecar-initContainer-in-place-upgrade",
 			sidecarSet: appsv1beta1.SidecarSet{
 				ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset"},
 				Spec: appsv1beta1.SidecarSetSpec{
@@ -679,6 +937,76 @@ func TestValidateSidecarSet(t *testing.T) {
 					},
 				},
 			},
+			expectErrs: 0,
+		},
+		{
+			caseName: "The native sidecar hot upgrade is not currently supported.",
+			sidecarSet: appsv1beta1.SidecarSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset"},
+				Spec: appsv1beta1.SidecarSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"a": "b"},
+					},
+					UpdateStrategy: appsv1beta1.SidecarSetUpdateStrategy{
+						Type: appsv1beta1.RollingUpdateSidecarSetStrategyType,
+					},
+					InitContainers: []appsv1beta1.SidecarContainer{
+						{
+							PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+							ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+								Type: appsv1beta1.ShareVolumePolicyDisabled,
+							},
+							UpgradeStrategy: appsv1beta1.SidecarContainerUpgradeStrategy{
+								UpgradeType:          appsv1beta1.SidecarContainerHotUpgrade,
+								HotUpgradeEmptyImage: "empty-image",
+							},
+							Container: corev1.Container{
+								Name:                     "test-sidecar",
+								Image:                    "test-image",
+								ImagePullPolicy:          corev1.PullIfNotPresent,
+								TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+								RestartPolicy:            &always,
+							},
+						},
+					},
+				},
+			},
+			expectErrs: 1,
+		},
+		{
+			caseName: "native-sidecar-injection-with-hot-upgrade",
+			sidecarSet: appsv1beta1.SidecarSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset"},
+				Spec: appsv1beta1.SidecarSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"a": "b"},
+					},
+					UpdateStrategy: appsv1beta1.SidecarSetUpdateStrategy{
+						Type: appsv1beta1.RollingUpdateSidecarSetStrategyType,
+					},
+					InjectionStrategy: appsv1beta1.SidecarSetInjectionStrategy{
+						NativeSidecar: true,
+					},
+					Containers: []appsv1beta1.SidecarContainer{
+						{
+							PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+							ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+								Type: appsv1beta1.ShareVolumePolicyDisabled,
+							},
+							UpgradeStrategy: appsv1beta1.SidecarContainerUpgradeStrategy{
+								UpgradeType:          appsv1beta1.SidecarContainerHotUpgrade,
+								HotUpgradeEmptyImage: "empty-image",
+							},
+							Container: corev1.Container{
+								Name:                     "test-sidecar",
+								Image:                    "test-image",
+								ImagePullPolicy:          corev1.PullIfNotPresent,
+								TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							},
+						},
+					},
+				},
+			},
 			expectErrs: 1,
 		},
 		// ResourcesPolicy validation test cases
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 
 	"github.com/appscode/jsonpatch"
 	apps "k8s.io/api/apps/v1"
@@ -14,6 +15,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	unversionedvalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	appsvalidation "k8s.io/kubernetes/pkg/apis/apps/validation"
 	apivalidation "k8s.io/kubernetes/pkg/apis/core/validation"
@@ -21,6 +24,7 @@ import (
 
 	appspub "github.com/openkruise/kruise/apis/apps/pub"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
 	apiutil "github.com/openkruise/kruise/pkg/util/api"
 	"github.com/openkruise/kruise/pkg/util/pvc"
 	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
@@ -73,6 +77,25 @@ func validateScaleStrategy(spec *appsv1beta1.StatefulSetSpec, fldPath *field.Pat
 		if spec.ScaleStrategy.MaxUnavailable != nil {
 			allErrs = append(allErrs, validateMaxUnavailableField(spec.ScaleStrategy.MaxUnavailable, spec, fldPath.Child("scaleStrategy").Child("maxUnavailable"))...)
 		}
+		if spec.ScaleStrategy.DrainHook != nil {
+			allErrs = append(allErrs, validateDrainHook(spec.ScaleStrategy.DrainHook, fldPath.Child("scaleStrategy").Child("drainHook"))...)
+		}
+	}
+	return allErrs
+}
+
+func validateDrainHook(hook *appsv1beta1.StatefulSetDrainHook, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, webhookutil.ValidatePodHTTPGetAction(&hook.HTTPGet, fldPath.Child("httpGet"))...)
+	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(hook.TimeoutSeconds), fldPath.Child("timeoutSeconds"))...)
+	if hook.TimeoutSeconds > util.MaxHTTPGetTimeoutSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), hook.TimeoutSeconds,
+			fmt.Sprintf("must be no more than %d", util.MaxHTTPGetTimeoutSeconds)))
+	}
+	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(hook.PeriodSeconds), fldPath.Child("periodSeconds"))...)
+	if hook.MaxWaitSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxWaitSeconds"), hook.MaxWaitSeconds, "must be greater than 0"))
 	}
 	return allErrs
 }
@@ -161,6 +184,129 @@ func validateRollingUpdateStatefulSetStrategyType(spec *appsv1beta1.StatefulSetS
 		// validate the `spec.UpdateStrategy.RollingUpdate.UnorderedUpdate` related fields
 		allErrs = append(allErrs, validateRollingUpdateStatefulSetStrategyTypeUnorderedUpdate(spec, fldPath)...)
 
+		// validate the `spec.UpdateStrategy.RollingUpdate.TopologyUpdateConstraint` related fields
+		allErrs = append(allErrs, validateTopologyUpdateConstraint(spec, fldPath)...)
+	}
+	return allErrs
+}
+
+func validateTopologyUpdateConstraint(spec *appsv1beta1.StatefulSetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	constraint := spec.UpdateStrategy.RollingUpdate.TopologyUpdateConstraint
+	if constraint == nil {
+		return allErrs
+	}
+	constraintPath := fldPath.Child("updateStrategy").Child("rollingUpdate").Child("topologyUpdateConstraint")
+	if constraint.TopologyKey == "" {
+		allErrs = append(allErrs, field.Required(constraintPath.Child("topologyKey"), ""))
+	} else {
+		allErrs = append(allErrs, unversionedvalidation.ValidateLabelName(constraint.TopologyKey, constraintPath.Child("topologyKey"))...)
+	}
+	if constraint.MaxUnavailable != nil {
+		allErrs = append(allErrs, appsvalidation.ValidatePositiveIntOrPercent(*constraint.MaxUnavailable, constraintPath.Child("maxUnavailable"))...)
+		allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*constraint.MaxUnavailable, constraintPath.Child("maxUnavailable"))...)
+	}
+	return allErrs
+}
+
+func validateQuorumGuard(spec *appsv1beta1.StatefulSetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.QuorumGuard == nil || spec.QuorumGuard.Quorum == nil {
+		return allErrs
+	}
+	quorumPath := fldPath.Child("quorumGuard").Child("quorum")
+	allErrs = append(allErrs, appsvalidation.ValidatePositiveIntOrPercent(*spec.QuorumGuard.Quorum, quorumPath)...)
+	allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*spec.QuorumGuard.Quorum, quorumPath)...)
+	if quorum, err := intstr.GetScaledValueFromIntOrPercent(spec.QuorumGuard.Quorum, 1, true); err == nil && quorum < 1 {
+		allErrs = append(allErrs, field.Invalid(quorumPath, spec.QuorumGuard.Quorum.String(), "should not be less than 1"))
+	}
+	return allErrs
+}
+
+func validateOrdinalMigration(spec *appsv1beta1.StatefulSetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.OrdinalMigration == nil {
+		return allErrs
+	}
+	migrationPath := fldPath.Child("ordinalMigration")
+	if spec.OrdinalMigration.ReleaseTo == "" && spec.OrdinalMigration.AdoptFrom == "" {
+		allErrs = append(allErrs, field.Required(migrationPath, "either releaseTo or adoptFrom should be set"))
+	}
+	validatePeer := func(peer string, peerPath *field.Path) {
+		if peer == "" {
+			return
+		}
+		for _, msg := range appsvalidation.ValidateStatefulSetName(peer, false) {
+			allErrs = append(allErrs, field.Invalid(peerPath, peer, msg))
+		}
+	}
+	validatePeer(spec.OrdinalMigration.ReleaseTo, migrationPath.Child("releaseTo"))
+	validatePeer(spec.OrdinalMigration.AdoptFrom, migrationPath.Child("adoptFrom"))
+	if spec.OrdinalMigration.ReleaseTo != "" && spec.OrdinalMigration.ReleaseTo == spec.OrdinalMigration.AdoptFrom {
+		allErrs = append(allErrs, field.Invalid(migrationPath.Child("adoptFrom"), spec.OrdinalMigration.AdoptFrom, "should not be the same as releaseTo"))
+	}
+	return allErrs
+}
+
+func validateOrdinalPatches(spec *appsv1beta1.StatefulSetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(spec.OrdinalPatches) == 0 {
+		return allErrs
+	}
+	selector, err := metav1.LabelSelectorAsSelector(spec.Selector)
+	if err != nil {
+		// the selector has been validated by validateSpecSelector
+		return allErrs
+	}
+	templateBytes, _ := json.Marshal(spec.Template)
+	for _, key := range sets.List(sets.KeySet(spec.OrdinalPatches)) {
+		patch := spec.OrdinalPatches[key]
+		patchPath := fldPath.Child("ordinalPatches").Key(key)
+		if ordinal, err := strconv.Atoi(key); err != nil || ordinal < 0 {
+			allErrs = append(allErrs, field.Invalid(patchPath, key, "must be a non-negative integer"))
+			continue
+		}
+		modified, err := strategicpatch.StrategicMergePatch(templateBytes, patch.Raw, &v1.PodTemplateSpec{})
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(patchPath, string(patch.Raw), fmt.Sprintf("failed to merge patch: %v", err)))
+			continue
+		}
+		template := &v1.PodTemplateSpec{}
+		if err := json.Unmarshal(modified, template); err != nil {
+			allErrs = append(allErrs, field.Invalid(patchPath, string(patch.Raw), fmt.Sprintf("failed to unmarshal: %v", err)))
+			continue
+		}
+		coreTemplate, err := convertor.ConvertPodTemplateSpec(template)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(patchPath, string(patch.Raw), fmt.Sprintf("Convert_v1_PodTemplateSpec_To_core_PodTemplateSpec failed: %v", err)))
+			continue
+		}
+		allErrs = append(allErrs, appsvalidation.ValidatePodTemplateSpecForStatefulSet(coreTemplate, selector, patchPath, webhookutil.DefaultPodValidationOptions)...)
+	}
+	return allErrs
+}
+
+func validateVolumeClaimUpdateStrategy(spec *appsv1beta1.StatefulSetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	strategyPath := fldPath.Child("volumeClaimUpdateStrategy")
+	switch spec.VolumeClaimUpdateStrategy.DeletePolicy {
+	case "", appsv1beta1.NeverVolumeClaimDeletePolicyType:
+	case appsv1beta1.OnPodRollingUpdateVolumeClaimDeletePolicyType:
+		if spec.VolumeClaimUpdateStrategy.Type != appsv1beta1.OnPodRollingUpdateVolumeClaimUpdateStrategyType {
+			allErrs = append(allErrs, field.Invalid(strategyPath.Child("deletePolicy"), spec.VolumeClaimUpdateStrategy.DeletePolicy,
+				fmt.Sprintf("can only work with %s type", appsv1beta1.OnPodRollingUpdateVolumeClaimUpdateStrategyType)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(strategyPath.Child("deletePolicy"), spec.VolumeClaimUpdateStrategy.DeletePolicy,
+			[]string{string(appsv1beta1.NeverVolumeClaimDeletePolicyType), string(appsv1beta1.OnPodRollingUpdateVolumeClaimDeletePolicyType)}))
+	}
+	if hook := spec.VolumeClaimUpdateStrategy.PreDeleteHook; hook != nil {
+		allErrs = append(allErrs, unversionedvalidation.ValidateLabels(hook.LabelsHandler, strategyPath.Child("preDeleteHook", "labelsHandler"))...)
 	}
 	return allErrs
 }
@@ -238,6 +384,10 @@ func validateStatefulSetSpec(spec *appsv1beta1.StatefulSetSpec, fldPath *field.P
 	allErrs = append(allErrs, validateReserveOrdinals(spec, fldPath)...)
 	allErrs = append(allErrs, validateScaleStrategy(spec, fldPath)...)
 	allErrs = append(allErrs, validateUpdateStrategyType(spec, fldPath)...)
+	allErrs = append(allErrs, validateQuorumGuard(spec, fldPath)...)
+	allErrs = append(allErrs, validateOrdinalMigration(spec, fldPath)...)
+	allErrs = append(allErrs, validateOrdinalPatches(spec, fldPath)...)
+	allErrs = append(allErrs, validateVolumeClaimUpdateStrategy(spec, fldPath)...)
 	allErrs = append(allErrs, ValidatePersistentVolumeClaimRetentionPolicy(spec.PersistentVolumeClaimRetentionPolicy, fldPath.Child("persistentVolumeClaimRetentionPolicy"))...)
 
 	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(*spec.Replicas), fldPath.Child("replicas"))...)
@@ -337,9 +487,15 @@ func ValidateStatefulSetUpdate(statefulSet, oldStatefulSet *appsv1beta1.Stateful
 	statefulSet.Spec.Lifecycle = oldStatefulSet.Spec.Lifecycle
 	statefulSet.Spec.RevisionHistoryLimit = oldStatefulSet.Spec.RevisionHistoryLimit
 	statefulSet.Spec.Ordinals = oldStatefulSet.Spec.Ordinals
+	restoreQuorumGuard := statefulSet.Spec.QuorumGuard
+	statefulSet.Spec.QuorumGuard = oldStatefulSet.Spec.QuorumGuard
+	restoreOrdinalMigration := statefulSet.Spec.OrdinalMigration
+	statefulSet.Spec.OrdinalMigration = oldStatefulSet.Spec.OrdinalMigration
+	restoreOrdinalPatches := statefulSet.Spec.OrdinalPatches
+	statefulSet.Spec.OrdinalPatches = oldStatefulSet.Spec.OrdinalPatches
 
 	if !apiequality.Semantic.DeepEqual(statefulSet.Spec, oldStatefulSet.Spec) {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "updates to statefulset spec for fields other than 'replicas', 'ordinals', 'template', 'reserveOrdinals', 'lifecycle', 'revisionHistoryLimit', 'persistentVolumeClaimRetentionPolicy', `volumeClaimTemplates`, `VolumeClaimUpdateStrategy` and 'updateStrategy' are forbidden"))
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "updates to statefulset spec for fields other than 'replicas', 'ordinals', 'template', 'reserveOrdinals', 'lifecycle', 'revisionHistoryLimit', 'persistentVolumeClaimRetentionPolicy', `volumeClaimTemplates`, `VolumeClaimUpdateStrategy`, 'quorumGuard', 'ordinalMigration', 'ordinalPatches' and 'updateStrategy' are forbidden"))
 	}
 	statefulSet.Spec.Replicas = restoreReplicas
 	statefulSet.Spec.Template = restoreTemplate
@@ -348,6 +504,9 @@ func ValidateStatefulSetUpdate(statefulSet, oldStatefulSet *appsv1beta1.Stateful
 	statefulSet.Spec.ReserveOrdinals = restoreReserveOrdinals
 	statefulSet.Spec.VolumeClaimTemplates = restorePVCTemplate
 	statefulSet.Spec.PersistentVolumeClaimRetentionPolicy = restorePersistentVolumeClaimRetentionPolicy
+	statefulSet.Spec.QuorumGuard = restoreQuorumGuard
+	statefulSet.Spec.OrdinalMigration = restoreOrdinalMigration
+	statefulSet.Spec.OrdinalPatches = restoreOrdinalPatches
 
 	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(*statefulSet.Spec.Replicas), field.NewPath("spec", "replicas"))...)
 	allErrs = append(allErrs, ValidatePersistentVolumeClaimRetentionPolicy(statefulSet.Spec.PersistentVolumeClaimRetentionPolicy, field.NewPath("spec", "persistentVolumeClaimRetentionPolicy"))...)
@@ -399,6 +558,10 @@ func ValidateVolumeClaimTemplateUpdate(c client.Client, sts, oldSts *appsv1beta1
 			continue
 		}
 		if !resizeOnly {
+			// storageClassName or accessModes modified, the claims will be recreated
+			if sts.Spec.VolumeClaimUpdateStrategy.DeletePolicy == appsv1beta1.OnPodRollingUpdateVolumeClaimDeletePolicyType {
+				continue
+			}
 			return field.ErrorList{field.Invalid(field.NewPath("spec", templateIdStr), template, "volumeClaimTemplate can not be modified when OnRollingUpdate")}
 		}
 		// check if sc allow volume expand
@@ -546,6 +546,139 @@ func TestValidateStatefulSet(t *testing.T) {
 			},
 			expectedFields: []string{"spec.podManagementPolicy", "spec.template.metadata.labels", "spec.template.spec.activeDeadlineSeconds", "spec.template.spec.restartPolicy"},
 		},
+		{
+			name: "invalid topology update constraint",
+			statefulSet: appsv1beta1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "abc-123", Namespace: metav1.NamespaceDefault},
+				Spec: appsv1beta1.StatefulSetSpec{
+					PodManagementPolicy: apps.ParallelPodManagement,
+					Selector:            &metav1.LabelSelector{MatchLabels: validLabels},
+					Template:            validPodTemplate.Template,
+					Replicas:            &val3,
+					UpdateStrategy: appsv1beta1.StatefulSetUpdateStrategy{
+						Type: apps.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &appsv1beta1.RollingUpdateStatefulSetStrategy{
+							Partition:       &val2,
+							PodUpdatePolicy: appsv1beta1.RecreatePodUpdateStrategyType,
+							MaxUnavailable:  &maxUnavailable1,
+							MinReadySeconds: ptr.To[int32](0),
+							TopologyUpdateConstraint: &appsv1beta1.TopologyUpdateConstraint{
+								TopologyKey:    "invalid key!",
+								MaxUnavailable: ptr.To(intstr.FromString("150%")),
+							},
+						},
+					},
+				},
+			},
+			expectedFields: []string{"spec.updateStrategy.rollingUpdate.topologyUpdateConstraint.topologyKey", "spec.updateStrategy.rollingUpdate.topologyUpdateConstraint.maxUnavailable"},
+		},
+		{
+			name: "invalid quorum guard",
+			statefulSet: appsv1beta1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "abc-123", Namespace: metav1.NamespaceDefault},
+				Spec: appsv1beta1.StatefulSetSpec{
+					PodManagementPolicy: apps.ParallelPodManagement,
+					Selector:            &metav1.LabelSelector{MatchLabels: validLabels},
+					Template:            validPodTemplate.Template,
+					Replicas:            &val3,
+					UpdateStrategy: appsv1beta1.StatefulSetUpdateStrategy{
+						Type: apps.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &appsv1beta1.RollingUpdateStatefulSetStrategy{
+							Partition:       &val2,
+							PodUpdatePolicy: appsv1beta1.RecreatePodUpdateStrategyType,
+							MaxUnavailable:  &maxUnavailable1,
+							MinReadySeconds: ptr.To[int32](0),
+						},
+					},
+					QuorumGuard: &appsv1beta1.StatefulSetQuorumGuard{
+						Quorum: ptr.To(intstr.FromInt32(0)),
+					},
+				},
+			},
+			expectedFields: []string{"spec.quorumGuard.quorum"},
+		},
+		{
+			name: "invalid ordinal migration",
+			statefulSet: appsv1beta1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "abc-123", Namespace: metav1.NamespaceDefault},
+				Spec: appsv1beta1.StatefulSetSpec{
+					PodManagementPolicy: apps.ParallelPodManagement,
+					Selector:            &metav1.LabelSelector{MatchLabels: validLabels},
+					Template:            validPodTemplate.Template,
+					Replicas:            &val3,
+					UpdateStrategy: appsv1beta1.StatefulSetUpdateStrategy{
+						Type: apps.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &appsv1beta1.RollingUpdateStatefulSetStrategy{
+							Partition:       &val2,
+							PodUpdatePolicy: appsv1beta1.RecreatePodUpdateStrategyType,
+							MaxUnavailable:  &maxUnavailable1,
+							MinReadySeconds: ptr.To[int32](0),
+						},
+					},
+					OrdinalMigration: &appsv1beta1.StatefulSetOrdinalMigration{
+						ReleaseTo: "Invalid_Name",
+						AdoptFrom: "Invalid.Name-",
+					},
+				},
+			},
+			expectedFields: []string{"spec.ordinalMigration.releaseTo", "spec.ordinalMigration.adoptFrom"},
+		},
+		{
+			name: "invalid drain hook",
+			statefulSet: appsv1beta1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "abc-123", Namespace: metav1.NamespaceDefault},
+				Spec: appsv1beta1.StatefulSetSpec{
+					PodManagementPolicy: apps.ParallelPodManagement,
+					Selector:            &metav1.LabelSelector{MatchLabels: validLabels},
+					Template:            validPodTemplate.Template,
+					Replicas:            &val3,
+					UpdateStrategy: appsv1beta1.StatefulSetUpdateStrategy{
+						Type: apps.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &appsv1beta1.RollingUpdateStatefulSetStrategy{
+							Partition:       &val2,
+							PodUpdatePolicy: appsv1beta1.RecreatePodUpdateStrategyType,
+							MaxUnavailable:  &maxUnavailable1,
+							MinReadySeconds: ptr.To[int32](0),
+						},
+					},
+					ScaleStrategy: &appsv1beta1.StatefulSetScaleStrategy{
+						DrainHook: &appsv1beta1.StatefulSetDrainHook{
+							HTTPGet:        v1.HTTPGetAction{Host: "example.com", Path: "/drain", Port: intstr.FromInt32(0)},
+							TimeoutSeconds: 600,
+						},
+					},
+				},
+			},
+			expectedFields: []string{"spec.scaleStrategy.drainHook.httpGet.host", "spec.scaleStrategy.drainHook.httpGet.port",
+				"spec.scaleStrategy.drainHook.timeoutSeconds", "spec.scaleStrategy.drainHook.maxWaitSeconds"},
+		},
+		{
+			name: "invalid ordinal patches",
+			statefulSet: appsv1beta1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "abc-123", Namespace: metav1.NamespaceDefault},
+				Spec: appsv1beta1.StatefulSetSpec{
+					PodManagementPolicy: apps.ParallelPodManagement,
+					Selector:            &metav1.LabelSelector{MatchLabels: validLabels},
+					Template:            validPodTemplate.Template,
+					Replicas:            &val3,
+					UpdateStrategy: appsv1beta1.StatefulSetUpdateStrategy{
+						Type: apps.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &appsv1beta1.RollingUpdateStatefulSetStrategy{
+							Partition:       &val2,
+							PodUpdatePolicy: appsv1beta1.RecreatePodUpdateStrategyType,
+							MaxUnavailable:  &maxUnavailable1,
+							MinReadySeconds: ptr.To[int32](0),
+						},
+					},
+					OrdinalPatches: map[string]runtime.RawExtension{
+						"0": {Raw: []byte(`{"spec":{"nodeSelector":{"disk":"ssd"}}}`)},
+						"1": {Raw: []byte(`{"metadata":{"labels":{"a":"c"}}}`)},
+						"x": {Raw: []byte(`{}`)},
+					},
+				},
+			},
+			expectedFields: []string{"spec.ordinalPatches[1].metadata.labels", "spec.ordinalPatches[x]"},
+		},
 	}
 
 	for _, tc := range errorCases {
@@ -665,6 +798,8 @@ func TestValidateStatefulSetUpdate(t *testing.T) {
 						WhenScaled:  appsv1beta1.RetainPersistentVolumeClaimRetentionPolicyType,
 						WhenDeleted: appsv1beta1.RetainPersistentVolumeClaimRetentionPolicyType,
 					},
+					QuorumGuard:      &appsv1beta1.StatefulSetQuorumGuard{Quorum: ptr.To(intstr.FromInt32(3))},
+					OrdinalMigration: &appsv1beta1.StatefulSetOrdinalMigration{AdoptFrom: "foo"},
 				},
 			},
 		},
@@ -924,6 +1059,34 @@ func TestValidateVolumeClaimTemplateUpdate(t *testing.T) {
 			},
 			expectedErrors: true,
 		},
+		{
+			name: "on pod rolling update strategy with delete policy and change sc",
+			sts: &appsv1beta1.StatefulSet{
+				Spec: appsv1beta1.StatefulSetSpec{
+					VolumeClaimUpdateStrategy: appsv1beta1.VolumeClaimUpdateStrategy{
+						Type:         appsv1beta1.OnPodRollingUpdateVolumeClaimUpdateStrategyType,
+						DeletePolicy: appsv1beta1.OnPodRollingUpdateVolumeClaimDeletePolicyType,
+					},
+					VolumeClaimTemplates: []v1.PersistentVolumeClaim{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+							Spec:       v1.PersistentVolumeClaimSpec{StorageClassName: &allowExpandSC.Name},
+						},
+					},
+				},
+			},
+			oldSts: &appsv1beta1.StatefulSet{
+				Spec: appsv1beta1.StatefulSetSpec{
+					VolumeClaimTemplates: []v1.PersistentVolumeClaim{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+							Spec:       v1.PersistentVolumeClaimSpec{StorageClassName: &disallowExpandSC.Name},
+						},
+					},
+				},
+			},
+			expectedErrors: false,
+		},
 		{
 			name: "on pod rolling update strategy and expand size with expansion allowed sc",
 			sts: &appsv1beta1.StatefulSet{
@@ -16,7 +16,15 @@ limitations under the License.
 
 package util
 
-import corevalidation "k8s.io/kubernetes/pkg/apis/core/validation"
+import (
+	"net/url"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	corevalidation "k8s.io/kubernetes/pkg/apis/core/validation"
+)
 
 var (
 	DefaultPodValidationOptions = corevalidation.PodValidationOptions{
@@ -28,3 +36,35 @@ var (
 		ResourceIsPod: false,
 	}
 )
+
+// ValidatePodHTTPGetAction validates the HTTPGetAction which is performed on the pod IP by the controllers.
+func ValidatePodHTTPGetAction(action *v1.HTTPGetAction, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if action.Host != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("host"), "can only be called on the pod IP"))
+	}
+	if _, err := url.Parse(action.Path); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), action.Path, "must be a valid URL path"))
+	}
+	switch action.Port.Type {
+	case intstr.Int:
+		for _, msg := range validation.IsValidPortNum(action.Port.IntValue()) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), action.Port.IntValue(), msg))
+		}
+	case intstr.String:
+		for _, msg := range validation.IsValidPortName(action.Port.StrVal) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), action.Port.StrVal, msg))
+		}
+	}
+	switch action.Scheme {
+	case "", v1.URISchemeHTTP, v1.URISchemeHTTPS:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("scheme"), action.Scheme, []string{string(v1.URISchemeHTTP), string(v1.URISchemeHTTPS)}))
+	}
+	for _, header := range action.HTTPHeaders {
+		for _, msg := range validation.IsHTTPHeaderName(header.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("httpHeaders"), header.Name, msg))
+		}
+	}
+	return allErrs
+}